@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 	"goselect/parser/context"
+	"strings"
 )
 
 func newDescribeCommand() *cobra.Command {
@@ -50,6 +52,9 @@ func newDescribeCommand() *cobra.Command {
 				return
 			}
 			cmd.Println(errorColor, ErrorMessageInvalidTerm)
+			if suggestions := context.NewContext(functions, attributes).SuggestionsFor(lookFor); len(suggestions) > 0 {
+				cmd.Println(errorColor, fmt.Sprintf(ErrorMessageDidYouMean, strings.Join(suggestions, " or ")))
+			}
 		},
 	}
 }
@@ -3,7 +3,10 @@ package cmd
 const (
 	ErrorMessageEmptyTerm                      = "expected term to be non-empty. please use --term=<attribute> or --term=<function>"
 	ErrorMessageInvalidTerm                    = "expected term to be one of the supported attributes or a function"
+	ErrorMessageDidYouMean                     = "did you mean %v?"
 	ErrorMessageInvalidExportFormat            = "expected export format to be one of the supported exported formats: %v"
 	ErrorMessageAttemptedToExportTableToFile   = "table can not be exported to a file"
 	ErrorMessageExpectedFilePathToBeADirectory = "expected file path to be a directory"
+	ErrorMessageInvalidParameter               = "expected a parameter as <name>=<value> or <position>=<value>, received %v"
+	ErrorMessageInvalidTimeFormat              = "expected a date/time format as <id>=<pattern>, received %v"
 )
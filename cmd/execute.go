@@ -19,40 +19,26 @@ func newExecuteCommand() *cobra.Command {
 		Use:     "execute",
 		Aliases: []string{"ex"},
 		Short:   "Execute a select query",
-		Long:    `Execute a select query. Select query syntax: select <attributes> from <source directory> [where <condition>] [order by] [limit]`,
+		Long:    `Execute a select query. Select query syntax: select <attributes> from <source directory>[, <source directory>...] [where <condition>] [group by] [having <condition>] [order by] [limit [offset]]`,
 		Example: `
 1. goselect execute -q='select filename, absolutepath from .'
 2. goselect ex -q='select name, size, extension from . where like(name, results.*) order by 2'
 3. goselect ex -q='select name, size, extension from . where or(like(name, results.*), gt(size, 2048)) order by 2 limit 5'
+4. goselect ex -q='select name, size from . where eq(name, :name) or gt(size, ?)' --param name='report (1),final.pdf' --param 1=10mb
+5. goselect ex -q='select name, size from .' --strict
+6. goselect ex -q='select name, toint(basename) from .' --lenient
+7. goselect ex -q='select formatdatetime(mtime, month), count() from . group by formatdatetime(mtime, month)' --timeFormat='month=%Y-%m'
+8. goselect ex -q='select name, mtime, extract(mtime, date) from . where mtime >= today' --timezone=Europe/Berlin
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			errorColor := "\033[31m"
 
-			buildOptions := func() *executor.Options {
-				nestedTraversal, _ := cmd.Flags().GetBool("nestedTraversal")
-				ignoreTraversal, _ := cmd.Flags().GetStringSlice("skipDirectoryTraversal")
-
-				options := executor.NewDefaultOptions()
-				if nestedTraversal {
-					options.EnableNestedTraversal()
-				} else {
-					options.DisableNestedTraversal()
-				}
-				options.DirectoriesToIgnoreTraversal(ignoreTraversal)
-				return options
-			}
 			executeQuery := func(cmd *cobra.Command) (*executor.EvaluatingRows, *parser.SelectQuery, error) {
-				rawQuery, _ := cmd.Flags().GetString("query")
-				newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
-				newParser, err := parser.NewParser(rawQuery, newContext)
+				query, newContext, err := parseQuery(cmd)
 				if err != nil {
 					return nil, nil, err
 				}
-				query, err := newParser.Parse()
-				if err != nil {
-					return nil, nil, err
-				}
-				rows, err := executor.NewSelectQueryExecutor(query, newContext, buildOptions()).Execute()
+				rows, err := executor.NewSelectQueryExecutor(query, newContext, optionsFrom(cmd)).Execute()
 				if err != nil {
 					return nil, nil, err
 				}
@@ -158,6 +144,83 @@ func SupportedExportFormats() []string {
 	return []string{"json", "html", "table"}
 }
 
+func optionsFrom(cmd *cobra.Command) *executor.Options {
+	nestedTraversal, _ := cmd.Flags().GetBool("nestedTraversal")
+	ignoreTraversal, _ := cmd.Flags().GetStringSlice("skipDirectoryTraversal")
+	minDepth, _ := cmd.Flags().GetUint32("minDepth")
+	maxDepth, _ := cmd.Flags().GetUint32("maxDepth")
+
+	options := executor.NewDefaultOptions()
+	if nestedTraversal {
+		options.EnableNestedTraversal()
+	} else {
+		options.DisableNestedTraversal()
+	}
+	options.DirectoriesToIgnoreTraversal(ignoreTraversal)
+	options.MinDepth(minDepth).MaxDepth(maxDepth)
+	return options
+}
+
+func parametersFrom(cmd *cobra.Command) (*parser.Parameters, error) {
+	values, _ := cmd.Flags().GetStringArray("param")
+	parameters := parser.NewParameters()
+	for _, value := range values {
+		separatorIndex := strings.Index(value, "=")
+		if separatorIndex <= 0 {
+			return nil, fmt.Errorf(ErrorMessageInvalidParameter, value)
+		}
+		parameters.AddNamed(value[:separatorIndex], value[separatorIndex+1:])
+	}
+	return parameters, nil
+}
+
+func registerTimeFormats(cmd *cobra.Command, newContext *context.ParsingApplicationContext) error {
+	values, _ := cmd.Flags().GetStringArray("timeFormat")
+	for _, value := range values {
+		separatorIndex := strings.Index(value, "=")
+		if separatorIndex <= 0 {
+			return fmt.Errorf(ErrorMessageInvalidTimeFormat, value)
+		}
+		if err := newContext.RegisterFormat(value[:separatorIndex], value[separatorIndex+1:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseQuery(cmd *cobra.Command) (*parser.SelectQuery, *context.ParsingApplicationContext, error) {
+	rawQuery, _ := cmd.Flags().GetString("query")
+	parameters, err := parametersFrom(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	functions := context.NewFunctions()
+	if lenient, _ := cmd.Flags().GetBool("lenient"); lenient {
+		functions = functions.InLenientMode()
+	}
+	newContext := context.NewContext(functions, context.NewAttributes())
+	if err := registerTimeFormats(cmd, newContext); err != nil {
+		return nil, nil, err
+	}
+	if timeZone, err := cmd.Flags().GetString("timezone"); err == nil {
+		if err := newContext.SetTimeZone(timeZone); err != nil {
+			return nil, nil, err
+		}
+	}
+	if strict, _ := cmd.Flags().GetBool("strict"); strict {
+		newContext = newContext.InStrictMode()
+	}
+	newParser, err := parser.NewParserWithParameters(rawQuery, newContext, parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+	query, err := newParser.Parse()
+	if err != nil {
+		return nil, nil, err
+	}
+	return query, newContext, nil
+}
+
 func init() {
 	executeCmd := newExecuteCommand()
 	rootCmd.AddCommand(executeCmd)
@@ -167,6 +230,31 @@ func init() {
 		"",
 		"specify the query. Use --query=<query> or -q=<query>",
 	)
+	executeCmd.PersistentFlags().StringArray(
+		"param",
+		[]string{},
+		"specify a value for a placeholder in the query, the value is never split on commas or parentheses. Use --param=<name>=<value> for :name and --param=<position>=<value> for ?, for example --param=ext=.log --param=1=10mb",
+	)
+	executeCmd.PersistentFlags().Bool(
+		"strict",
+		false,
+		"specify if every token in the query must be consumed by the grammar, an unknown token is an error instead of being ignored. Use --strict=<true/false>",
+	)
+	executeCmd.PersistentFlags().Bool(
+		"lenient",
+		false,
+		"specify if a value that can not be converted by cast, toint, tofloat, tostring or todatetime becomes null instead of an error. Use --lenient=<true/false>",
+	)
+	executeCmd.PersistentFlags().StringArray(
+		"timeFormat",
+		[]string{},
+		"specify a named date/time format with a strftime-style pattern, usable in formatdatetime, parsedatetime and todatetime. Use --timeFormat=<id>=<pattern>, for example --timeFormat=month=%Y-%m",
+	)
+	executeCmd.PersistentFlags().String(
+		"timezone",
+		"UTC",
+		"specify the time zone of now(), the date/time attributes, the relative times like 7 days ago and the displayed date/times. Use --timezone=<zone> with a name like Europe/Berlin, Local for the system time zone or an offset like +05:30",
+	)
 	executeCmd.PersistentFlags().BoolP(
 		"nestedTraversal",
 		"n",
@@ -179,6 +267,16 @@ func init() {
 		[]string{".git", ".github"},
 		"specify the directory names that should not be traversed. Use --skipDirectoryTraversal=<directory> or -s=<directory>. Multiple directory names can be passed by using --skipDirectoryTraversal=.git --skipDirectoryTraversal=.github",
 	)
+	executeCmd.PersistentFlags().Uint32(
+		"minDepth",
+		0,
+		"specify the minimum depth of the files to choose, files directly inside a source directory are at depth 1. The depth clause in the query takes precedence. Use --minDepth=<value>",
+	)
+	executeCmd.PersistentFlags().Uint32(
+		"maxDepth",
+		0,
+		"specify the maximum depth of the traversal, directories past this depth are never read and 0 means no limit. The depth clause in the query takes precedence. Use --maxDepth=<value>",
+	)
 	executeCmd.PersistentFlags().StringP(
 		"format",
 		"f",
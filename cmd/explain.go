@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"github.com/jedib0t/go-pretty/v6/list"
+	"github.com/spf13/cobra"
+	"goselect/parser/executor"
+)
+
+func newExplainCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "explain",
+		Aliases: []string{"exp"},
+		Short:   "Explain the plan of a select query without executing it",
+		Long:    `Explain the plan of a select query as a tree, with the attributes and functions by their canonical names, the clauses and the shortcuts taken by the execution`,
+		Example: `
+1. goselect explain -q='select lower(fname), count() from . group by lower(fname)'
+2. goselect exp -q='select name, size from . where size > 1mb order by 2 desc limit 10'
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			errorColor := "\033[31m"
+
+			query, newContext, err := parseQuery(cmd)
+			if err != nil {
+				cmd.Println(errorColor, err)
+				return
+			}
+			plan := executor.NewSelectQueryExecutor(query, newContext, optionsFrom(cmd)).Explain()
+
+			buffer := new(bytes.Buffer)
+			listWriter := list.NewWriter()
+			listWriter.SetOutputMirror(buffer)
+			listWriter.SetStyle(list.StyleConnectedLight)
+
+			var appendPlan func(plan *executor.Plan)
+			appendPlan = func(plan *executor.Plan) {
+				listWriter.AppendItem(plan.Label)
+				if len(plan.Children) == 0 {
+					return
+				}
+				listWriter.Indent()
+				for _, child := range plan.Children {
+					appendPlan(child)
+				}
+				listWriter.UnIndent()
+			}
+			appendPlan(plan)
+			listWriter.Render()
+			cmd.Print(buffer.String())
+		},
+	}
+}
+
+func init() {
+	explainCmd := newExplainCommand()
+	rootCmd.AddCommand(explainCmd)
+	explainCmd.PersistentFlags().StringP(
+		"query",
+		"q",
+		"",
+		"specify the query. Use --query=<query> or -q=<query>",
+	)
+	explainCmd.PersistentFlags().StringArray(
+		"param",
+		[]string{},
+		"specify a value for a placeholder in the query. Use --param=<name>=<value> for :name and --param=<position>=<value> for ?",
+	)
+	explainCmd.PersistentFlags().Bool(
+		"strict",
+		false,
+		"specify if every token in the query must be consumed by the grammar. Use --strict=<true/false>",
+	)
+	explainCmd.PersistentFlags().BoolP(
+		"nestedTraversal",
+		"n",
+		true,
+		"specify if nested directories should be traversed. Use --nestedTraversal=<true/false> or -n=<true/false>",
+	)
+	explainCmd.PersistentFlags().StringSliceP(
+		"skipDirectoryTraversal",
+		"s",
+		[]string{".git", ".github"},
+		"specify the directory names that should not be traversed. Use --skipDirectoryTraversal=<directory> or -s=<directory>",
+	)
+	explainCmd.PersistentFlags().Uint32(
+		"minDepth",
+		0,
+		"specify the minimum depth of the files to choose, files directly inside a source directory are at depth 1. The depth clause in the query takes precedence. Use --minDepth=<value>",
+	)
+	explainCmd.PersistentFlags().Uint32(
+		"maxDepth",
+		0,
+		"specify the maximum depth of the traversal, directories past this depth are never read and 0 means no limit. The depth clause in the query takes precedence. Use --maxDepth=<value>",
+	)
+}
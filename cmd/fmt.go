@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newFormatCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "fmt",
+		Aliases: []string{"format"},
+		Short:   "Format a select query in its canonical form",
+		Long:    `Format a select query in its canonical form, one clause per line, with the attributes and functions by their canonical names, infix operators as functions and the values quoted only when needed`,
+		Example: `
+1. goselect fmt -q='select fname, size / 1024 from . where ext = .log order by 2 desc'
+2. goselect format -q='select lower(name) as lname, count() from . group by lower(name)'
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			errorColor := "\033[31m"
+
+			query, _, err := parseQuery(cmd)
+			if err != nil {
+				cmd.Println(errorColor, err)
+				return
+			}
+			cmd.Println(query.Pretty())
+		},
+	}
+}
+
+func init() {
+	formatCmd := newFormatCommand()
+	rootCmd.AddCommand(formatCmd)
+	formatCmd.PersistentFlags().StringP(
+		"query",
+		"q",
+		"",
+		"specify the query. Use --query=<query> or -q=<query>",
+	)
+	formatCmd.PersistentFlags().StringArray(
+		"param",
+		[]string{},
+		"specify a value for a placeholder in the query. Use --param=<name>=<value> for :name and --param=<position>=<value> for ?",
+	)
+	formatCmd.PersistentFlags().Bool(
+		"strict",
+		false,
+		"specify if every token in the query must be consumed by the grammar. Use --strict=<true/false>",
+	)
+}
@@ -13,9 +13,11 @@ var rootCmd = &cobra.Command{
 1. goselect execute -q='select filename, absolutepath from .'
 2. goselect ex -q='select name, size, extension from . where like(name, results.*) order by 2'
 3. goselect ex -q='select name, size, extension from . where or(like(name, results.*), gt(size, 2048)) order by 2 limit 5'
+4. goselect ex -q='select ext, count(), sum(size) from . group by ext order by 2 desc'
+5. goselect ex -q='select name, size / 1024 from . where size > 1mb and (ext = .log or name like results.*)'
 `,
 	Long: `goselect provides SQL like 'select' interface for file systems. 
-The syntax for select query is: select <attributes> from <directory> [where condition] [order by] [limit].
+The syntax for select query is: select [distinct] <attributes> from <directory>[, <directory>...] [as alias] [[left] join <directory> as alias on condition] [where condition] [group by] [having condition] [order by] [limit [offset]].
 Queries are case-insensitive in nature. 
 
 goselect provides various features including:
@@ -23,15 +25,36 @@ goselect provides various features including:
 2. Support for function aliases. For example, lower is same as low 
 3. Support for various scalar functions like lower, upper, now, concat etc
 4. Support for various aggregate functions like count, countdistinct, average etc
-5. Support for exporting the results in table, json and html format
+5. Support for 'group by' with attributes and scalar functions. For example, select ext, count() from . group by ext
+6. Support for 'having' to filter the groups on aggregate values. For example, select ext, count() from . group by ext having gt(count(), 10)
+7. Support for infix operators along with the functions. For example, where size > 1mb and (ext = .log or name like err.*) is same as where and(gt(size, 1mb), or(eq(ext, .log), like(name, err.*)))
+8. Support for aliases in projections and ordering by aliases, attributes or expressions. For example, select fmtsize(size) as hsize from . order by hsize desc, lower(name)
+9. Support for distinct projections. For example, select distinct ext from .
+10. Support for multiple source directories. For example, select name, root from ~/projects/a, ~/projects/b
+11. Support for union, union all, except and intersect between select queries. For example, select name from ~/a union select name from ~/b
+12. Support for in with a list of values or a subquery. For example, select name from . where basename in (select basename from ../other)
+13. Support for conditional expressions. For example, select name, case when size > 1mb then large else small end as bucket from . is same as select name, if(gt(size, 1mb), large, small) as bucket from .
+14. Support for join and left join between directories. For example, select a.relpath from ./src as a left join ./backup as b on eq(a.relpath, b.relpath) where eq(length(b.name), 0)
+15. Support for placeholders. For example, goselect ex -q='select name from . where eq(name, :name)' --param name='report (1),final.pdf'
+16. Support for parse errors with a caret under the offending token and suggestions. For example, order by nmae suggests name
+17. Support for a strict mode. For example, goselect ex -q='select nmae from .' --strict fails instead of ignoring nmae
+18. Support for explaining the plan of a query. For example, goselect explain -q='select low(fname) from . limit 10'
+19. Support for formatting a query in its canonical form. For example, goselect fmt -q='select fname from . where ext = .log'
+20. Support for window functions. For example, select name, row_number() over (partition by dirname(path) order by size desc) as rn from .
+21. Support for limiting the traversal depth. For example, select name, depth from . depth 2 or --maxDepth=2
+22. Support for glob patterns as the source. For example, select relpath, size from ./src/**/*.go
+23. Support for groups in regular expressions. For example, select regexextract(name, 'v([0-9.]+)', 1) as version from . where regexmatch(name, '^app-v')
+24. Support for null values with isnull, isnotnull, coalesce and nullif. For example, select a.relpath, coalesce(b.size, 0) from ./src as a left join ./backup as b on eq(a.relpath, b.relpath)
+25. Support for explicit conversions. For example, select name from ./builds where cast(basename as int) > 100
+26. Support for date arithmetic and formatting. For example, select datetrunc(mtime, month), count() from ./reports group by datetrunc(mtime, month)
+27. Support for relative times and age. For example, select name, formatduration(age, hour) from ./logs where mtime < 30 days ago
+28. Support for time zones. For example, select name, mtime from ./logs where mtime >= today with --timezone=Europe/Berlin
+29. Support for exporting the results in table, json and html format
 
 Features that are different from SQL:
-1. goselect does not support 'group by'. All the aggregating functions return results that repeat for each row
-2. goselect does not support expressions like 1+2 or 1*2. Instead, goselect gives functions like 'add' and 'mul' etc to write such expressions
-3. goselect does not support expressions like name=sample.log in 'where' clause. Instead, various functions are given to represent such expressions. These functions include: 'eq', 'ne', 'lt' etc
-4. goselect has a weak grammar. For example, a query like: select 1+2, name from /home/projects will ignore 1+2 and return file names
-5. goselect's 'order by' clause supports only attribute positions. For example, a query like: select name, size from /home/projects order by 1 will order the results by first attribute
-6. goselect does not support single quote ['] and double quotes["]. For example, to match a file name, one could simply write a query: select * from . where eq(name, sample) 
+1. goselect expects the arithmetic operators (+, -, *, /) and the word operators (and, or, not, like) to be separated by spaces. For example, size / 1024 is an expression but size/1024 is an error, the comparison operators (=, !=, <>, <, <=, >, >=) do not need spaces, size>1024 is same as size > 1024
+2. goselect has a weak grammar. For example, a query like: select nme, name from /home/projects will ignore nme and return file names. Use --strict to reject the tokens that are not consumed by the grammar
+3. goselect does not need quotes around a value. For example, to match a file name, one could simply write a query: select * from . where eq(name, sample). A value in single quotes ['] or double quotes ["] is always a value, never an attribute, a function or a keyword, and it keeps its backslashes 
 
 goselect is available here: https://github.com/SarthakMakhija/goselect
 `,
@@ -5,6 +5,7 @@ package test
 
 import (
 	"bytes"
+	"fmt"
 	"goselect/cmd"
 	"goselect/parser/context"
 	"strings"
@@ -66,3 +67,17 @@ func TestBlankTerm(t *testing.T) {
 		t.Fatalf("Expected an error %v while trying to get the description without a term value, received %v", cmd.ErrorMessageEmptyTerm, contents)
 	}
 }
+
+func TestInvalidTermWithASuggestion(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"describe", "--term", "lowre"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+	contents := buffer.String()
+	expected := fmt.Sprintf(cmd.ErrorMessageDidYouMean, "lower")
+
+	if !strings.Contains(contents, expected) {
+		t.Fatalf("Expected %v while trying to get the description of %v, received %v", expected, "lowre", contents)
+	}
+}
@@ -337,3 +337,221 @@ func TestExecuteWithExportToAFileInADirectoryWithPathSeparator(t *testing.T) {
 		t.Fatalf("Expected file %v to exist but received an err %v", fileName, err)
 	}
 }
+
+func TestAttemptToExecuteAQueryWithAMisspelledProjectionInTheStrictMode(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select nmae from ./resources/", "--strict", "--format", "table", "--path", "", "--nestedTraversal=true", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	expected := fmt.Sprintf(messages.ErrorMessageUnexpectedTokenInProjection, "nmae")
+	if !strings.Contains(contents, expected) {
+		t.Fatalf("Expected error message %v to be contained in the result but was not, received %v", expected, contents)
+	}
+}
+
+func TestExecutesAQueryWithAMisspelledProjectionOutsideTheStrictMode(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select nmae, name from ./resources/ where eq(ext, .log) order by 1", "--strict=false", "--format", "table", "--path", "", "--nestedTraversal=true", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	if !strings.Contains(contents, "TestResultsWithProjections_A.log") {
+		t.Fatalf("Expected file name %v to be contained in the result but was not, received %v", "TestResultsWithProjections_A.log", contents)
+	}
+}
+
+func TestExecutesAQueryWithAMaximumDepth(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name from ./resources/ order by 1", "--format", "table", "--path", "", "--nestedTraversal=true", "--minDepth=0", "--maxDepth=1", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	if !strings.Contains(contents, "log") {
+		t.Fatalf("Expected directory name %v to be contained in the result but was not, received %v", "log", contents)
+	}
+	if strings.Contains(contents, "TestResultsWithProjections_A.log") {
+		t.Fatalf("Expected file name %v to not be contained in the result but was, received %v", "TestResultsWithProjections_A.log", contents)
+	}
+}
+
+func TestExecutesAQueryWithTheDepthClauseOverTheDepthFlags(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name, depth from ./resources/ depth 2 to 2 order by 1", "--format", "table", "--path", "", "--nestedTraversal=true", "--minDepth=0", "--maxDepth=0", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	if !strings.Contains(contents, "TestResultsWithProjections_A.log") {
+		t.Fatalf("Expected file name %v to be contained in the result but was not, received %v", "TestResultsWithProjections_A.log", contents)
+	}
+	if !strings.Contains(contents, "Rows: 3") {
+		t.Fatalf("Expected %v to be contained in the result but was not, received %v", "Rows: 3", contents)
+	}
+}
+
+func TestExecutesAQueryWithAGlobInTheSource(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name from ./resources/**/*.log order by 1", "--format", "table", "--path", "", "--nestedTraversal=true", "--minDepth=0", "--maxDepth=0", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	if !strings.Contains(contents, "TestResultsWithProjections_B.log") {
+		t.Fatalf("Expected file name %v to be contained in the result but was not, received %v", "TestResultsWithProjections_B.log", contents)
+	}
+	if strings.Contains(contents, "TestResultsWithProjections_C.txt") {
+		t.Fatalf("Expected file name %v to not be contained in the result but was, received %v", "TestResultsWithProjections_C.txt", contents)
+	}
+}
+
+func TestExecutesAQueryWithAFailedConversion(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select toint(name) from ./resources/log", "--lenient=false", "--format", "table", "--path", "", "--nestedTraversal=true", "--minDepth=0", "--maxDepth=0", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	expected := fmt.Sprintf(messages.ErrorMessageCannotCast, "TestResultsWithProjections_A.log", "int")
+	if !strings.Contains(contents, expected) {
+		t.Fatalf("Expected error message %v to be contained in the result but was not, received %v", expected, contents)
+	}
+}
+
+func TestExecutesAQueryWithAFailedConversionInTheLenientMode(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name, toint(name) from ./resources/log order by 1", "--lenient", "--format", "json", "--path", "", "--nestedTraversal=true", "--minDepth=0", "--maxDepth=0", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	expected := "\"toint(name)\" : null"
+	if !strings.Contains(contents, expected) {
+		t.Fatalf("Expected %v to be contained in the result but was not, received %v", expected, contents)
+	}
+}
+
+func TestExecutesAQueryWithANamedTimeFormat(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select formatdatetime(parsedatetime(18/10/2022, dmy), ym), count() from ./resources/log group by formatdatetime(parsedatetime(18/10/2022, dmy), ym)", "--timeFormat", "dmy=%d/%m/%Y", "--timeFormat", "ym=%Y-%m", "--format", "json", "--path", "", "--nestedTraversal=true", "--minDepth=0", "--maxDepth=0", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	expected := "\"formatdatetime(parsedatetime(18/10/2022,dmy),ym)\" : \"2022-10\""
+	if !strings.Contains(contents, expected) {
+		t.Fatalf("Expected %v to be contained in the result but was not, received %v", expected, contents)
+	}
+}
+
+func TestExecutesAQueryWithANamedTimeFormatHavingText(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select formatdatetime(parsedatetime(18/10/2022, dmy), quarter) from ./resources/log", "--timeFormat", "dmy=%d/%m/%Y", "--timeFormat", "quarter=Q4 Monday-%Y", "--format", "json", "--path", "", "--nestedTraversal=true", "--minDepth=0", "--maxDepth=0", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	expected := "\"formatdatetime(parsedatetime(18/10/2022,dmy),quarter)\" : \"Q4 Monday-2022\""
+	if !strings.Contains(contents, expected) {
+		t.Fatalf("Expected %v to be contained in the result but was not, received %v", expected, contents)
+	}
+}
+
+func TestExecutesAQueryWithARelativeTime(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name, formatduration(age, day) from ./resources/log where mtime < 0 days ago and age > 0s", "--format", "json", "--path", "", "--nestedTraversal=true", "--minDepth=0", "--maxDepth=0", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	if !strings.Contains(contents, "TestResultsWithProjections_A.log") {
+		t.Fatalf("Expected file name %v to be contained in the result but was not, received %v", "TestResultsWithProjections_A.log", contents)
+	}
+	if !strings.Contains(contents, "d\"") {
+		t.Fatalf("Expected the age formatted in days to be contained in the result but was not, received %v", contents)
+	}
+}
+
+func TestExecutesAQueryInATimeZone(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name, mtime from ./resources/log", "--timezone", "+05:30", "--format", "json", "--path", "", "--nestedTraversal=true", "--minDepth=0", "--maxDepth=0", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	if !strings.Contains(contents, "+0530") {
+		t.Fatalf("Expected the modified time in the time zone %v to be contained in the result but was not, received %v", "+05:30", contents)
+	}
+}
+
+func TestExecutesAQueryWithParameters(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name from ./resources/ where eq(ext, :ext) and gt(size, ?) order by 1", "--param", "ext=.log", "--param", "1=60", "--format", "table", "--path", "", "--nestedTraversal=true", "--minWidth=0", "--maxWidth=0"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	if !strings.Contains(contents, "TestResultsWithProjections_A.log") {
+		t.Fatalf("Expected file name %v to be contained in the result but was not, received %v", "TestResultsWithProjections_A.log", contents)
+	}
+	if strings.Contains(contents, "TestResultsWithProjections_B.log") {
+		t.Fatalf("Expected file name %v to not be contained in the result but was, received %v", "TestResultsWithProjections_B.log", contents)
+	}
+}
+
+func TestAttemptToExecuteAQueryWithAnUnsupportedTimeZone(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name from ./resources/log", "--timezone", "Mars/Olympus"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	expected := fmt.Sprintf(messages.ErrorMessageUnsupportedTimeZone, "Mars/Olympus")
+	if !strings.Contains(contents, expected) {
+		t.Fatalf("Expected error message %v to be contained in the result but was not, received %v", expected, contents)
+	}
+}
+
+func TestAttemptToExecuteAQueryWithAnInvalidTimeFormat(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name from ./resources/log", "--timeFormat", "%Y-%m"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	expected := fmt.Sprintf(cmd.ErrorMessageInvalidTimeFormat, "%Y-%m")
+	if !strings.Contains(contents, expected) {
+		t.Fatalf("Expected error message %v to be contained in the result but was not, received %v", expected, contents)
+	}
+}
+
+func TestAttemptToExecuteAQueryWithAnInvalidParameter(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"execute", "--query", "select name from ./resources/ where eq(ext, :ext)", "--param", "=.log"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	expected := fmt.Sprintf(cmd.ErrorMessageInvalidParameter, "=.log")
+	if !strings.Contains(contents, expected) {
+		t.Fatalf("Expected error message %v to be contained in the result but was not, received %v", expected, contents)
+	}
+}
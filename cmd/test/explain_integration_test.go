@@ -0,0 +1,39 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"bytes"
+	"goselect/cmd"
+	"strings"
+	"testing"
+)
+
+func TestExplainAQuery(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"explain", "--query", "select low(fname) from ./resources/ where eq(ext, .log) limit 1"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	for _, expected := range []string{"scalar function lower (written as low)", "where", "limit 1 offset 0", "limit short-circuits the traversal"} {
+		if !strings.Contains(contents, expected) {
+			t.Fatalf("Expected %v to be contained in the plan but was not, received %v", expected, contents)
+		}
+	}
+}
+
+func TestAttemptToExplainAnInvalidQuery(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"explain", "--query", "select name from ./resources/ order by nmae"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	if !strings.Contains(contents, "did you mean name?") {
+		t.Fatalf("Expected a suggestion to be contained in the result but was not, received %v", contents)
+	}
+}
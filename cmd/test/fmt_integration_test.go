@@ -0,0 +1,53 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"bytes"
+	"goselect/cmd"
+	"strings"
+	"testing"
+)
+
+func TestFormatAQuery(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"fmt", "--query", "select fname, fsize / 1024 from ./resources/ where ext = .log order by 2 desc limit 1"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := strings.Trim(buffer.String(), "\n")
+	expected := "select name, divide(size, 1024)\nfrom ./resources/\nwhere equal(extension, .log)\norder by 2 desc\nlimit 1"
+	if contents != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, contents)
+	}
+}
+
+func TestFormatAFormattedQuery(t *testing.T) {
+	format := func(query string) string {
+		cmd.GetRootCommand().SetArgs([]string{"fmt", "--query", query})
+		buffer := new(bytes.Buffer)
+		cmd.GetRootCommand().SetOut(buffer)
+
+		_ = cmd.GetRootCommand().Execute()
+		return strings.Trim(buffer.String(), "\n")
+	}
+	formatted := format("select name from ./resources/ where eq(ext, .log) order by 1")
+	if reformatted := format(formatted); reformatted != formatted {
+		t.Fatalf("Expected the formatted query %v to format to itself, received %v", formatted, reformatted)
+	}
+}
+
+func TestAttemptToFormatAnInvalidQuery(t *testing.T) {
+	cmd.GetRootCommand().SetArgs([]string{"fmt", "--query", "select nmae from ./resources/"})
+	buffer := new(bytes.Buffer)
+	cmd.GetRootCommand().SetOut(buffer)
+
+	_ = cmd.GetRootCommand().Execute()
+
+	contents := buffer.String()
+	if !strings.Contains(contents, "did you mean name?") {
+		t.Fatalf("Expected a suggestion to be contained in the result but was not, received %v", contents)
+	}
+}
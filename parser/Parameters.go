@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"fmt"
+	"goselect/parser/error/messages"
+	"goselect/parser/tokenizer"
+	"strconv"
+	"strings"
+)
+
+type Parameters struct {
+	values          map[string]string
+	positionalCount int
+}
+
+func NewParameters() *Parameters {
+	return &Parameters{values: make(map[string]string)}
+}
+
+func (parameters *Parameters) AddPositional(value string) *Parameters {
+	parameters.positionalCount = parameters.positionalCount + 1
+	parameters.values[strconv.Itoa(parameters.positionalCount)] = value
+	return parameters
+}
+
+func (parameters *Parameters) AddNamed(name string, value string) *Parameters {
+	parameters.values[strings.ToLower(strings.TrimPrefix(name, ":"))] = value
+	return parameters
+}
+
+/*
+positional: ? is bound to the parameters from left to right, or by its position as the name (1, 2 ...)
+named:      :name is bound to the parameter name
+example:    where eq(name, ?) with the value a,b(1) compares the name with a,b(1), bound values are never tokenized
+*/
+func (parameters *Parameters) bind(query string, tokens *tokenizer.Tokens) (*tokenizer.Tokens, error) {
+	boundTokens := tokenizer.NewEmptyTokens()
+	position := 0
+	for iterator := tokens.Iterator(); iterator.HasNext(); {
+		token := iterator.Next()
+		if !token.IsAPlaceholder() {
+			boundTokens.Add(token)
+			continue
+		}
+		if token.TokenValue == "?" {
+			position = position + 1
+			value, ok := parameters.values[strconv.Itoa(position)]
+			if !ok {
+				return nil, newParseError(fmt.Errorf(messages.ErrorMessageMissingPositionalParameter, position), query, token.Offset, nil)
+			}
+			boundTokens.Add(tokenizer.NewBoundToken(value, token))
+			continue
+		}
+		name := strings.TrimPrefix(token.TokenValue, ":")
+		value, ok := parameters.values[strings.ToLower(name)]
+		if !ok {
+			return nil, newParseError(fmt.Errorf(messages.ErrorMessageMissingNamedParameter, name), query, token.Offset, nil)
+		}
+		boundTokens.Add(tokenizer.NewBoundToken(value, token))
+	}
+	return boundTokens, nil
+}
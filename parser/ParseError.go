@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser/error/messages"
+	"strings"
+	"unicode/utf8"
+)
+
+type ParseError struct {
+	Offset      int
+	Column      int
+	Suggestions []string
+	line        string
+	err         error
+}
+
+func newParseError(err error, query string, offset int, suggestions []string) *ParseError {
+	lineStart := strings.LastIndex(query[:offset], "\n") + 1
+	lineEnd := strings.Index(query[offset:], "\n")
+	if lineEnd == -1 {
+		lineEnd = len(query)
+	} else {
+		lineEnd = offset + lineEnd
+	}
+	return &ParseError{
+		Offset:      offset,
+		Column:      utf8.RuneCountInString(query[lineStart:offset]) + 1,
+		Suggestions: suggestions,
+		line:        query[lineStart:lineEnd],
+		err:         err,
+	}
+}
+
+/*
+rendering: the message, the line of the query holding the offending token, a caret under the token and the suggestions if any
+example:   order by nmae renders the message, the query, a caret under nmae and "did you mean name?"
+*/
+func (parseError *ParseError) Error() string {
+	var message strings.Builder
+	message.WriteString(parseError.err.Error())
+	message.WriteString("\n" + parseError.line)
+	message.WriteString("\n" + strings.Repeat(" ", parseError.Column-1) + "^")
+	if len(parseError.Suggestions) > 0 {
+		message.WriteString("\n" + fmt.Sprintf(messages.ErrorMessageDidYouMean, strings.Join(parseError.Suggestions, " or ")))
+	}
+	return message.String()
+}
+
+func (parseError *ParseError) Unwrap() error {
+	return parseError.err
+}
+
+func isAParseError(err error) bool {
+	var parseError *ParseError
+	return errors.As(err, &parseError)
+}
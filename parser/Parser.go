@@ -2,8 +2,12 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 	"goselect/parser/context"
 	"goselect/parser/error/messages"
+	"goselect/parser/expression"
+	"goselect/parser/groupby"
+	"goselect/parser/having"
 	"goselect/parser/limit"
 	"goselect/parser/order"
 	"goselect/parser/projection"
@@ -14,11 +18,20 @@ import (
 )
 
 type SelectQuery struct {
-	Projections *projection.Projections
-	Source      *source.Source
-	Order       *order.Order
-	Limit       *limit.Limit
-	Where       *where.Where
+	Projections   *projection.Projections
+	Source        *source.Source
+	Order         *order.Order
+	Limit         *limit.Limit
+	Where         *where.Where
+	GroupBy       *groupby.GroupBy
+	Having        *having.Having
+	SetOperations []*SetOperation
+	Subqueries    []*Subquery
+}
+
+type Subquery struct {
+	Query  *SelectQuery
+	Values *context.ValueSet
 }
 
 func (selectQuery *SelectQuery) IsLimitDefined() bool {
@@ -29,54 +42,198 @@ func (selectQuery *SelectQuery) IsOrderDefined() bool {
 	return selectQuery.Order != nil
 }
 
+func (selectQuery *SelectQuery) IsGroupByDefined() bool {
+	return selectQuery.GroupBy != nil
+}
+
+func (selectQuery *SelectQuery) IsHavingDefined() bool {
+	return selectQuery.Having != nil
+}
+
+func (selectQuery *SelectQuery) IsGroupingDefined() bool {
+	return selectQuery.IsGroupByDefined() || selectQuery.IsHavingDefined()
+}
+
+func (selectQuery *SelectQuery) IsCombined() bool {
+	return len(selectQuery.SetOperations) > 0
+}
+
 type Parser struct {
-	query   string
-	context *context.ParsingApplicationContext
+	query      string
+	context    *context.ParsingApplicationContext
+	parameters *Parameters
 }
 
 func NewParser(query string, context *context.ParsingApplicationContext) (*Parser, error) {
+	return NewParserWithParameters(query, context, NewParameters())
+}
+
+func NewParserWithParameters(query string, context *context.ParsingApplicationContext, parameters *Parameters) (*Parser, error) {
 	if len(query) == 0 {
 		return nil, errors.New(messages.ErrorMessageEmptyQuery)
 	}
-	return &Parser{query: strings.TrimSpace(query), context: context}, nil
+	return &Parser{query: strings.TrimSpace(query), context: context, parameters: parameters}, nil
 }
 
 func (parser *Parser) Parse() (*SelectQuery, error) {
-	tokens := tokenizer.NewTokenizer(parser.query).Tokenize()
+	tokens, err := parser.parameters.bind(parser.query, tokenizer.NewTokenizer(parser.query).Tokenize())
+	if err != nil {
+		return nil, err
+	}
+	return parser.parse(tokens)
+}
+
+func (parser *Parser) parse(tokens *tokenizer.Tokens) (*SelectQuery, error) {
 	iterator := tokens.Iterator()
+	segments, operators, err := splitOnSetOperators(iterator)
+	if err != nil {
+		return nil, parser.errorAt(err, iterator)
+	}
+	iterator = segments[0].Iterator()
+	selectQuery, err := parser.parseSelect(iterator)
+	if err != nil {
+		return nil, parser.errorAt(err, iterator)
+	}
+	for index, operator := range operators {
+		if iterator.HasNext() {
+			return nil, parser.errorAt(errors.New(messages.ErrorMessageOrderByOrLimitBeforeSetOperator), iterator)
+		}
+		iterator = segments[index+1].Iterator()
+		query, err := parser.parseSelect(iterator)
+		if err != nil {
+			return nil, parser.errorAt(err, iterator)
+		}
+		if query.Projections.Count() != selectQuery.Projections.Count() {
+			return nil, parser.errorAt(errors.New(messages.ErrorMessageIncompatibleProjectionsInSetOperation), iterator)
+		}
+		if err := parser.ensureGrouped(query); err != nil {
+			return nil, parser.errorAt(err, iterator)
+		}
+		selectQuery.SetOperations = append(selectQuery.SetOperations, &SetOperation{Operator: operator, Query: query})
+	}
+	hiddenCount := selectQuery.Projections.HiddenCount()
+	orderBy, err := order.NewOrder(iterator, selectQuery.Projections, parser.context.WithSourceAliases(selectQuery.Source.Aliases()))
+	if err != nil {
+		return nil, parser.errorAt(err, iterator)
+	}
+	if selectQuery.IsCombined() && selectQuery.Projections.HiddenCount() > hiddenCount {
+		return nil, parser.errorAt(errors.New(messages.ErrorMessageOrderByNonProjectedInSetOperation), iterator)
+	}
+	if selectQuery.Projections.IsDistinct() && selectQuery.Projections.HiddenCount() > hiddenCount {
+		return nil, parser.errorAt(errors.New(messages.ErrorMessageOrderByNonProjectedWithDistinct), iterator)
+	}
+	if err := parser.ensureGrouped(selectQuery); err != nil {
+		return nil, parser.errorAt(err, iterator)
+	}
+	limitResults, err := limit.NewLimit(iterator)
+	if err != nil {
+		return nil, parser.errorAt(err, iterator)
+	}
+	if parser.context.IsInStrictMode() && iterator.HasNext() {
+		return nil, parser.errorAt(fmt.Errorf(messages.ErrorMessageUnexpectedTokenAtEnd, iterator.Next().TokenValue), iterator)
+	}
+	selectQuery.Order = orderBy
+	selectQuery.Limit = limitResults
+	return selectQuery, nil
+}
 
+func (parser *Parser) errorAt(err error, iterator *tokenizer.TokenIterator) error {
+	if isAParseError(err) {
+		return err
+	}
+	token, ok := iterator.Last()
+	var tokenError *tokenizer.TokenError
+	if errors.As(err, &tokenError) {
+		token, ok = tokenError.Token, true
+	}
+	if !ok {
+		return newParseError(err, parser.query, 0, nil)
+	}
+	var suggestions []string
+	if token.TokenType == tokenizer.RawString && !token.IsBound() && !token.IsQuoted() {
+		suggestions = parser.context.SuggestionsFor(token.TokenValue)
+	}
+	return newParseError(err, parser.query, token.Offset, suggestions)
+}
+
+func (parser *Parser) parseSelect(iterator *tokenizer.TokenIterator) (*SelectQuery, error) {
 	if iterator.HasNext() && !iterator.Peek().Equals("select") {
 		return nil, errors.New(messages.ErrorMessageNonSelectQuery)
 	}
-	projections, err := projection.NewProjections(iterator, parser.context)
+	ctx := parser.context.WithSourceAliases(source.AliasesAhead(iterator))
+	projections, err := projection.NewProjections(iterator, ctx)
 	if err != nil {
 		return nil, err
 	}
-	fileSource, err := source.NewSource(iterator)
+	fileSource, err := source.NewSource(iterator, ctx)
 	if err != nil {
 		return nil, err
 	}
-	if iterator.HasNext() && !iterator.Peek().Equals("where") && !iterator.Peek().Equals("order") && !iterator.Peek().Equals("limit") {
-		return nil, errors.New(messages.ErrorMessageInvalidKeywordAfterFrom)
+	if iterator.HasNext() &&
+		!iterator.Peek().Equals("where") &&
+		!iterator.Peek().Equals("group") &&
+		!iterator.Peek().Equals("having") &&
+		!iterator.Peek().Equals("order") &&
+		!iterator.Peek().Equals("limit") {
+		return nil, iterator.ErrorAtNext(errors.New(messages.ErrorMessageInvalidKeywordAfterFrom))
 	}
-	whereClause, err := where.NewWhere(iterator, parser.context)
+	whereClause, err := where.NewWhere(iterator, ctx)
 	if err != nil {
 		return nil, err
 	}
-	orderBy, err := order.NewOrder(iterator, projections.Count())
+	groupBy, err := groupby.NewGroupBy(iterator, ctx)
 	if err != nil {
 		return nil, err
 	}
-	limitResults, err := limit.NewLimit(iterator)
+	havingClause, err := having.NewHaving(iterator, projections, ctx)
+	if err != nil {
+		return nil, err
+	}
+	subqueries, err := parser.parseSubqueries(whereClause)
 	if err != nil {
 		return nil, err
 	}
-
 	return &SelectQuery{
 		Projections: projections,
 		Source:      fileSource,
 		Where:       whereClause,
-		Order:       orderBy,
-		Limit:       limitResults,
+		GroupBy:     groupBy,
+		Having:      havingClause,
+		Subqueries:  subqueries,
 	}, nil
 }
+
+/*
+grouped: with group by or having, every projection, hidden or not, and having must be grouped, see groupby.IsGrouped
+*/
+func (parser *Parser) ensureGrouped(selectQuery *SelectQuery) error {
+	if !selectQuery.IsGroupingDefined() {
+		return nil
+	}
+	expressions := append([]*expression.Expression{}, selectQuery.Projections.Expressions().Expressions...)
+	if selectQuery.IsHavingDefined() {
+		expressions = append(expressions, selectQuery.Having.Expression())
+	}
+	for _, anExpression := range expressions {
+		if !selectQuery.GroupBy.IsGrouped(anExpression, parser.context.AllAttributes()) {
+			display := expression.Expressions{Expressions: []*expression.Expression{anExpression}}.DisplayableAttributes()[0]
+			return fmt.Errorf(messages.ErrorMessageNonGroupedExpression, display)
+		}
+	}
+	return nil
+}
+
+func (parser *Parser) parseSubqueries(whereClause *where.Where) ([]*Subquery, error) {
+	var subqueries []*Subquery
+	for _, subquery := range whereClause.Subqueries() {
+		query, err := parser.parse(subquery.Tokens())
+		if err != nil {
+			return nil, err
+		}
+		if query.Projections.Count() != 1 {
+			return nil, errors.New(messages.ErrorMessageSubqueryWithMultipleProjections)
+		}
+		subqueries = append(subqueries, &Subquery{Query: query, Values: subquery.Values()})
+	}
+	return subqueries, nil
+}
@@ -0,0 +1,442 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/expression"
+	"goselect/parser/groupby"
+	"goselect/parser/having"
+	"goselect/parser/limit"
+	"goselect/parser/order"
+	"goselect/parser/projection"
+	"goselect/parser/source"
+	"goselect/parser/where"
+	"math"
+	"time"
+)
+
+type Term struct {
+	attribute string
+	function  string
+	args      []*Term
+	value     interface{}
+	isAValue  bool
+	alias     string
+	window    *Window
+}
+
+type Window struct {
+	partitionBy []interface{}
+	orderings   []interface{}
+}
+
+type Ordering struct {
+	by         interface{}
+	descending bool
+}
+
+type join struct {
+	joinType  source.JoinType
+	directory string
+	alias     string
+	on        *Term
+}
+
+type QueryBuilder struct {
+	projections []interface{}
+	distinct    bool
+	directories []string
+	alias       string
+	joins       []join
+	depth       []uint32
+	where       *Term
+	groupBy     []interface{}
+	having      *Term
+	orderings   []interface{}
+	limit       *limit.Limit
+}
+
+func Attr(attribute string) *Term {
+	return &Term{attribute: attribute}
+}
+
+func Val(value interface{}) *Term {
+	return &Term{value: value, isAValue: true}
+}
+
+func Fn(function string, args ...*Term) *Term {
+	return &Term{function: function, args: args}
+}
+
+func (term *Term) As(alias string) *Term {
+	term.alias = alias
+	return term
+}
+
+func (term *Term) Over(window *Window) *Term {
+	if window == nil {
+		window = NewWindow()
+	}
+	term.window = window
+	return term
+}
+
+func NewWindow() *Window {
+	return &Window{}
+}
+
+func (window *Window) PartitionBy(terms ...interface{}) *Window {
+	window.partitionBy = append(window.partitionBy, terms...)
+	return window
+}
+
+func (window *Window) OrderBy(orderings ...interface{}) *Window {
+	window.orderings = append(window.orderings, orderings...)
+	return window
+}
+
+func Asc(by interface{}) *Ordering {
+	return &Ordering{by: by}
+}
+
+func Desc(by interface{}) *Ordering {
+	return &Ordering{by: by, descending: true}
+}
+
+/*
+builder:  Select(projections).From(directories)[.As(alias)][.Join(...)][.Depth(max) | .DepthBetween(min, max)][.Where(term)][.GroupBy(...)][.Having(term)][.OrderBy(...)][.Limit(n)]
+terms:    a projection, a group by or an order by is an attribute name, "*" in projections, or a term built with Attr, Val and Fn
+ordering: an order by is a position, an alias, an attribute name, a term or Asc/Desc of any of these
+window:   Fn("rownumber").Over(NewWindow().PartitionBy(Fn("dirname", Attr("path"))).OrderBy(Desc("size"))), only as a projection
+example:  Select("name", Fn("lower", Attr("ext")).As("e")).From(".").Where(Fn("gt", Attr("size"), Val(1024))).OrderBy(Desc(2)).Build(ctx)
+*/
+func Select(projections ...interface{}) *QueryBuilder {
+	return &QueryBuilder{projections: projections}
+}
+
+func (builder *QueryBuilder) Distinct() *QueryBuilder {
+	builder.distinct = true
+	return builder
+}
+
+func (builder *QueryBuilder) From(directories ...string) *QueryBuilder {
+	builder.directories = append(builder.directories, directories...)
+	return builder
+}
+
+func (builder *QueryBuilder) As(alias string) *QueryBuilder {
+	builder.alias = alias
+	return builder
+}
+
+func (builder *QueryBuilder) Join(directory string, alias string, on *Term) *QueryBuilder {
+	builder.joins = append(builder.joins, join{joinType: source.JoinTypeInner, directory: directory, alias: alias, on: on})
+	return builder
+}
+
+func (builder *QueryBuilder) LeftJoin(directory string, alias string, on *Term) *QueryBuilder {
+	builder.joins = append(builder.joins, join{joinType: source.JoinTypeLeft, directory: directory, alias: alias, on: on})
+	return builder
+}
+
+func (builder *QueryBuilder) Depth(maxDepth uint32) *QueryBuilder {
+	builder.depth = []uint32{0, maxDepth}
+	return builder
+}
+
+func (builder *QueryBuilder) DepthBetween(minDepth uint32, maxDepth uint32) *QueryBuilder {
+	builder.depth = []uint32{minDepth, maxDepth}
+	return builder
+}
+
+func (builder *QueryBuilder) Where(condition *Term) *QueryBuilder {
+	builder.where = condition
+	return builder
+}
+
+func (builder *QueryBuilder) GroupBy(expressions ...interface{}) *QueryBuilder {
+	builder.groupBy = append(builder.groupBy, expressions...)
+	return builder
+}
+
+func (builder *QueryBuilder) Having(condition *Term) *QueryBuilder {
+	builder.having = condition
+	return builder
+}
+
+func (builder *QueryBuilder) OrderBy(orderings ...interface{}) *QueryBuilder {
+	builder.orderings = append(builder.orderings, orderings...)
+	return builder
+}
+
+func (builder *QueryBuilder) Limit(count uint32) *QueryBuilder {
+	if builder.limit == nil {
+		builder.limit = &limit.Limit{}
+	}
+	builder.limit.Limit = count
+	return builder
+}
+
+func (builder *QueryBuilder) Offset(offset uint32) *QueryBuilder {
+	if builder.limit == nil {
+		builder.limit = &limit.Limit{Limit: math.MaxInt32}
+	}
+	builder.limit.Offset = offset
+	return builder
+}
+
+func (builder *QueryBuilder) Build(ctx *context.ParsingApplicationContext) (*SelectQuery, error) {
+	aliases := []string{builder.alias}
+	for _, aJoin := range builder.joins {
+		aliases = append(aliases, aJoin.alias)
+	}
+	if len(builder.alias) > 0 {
+		ctx = ctx.WithSourceAliases(aliases)
+	}
+	projections, err := builder.buildProjections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fileSource, err := builder.buildSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	selectQuery := &SelectQuery{Projections: projections, Source: fileSource, Where: &where.Where{}, Limit: builder.limit}
+	if builder.where != nil {
+		condition, err := builder.where.nonWindowExpressionWith(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if selectQuery.Where, err = where.NewWhereWith(condition, ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(builder.groupBy) > 0 {
+		var expressions []*expression.Expression
+		for _, groupBy := range builder.groupBy {
+			anExpression, err := termOf(groupBy).nonWindowExpressionWith(ctx)
+			if err != nil {
+				return nil, err
+			}
+			expressions = append(expressions, anExpression)
+		}
+		if selectQuery.GroupBy, err = groupby.NewGroupByWith(expressions); err != nil {
+			return nil, err
+		}
+	}
+	if builder.having != nil {
+		condition, err := builder.having.nonWindowExpressionWith(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if selectQuery.Having, err = having.NewHavingWith(condition, ctx); err != nil {
+			return nil, err
+		}
+	}
+	if len(builder.orderings) > 0 {
+		if selectQuery.Order, err = builder.buildOrder(projections, ctx); err != nil {
+			return nil, err
+		}
+	}
+	return selectQuery, nil
+}
+
+func (builder *QueryBuilder) buildProjections(ctx *context.ParsingApplicationContext) (*projection.Projections, error) {
+	var expressions []*expression.Expression
+	var aliases []string
+	for _, aProjection := range builder.projections {
+		if attribute, ok := aProjection.(string); ok && context.IsAWildcardAttribute(attribute) {
+			wildcardAttributes := expression.WithAttributes(context.AttributesOnWildcard())
+			expressions = append(expressions, wildcardAttributes...)
+			aliases = append(aliases, make([]string, len(wildcardAttributes))...)
+			continue
+		}
+		term := termOf(aProjection)
+		anExpression, err := term.expressionWith(ctx)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, anExpression)
+		aliases = append(aliases, term.alias)
+	}
+	return projection.NewProjectionsWith(expressions, aliases, builder.distinct)
+}
+
+func (builder *QueryBuilder) buildSource(ctx *context.ParsingApplicationContext) (*source.Source, error) {
+	var joins []*source.Join
+	for _, aJoin := range builder.joins {
+		on, err := aJoin.on.nonWindowExpressionWith(ctx)
+		if err != nil {
+			return nil, err
+		}
+		join, err := source.NewJoinWith(aJoin.joinType, aJoin.directory, aJoin.alias, on, ctx)
+		if err != nil {
+			return nil, err
+		}
+		joins = append(joins, join)
+	}
+	fileSource, err := source.NewSourceWith(builder.directories, builder.alias, joins)
+	if err != nil {
+		return nil, err
+	}
+	if len(builder.depth) > 0 {
+		if fileSource.Depth, err = source.NewDepth(builder.depth[0], builder.depth[1]); err != nil {
+			return nil, err
+		}
+	}
+	return fileSource, nil
+}
+
+func (builder *QueryBuilder) buildOrder(projections *projection.Projections, ctx *context.ParsingApplicationContext) (*order.Order, error) {
+	var attributes []order.AttributeRef
+	var directions []bool
+	for _, anOrdering := range builder.orderings {
+		ordering, ok := anOrdering.(*Ordering)
+		if !ok {
+			ordering = Asc(anOrdering)
+		}
+		position, err := positionOf(ordering.by, projections, ctx)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, order.AttributeRef{ProjectionPosition: position})
+		directions = append(directions, !ordering.descending)
+	}
+	return order.NewOrderWith(attributes, directions)
+}
+
+func positionOf(by interface{}, projections *projection.Projections, ctx *context.ParsingApplicationContext) (int, error) {
+	if position, ok := by.(int); ok {
+		if position <= 0 {
+			return -1, fmt.Errorf(messages.ErrorMessageNonZeroPositivePositions)
+		}
+		if position > projections.Count() {
+			return -1, fmt.Errorf(messages.ErrorMessageOrderByPositionOutOfRange, 1, projections.Count())
+		}
+		return position, nil
+	}
+	if alias, ok := by.(string); ok {
+		if position, ok := projections.PositionOfAlias(alias); ok {
+			return position, nil
+		}
+	}
+	anExpression, err := termOf(by).nonWindowExpressionWith(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if position, ok := projections.PositionOf(anExpression); ok {
+		return position, nil
+	}
+	return projections.AddHidden(anExpression), nil
+}
+
+func termOf(item interface{}) *Term {
+	switch term := item.(type) {
+	case *Term:
+		return term
+	case string:
+		return Attr(term)
+	}
+	return &Term{value: item}
+}
+
+func (term *Term) expressionWith(ctx *context.ParsingApplicationContext) (*expression.Expression, error) {
+	switch {
+	case term.isAValue:
+		value, err := valueOf(term.value)
+		if err != nil {
+			return nil, err
+		}
+		return expression.WithValue(value), nil
+	case len(term.function) > 0:
+		if !ctx.IsASupportedFunction(term.function) {
+			return nil, fmt.Errorf(messages.ErrorMessageUnsupportedFunctionInBuilder, term.function)
+		}
+		var args []*expression.Expression
+		for _, arg := range term.args {
+			anExpression, err := arg.expressionWith(ctx)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, anExpression)
+		}
+		if term.window != nil {
+			return term.windowExpressionWith(args, ctx)
+		}
+		if ctx.IsAWindowFunction(term.function) {
+			return nil, fmt.Errorf(messages.ErrorMessageMissingOverForWindowFunction, term.function, term.function)
+		}
+		isAggregate := ctx.IsAnAggregateFunction(term.function)
+		var state *context.FunctionState
+		if isAggregate {
+			state = ctx.InitialState(term.function)
+		}
+		return expression.WithFunctionInstance(expression.FunctionInstanceWith(term.function, args, state, isAggregate)), nil
+	case len(term.attribute) > 0:
+		if !ctx.IsASupportedAttribute(term.attribute) {
+			return nil, fmt.Errorf(messages.ErrorMessageUnsupportedAttributeInBuilder, term.attribute)
+		}
+		return expression.WithAttribute(term.attribute), nil
+	}
+	return nil, fmt.Errorf(messages.ErrorMessageUnsupportedTermInBuilder, term.value)
+}
+
+func (term *Term) nonWindowExpressionWith(ctx *context.ParsingApplicationContext) (*expression.Expression, error) {
+	anExpression, err := term.expressionWith(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if anExpression.HasAWindow() {
+		return nil, errors.New(messages.ErrorMessageWindowFunctionNotSupported)
+	}
+	return anExpression, nil
+}
+
+func (term *Term) windowExpressionWith(args []*expression.Expression, ctx *context.ParsingApplicationContext) (*expression.Expression, error) {
+	var partitionBy, orderBy []*expression.Expression
+	var ascending []bool
+	for _, aPartitionBy := range term.window.partitionBy {
+		anExpression, err := termOf(aPartitionBy).expressionWith(ctx)
+		if err != nil {
+			return nil, err
+		}
+		partitionBy = append(partitionBy, anExpression)
+	}
+	for _, anOrdering := range term.window.orderings {
+		ordering, ok := anOrdering.(*Ordering)
+		if !ok {
+			ordering = Asc(anOrdering)
+		}
+		anExpression, err := termOf(ordering.by).expressionWith(ctx)
+		if err != nil {
+			return nil, err
+		}
+		orderBy = append(orderBy, anExpression)
+		ascending = append(ascending, !ordering.descending)
+	}
+	return expression.NewWindowFunction(term.function, args, expression.NewWindow(partitionBy, orderBy, ascending), ctx)
+}
+
+func valueOf(value interface{}) (context.Value, error) {
+	switch aValue := value.(type) {
+	case string:
+		return context.StringValue(aValue), nil
+	case int:
+		return context.IntValue(aValue), nil
+	case int64:
+		return context.Int64Value(aValue), nil
+	case uint32:
+		return context.Uint32Value(aValue), nil
+	case uint64:
+		return context.Uint64Value(aValue), nil
+	case float64:
+		return context.Float64Value(aValue), nil
+	case bool:
+		return context.BooleanValue(aValue), nil
+	case time.Time:
+		return context.DateTimeValue(aValue), nil
+	}
+	return context.EmptyValue, fmt.Errorf(messages.ErrorMessageUnsupportedValueInBuilder, value)
+}
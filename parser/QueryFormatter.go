@@ -0,0 +1,290 @@
+package parser
+
+import (
+	"fmt"
+	"goselect/parser/context"
+	"goselect/parser/expression"
+	"goselect/parser/source"
+	"goselect/parser/tokenizer"
+	"strings"
+)
+
+var formattingKeywords = map[string]bool{
+	"select": true, "distinct": true, "as": true, "from": true, "where": true, "group": true, "by": true, "having": true,
+	"order": true, "asc": true, "desc": true, "limit": true, "offset": true, "union": true, "intersect": true, "except": true,
+	"all": true, "case": true, "when": true, "then": true, "else": true, "end": true, "over": true, "partition": true,
+	"and": true, "or": true, "not": true, "like": true, "in": true, "join": true, "left": true, "inner": true, "on": true,
+	"depth": true, "to": true, "null": true,
+}
+
+type queryFormatter struct {
+	attributes *context.AllAttributes
+	functions  *context.AllFunctions
+}
+
+/*
+canonical: attributes and functions by their canonical names, infix operators as functions, values quoted only when needed
+example:   select fname, size / 1024 from . where ext = .log becomes select name, divide(size, 1024) from . where equal(extension, .log)
+*/
+func (selectQuery *SelectQuery) String() string {
+	return strings.Join(newQueryFormatter().clausesOf(selectQuery), " ")
+}
+
+func (selectQuery *SelectQuery) Pretty() string {
+	return strings.Join(newQueryFormatter().clausesOf(selectQuery), "\n")
+}
+
+func newQueryFormatter() *queryFormatter {
+	return &queryFormatter{attributes: context.NewAttributes(), functions: context.NewFunctions()}
+}
+
+func (formatter *queryFormatter) clausesOf(selectQuery *SelectQuery) []string {
+	clauses := formatter.selectClausesOf(selectQuery)
+	for _, setOperation := range selectQuery.SetOperations {
+		clauses = append(clauses, setOperation.Operator.String())
+		clauses = append(clauses, formatter.selectClausesOf(setOperation.Query)...)
+	}
+	if selectQuery.IsOrderDefined() {
+		clauses = append(clauses, formatter.orderOf(selectQuery))
+	}
+	if selectQuery.IsLimitDefined() {
+		limit := fmt.Sprintf("limit %v", selectQuery.Limit.Limit)
+		if selectQuery.Limit.Offset > 0 {
+			limit = fmt.Sprintf("%v offset %v", limit, selectQuery.Limit.Offset)
+		}
+		clauses = append(clauses, limit)
+	}
+	return clauses
+}
+
+func (formatter *queryFormatter) selectClausesOf(selectQuery *SelectQuery) []string {
+	projections := selectQuery.Projections
+	var columns []string
+	for index, anExpression := range projections.Expressions().Expressions[:projections.Count()] {
+		column := formatter.expressionOf(anExpression, selectQuery)
+		if alias := projections.AliasAt(index); len(alias) > 0 {
+			column = column + " as " + alias
+		}
+		columns = append(columns, column)
+	}
+	projection := "select "
+	if projections.IsDistinct() {
+		projection = "select distinct "
+	}
+	clauses := []string{projection + strings.Join(columns, ", ")}
+	clauses = append(clauses, formatter.sourceOf(selectQuery.Source, selectQuery)...)
+
+	if selectQuery.Where != nil && selectQuery.Where.Expression() != nil {
+		clauses = append(clauses, "where "+formatter.expressionOf(selectQuery.Where.Expression(), selectQuery))
+	}
+	if selectQuery.IsGroupByDefined() {
+		var expressions []string
+		for _, anExpression := range selectQuery.GroupBy.Expressions().Expressions {
+			expressions = append(expressions, formatter.expressionOf(anExpression, selectQuery))
+		}
+		clauses = append(clauses, "group by "+strings.Join(expressions, ", "))
+	}
+	if selectQuery.IsHavingDefined() {
+		clauses = append(clauses, "having "+formatter.expressionOf(selectQuery.Having.Expression(), selectQuery))
+	}
+	return clauses
+}
+
+func (formatter *queryFormatter) sourceOf(fileSource *source.Source, selectQuery *SelectQuery) []string {
+	var directories []string
+	for index := range fileSource.Directories {
+		directories = append(directories, literalOf(fileSource.PathAt(index)))
+	}
+	from := "from " + strings.Join(directories, ", ")
+	if len(fileSource.Alias) > 0 {
+		from = from + " as " + fileSource.Alias
+	}
+	clauses := []string{from}
+	for _, join := range fileSource.Joins {
+		joinType := "join"
+		if join.IsLeft() {
+			joinType = "left join"
+		}
+		clauses = append(clauses, fmt.Sprintf(
+			"%v %v as %v on %v",
+			joinType,
+			literalOf(join.Directory),
+			join.Alias,
+			formatter.expressionOf(join.On, selectQuery),
+		))
+	}
+	if fileSource.IsDepthDefined() {
+		if fileSource.Depth.Min > 0 {
+			clauses = append(clauses, fmt.Sprintf("depth %v to %v", fileSource.Depth.Min, fileSource.Depth.Max))
+		} else {
+			clauses = append(clauses, fmt.Sprintf("depth %v", fileSource.Depth.Max))
+		}
+	}
+	return clauses
+}
+
+func (formatter *queryFormatter) orderOf(selectQuery *SelectQuery) string {
+	expressions := selectQuery.Projections.Expressions().Expressions
+	var attributes []string
+	for index, attribute := range selectQuery.Order.Attributes {
+		orderBy := fmt.Sprintf("%v", attribute.ProjectionPosition)
+		if attribute.ProjectionPosition > selectQuery.Projections.Count() {
+			orderBy = formatter.expressionOf(expressions[attribute.ProjectionPosition-1], selectQuery)
+		}
+		if !selectQuery.Order.IsAscendingAt(index) {
+			orderBy = orderBy + " desc"
+		}
+		attributes = append(attributes, orderBy)
+	}
+	return "order by " + strings.Join(attributes, ", ")
+}
+
+func (formatter *queryFormatter) expressionOf(anExpression *expression.Expression, selectQuery *SelectQuery) string {
+	if attribute := anExpression.Attribute(); len(attribute) > 0 {
+		return formatter.attributes.CanonicalNameOf(attribute)
+	}
+	functionName := anExpression.FunctionName()
+	if len(functionName) == 0 {
+		if anExpression.Value().IsNull() {
+			return anExpression.Value().GetAsLiteral()
+		}
+		if literal := anExpression.Literal(); len(literal) > 0 {
+			return formatter.valueOf(literal)
+		}
+		if value := anExpression.Value().GetAsLiteral(); context.IsATimeLiteral(value) {
+			return quotedLiteralOf(value)
+		}
+		return formatter.valueOf(anExpression.Value().GetAsLiteral())
+	}
+	if canonicalName := formatter.functions.CanonicalNameOf(functionName); len(canonicalName) > 0 {
+		functionName = canonicalName
+	}
+	args := anExpression.FunctionArgs()
+	if subquery := anExpression.FunctionSubquery(); subquery != nil {
+		for _, parsedSubquery := range selectQuery.Subqueries {
+			if parsedSubquery.Values == subquery.Values() {
+				return fmt.Sprintf("%v in (%v)", formatter.expressionOf(args[0], selectQuery), parsedSubquery.Query.String())
+			}
+		}
+	}
+	if functionName == context.FunctionNameIf && len(args) == 3 && isABlankValue(args[2]) {
+		return fmt.Sprintf(
+			"case when %v then %v end",
+			formatter.expressionOf(args[0], selectQuery),
+			formatter.expressionOf(args[1], selectQuery),
+		)
+	}
+	if functionName == context.FunctionNameCast && len(args) == 2 && len(args[1].FunctionName()) == 0 && len(args[1].Attribute()) == 0 {
+		return fmt.Sprintf("cast(%v as %v)", formatter.expressionOf(args[0], selectQuery), args[1].Value().GetAsString())
+	}
+	var formattedArgs []string
+	for _, arg := range args {
+		formattedArgs = append(formattedArgs, formatter.expressionOf(arg, selectQuery))
+	}
+	function := functionName + "(" + strings.Join(formattedArgs, ", ") + ")"
+	if window := anExpression.Window(); window != nil {
+		return function + " over (" + formatter.windowOf(window, selectQuery) + ")"
+	}
+	return function
+}
+
+func (formatter *queryFormatter) windowOf(window *expression.Window, selectQuery *SelectQuery) string {
+	var clauses []string
+	if len(window.PartitionBy()) > 0 {
+		var partitionBy []string
+		for _, anExpression := range window.PartitionBy() {
+			partitionBy = append(partitionBy, formatter.expressionOf(anExpression, selectQuery))
+		}
+		clauses = append(clauses, "partition by "+strings.Join(partitionBy, ", "))
+	}
+	if len(window.OrderBy()) > 0 {
+		var orderBy []string
+		for index, anExpression := range window.OrderBy() {
+			orderingBy := formatter.expressionOf(anExpression, selectQuery)
+			if !window.IsAscendingAt(index) {
+				orderingBy = orderingBy + " desc"
+			}
+			orderBy = append(orderBy, orderingBy)
+		}
+		clauses = append(clauses, "order by "+strings.Join(orderBy, ", "))
+	}
+	return strings.Join(clauses, " ")
+}
+
+func isABlankValue(anExpression *expression.Expression) bool {
+	return len(anExpression.Attribute()) == 0 &&
+		len(anExpression.FunctionName()) == 0 &&
+		len(anExpression.Value().GetAsString()) == 0
+}
+
+func (formatter *queryFormatter) valueOf(value string) string {
+	if strings.Contains(value, "'") && strings.Contains(value, "\"") {
+		return formatter.concatenationOf(value)
+	}
+	if formatter.attributes.IsASupportedAttribute(value) || formatter.functions.IsASupportedFunction(value) {
+		return quotedLiteralOf(value)
+	}
+	return literalOf(value)
+}
+
+/*
+both quotes: a quoted literal can not hold both the quotes, so the value is split around the single quotes and concatenated
+example:     it's "x" becomes concat(it, "'", 's "x"')
+*/
+func (formatter *queryFormatter) concatenationOf(value string) string {
+	var parts []string
+	for index, part := range strings.Split(value, "'") {
+		if index > 0 {
+			parts = append(parts, quotedLiteralOf("'"))
+		}
+		if len(part) > 0 {
+			parts = append(parts, formatter.valueOf(part))
+		}
+	}
+	return context.FunctionNameConcat + "(" + strings.Join(parts, ", ") + ")"
+}
+
+func literalOf(value string) string {
+	tokens := tokenizer.NewTokenizer(value).Tokenize().Iterator()
+	if tokens.HasNext() {
+		token := tokens.Next()
+		if !tokens.HasNext() && token.TokenValue == value && isAPlainValue(token) {
+			return value
+		}
+	}
+	return quotedLiteralOf(value)
+}
+
+func isAPlainValue(token tokenizer.Token) bool {
+	switch token.TokenType {
+	case tokenizer.RawString, tokenizer.Numeric, tokenizer.FloatingPoint, tokenizer.Boolean:
+		return !formattingKeywords[strings.ToLower(token.TokenValue)]
+	}
+	return false
+}
+
+func quotedLiteralOf(value string) string {
+	if strings.Contains(value, "'") {
+		return "\"" + escapedBackSlashes(value) + "\""
+	}
+	return "'" + escapedBackSlashes(value) + "'"
+}
+
+/*
+escape: the reverse of the tokenizer, a backslash that the tokenizer would drop is doubled
+example: x\ y becomes x\\ y, a\ becomes a\\, (\w+)\.log stays as it is
+*/
+func escapedBackSlashes(value string) string {
+	var escaped strings.Builder
+	for index := 0; index < len(value); index++ {
+		if value[index] == '\\' && (index+1 == len(value) || isAnEscapedCharacter(value[index+1])) {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteByte(value[index])
+	}
+	return escaped.String()
+}
+
+func isAnEscapedCharacter(ch byte) bool {
+	return ch == '\'' || ch == '"' || ch == ' '
+}
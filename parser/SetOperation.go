@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"errors"
+	"goselect/parser/error/messages"
+	"goselect/parser/tokenizer"
+)
+
+type SetOperator int
+
+const (
+	SetOperatorUnion SetOperator = iota
+	SetOperatorUnionAll
+	SetOperatorExcept
+	SetOperatorIntersect
+)
+
+func (operator SetOperator) String() string {
+	switch operator {
+	case SetOperatorUnionAll:
+		return "union all"
+	case SetOperatorExcept:
+		return "except"
+	case SetOperatorIntersect:
+		return "intersect"
+	default:
+		return "union"
+	}
+}
+
+type SetOperation struct {
+	Operator SetOperator
+	Query    *SelectQuery
+}
+
+/*
+query:   select [union [all] | except | intersect select]* [order by] [limit]
+example: select name from ~/a union select name from ~/b order by 1
+the set operations are applied from left to right, 'order by' and 'limit' after the last select apply to the combined results
+*/
+func splitOnSetOperators(iterator *tokenizer.TokenIterator) ([]*tokenizer.Tokens, []SetOperator, error) {
+	var segments []*tokenizer.Tokens
+	var operators []SetOperator
+
+	segment := tokenizer.NewEmptyTokens()
+	depth := 0
+	for iterator.HasNext() {
+		token := iterator.Next()
+		if token.Equals("(") {
+			depth = depth + 1
+		} else if token.Equals(")") {
+			depth = depth - 1
+		}
+		if depth > 0 {
+			segment.Add(token)
+			continue
+		}
+		operator, isASetOperator := setOperatorFor(token, iterator)
+		if !isASetOperator {
+			segment.Add(token)
+			continue
+		}
+		if !iterator.HasNext() || !iterator.Peek().Equals("select") {
+			return nil, nil, errors.New(messages.ErrorMessageExpectedSelectAfterSetOperator)
+		}
+		segments = append(segments, segment)
+		operators = append(operators, operator)
+		segment = tokenizer.NewEmptyTokens()
+	}
+	return append(segments, segment), operators, nil
+}
+
+func setOperatorFor(token tokenizer.Token, iterator *tokenizer.TokenIterator) (SetOperator, bool) {
+	switch {
+	case token.Equals("union"):
+		if iterator.HasNext() && iterator.Peek().Equals("all") {
+			iterator.Next()
+			return SetOperatorUnionAll, true
+		}
+		return SetOperatorUnion, true
+	case token.Equals("except"):
+		return SetOperatorExcept, true
+	case token.Equals("intersect"):
+		return SetOperatorIntersect, true
+	}
+	return -1, false
+}
@@ -11,6 +11,9 @@ type SumFunctionBlock struct{}
 type AverageFunctionBlock struct{}
 type MinFunctionBlock struct{}
 type MaxFunctionBlock struct{}
+type RowNumberFunctionBlock struct{}
+type RankFunctionBlock struct{}
+type DenseRankFunctionBlock struct{}
 
 func (c *CountFunctionBlock) initialState() *FunctionState {
 	return &FunctionState{Initial: zeroUint32Value, isUpdated: false}
@@ -24,6 +27,9 @@ func (c *CountFunctionBlock) finalValue(currentState *FunctionState, _ []Value)
 	if currentState.isUpdated {
 		return currentState.Initial, nil
 	}
+	if currentState.hasNulls {
+		return zeroUint32Value, nil
+	}
 	return oneUint32Value, nil
 }
 
@@ -51,6 +57,9 @@ func (c *CountDistinctFunctionBlock) finalValue(currentState *FunctionState, _ [
 	if currentState.isUpdated {
 		return Uint32Value(uint32(len(currentState.extras))), nil
 	}
+	if currentState.hasNulls {
+		return zeroUint32Value, nil
+	}
 	return oneUint32Value, nil
 }
 
@@ -79,6 +88,9 @@ func (c *SumFunctionBlock) finalValue(currentState *FunctionState, values []Valu
 	if currentState.isUpdated {
 		return currentState.Initial, nil
 	}
+	if currentState.hasNulls {
+		return NullValue, nil
+	}
 	if err := ensureNParametersOrError(values, FunctionNameSum, 1); err != nil {
 		return EmptyValue, err
 	}
@@ -118,6 +130,9 @@ func (a *AverageFunctionBlock) finalValue(currentState *FunctionState, values []
 		asFloat64, _ := currentState.Initial.GetNumericAsFloat64()
 		return Float64Value(asFloat64 / ((float64)(currentState.extras["count"].uint32Value))), nil
 	}
+	if currentState.hasNulls {
+		return NullValue, nil
+	}
 	if err := ensureNParametersOrError(values, FunctionNameAverage, 1); err != nil {
 		return EmptyValue, err
 	}
@@ -160,6 +175,9 @@ func (m *MinFunctionBlock) finalValue(currentState *FunctionState, values []Valu
 	if currentState.isUpdated {
 		return currentState.Initial, nil
 	}
+	if currentState.hasNulls {
+		return NullValue, nil
+	}
 	if err := ensureNParametersOrError(values, FunctionNameMin, 1); err != nil {
 		return EmptyValue, err
 	}
@@ -198,8 +216,75 @@ func (m *MaxFunctionBlock) finalValue(currentState *FunctionState, values []Valu
 	if currentState.isUpdated {
 		return currentState.Initial, nil
 	}
+	if currentState.hasNulls {
+		return NullValue, nil
+	}
 	if err := ensureNParametersOrError(values, FunctionNameMax, 1); err != nil {
 		return EmptyValue, err
 	}
 	return values[0], nil
 }
+
+func (r *RowNumberFunctionBlock) initialState() *FunctionState {
+	return &FunctionState{Initial: zeroUint32Value, isUpdated: false}
+}
+
+func (r *RowNumberFunctionBlock) run(initialState *FunctionState, _ ...Value) (*FunctionState, error) {
+	return &FunctionState{Initial: Uint32Value(initialState.Initial.uint32Value + 1), isUpdated: true}, nil
+}
+
+func (r *RowNumberFunctionBlock) finalValue(currentState *FunctionState, _ []Value) (Value, error) {
+	return currentState.Initial, nil
+}
+
+func (r *RankFunctionBlock) initialState() *FunctionState {
+	return &FunctionState{Initial: zeroUint32Value, extras: map[interface{}]Value{"count": zeroUint32Value}, isUpdated: false}
+}
+
+func (r *RankFunctionBlock) run(initialState *FunctionState, args ...Value) (*FunctionState, error) {
+	count := initialState.extras["count"].uint32Value + 1
+	rank := count
+	if initialState.isUpdated && isAPeer(initialState, args) {
+		rank = initialState.Initial.uint32Value
+	}
+	return rankedState(rank, count, args), nil
+}
+
+func (r *RankFunctionBlock) finalValue(currentState *FunctionState, _ []Value) (Value, error) {
+	return currentState.Initial, nil
+}
+
+func (d *DenseRankFunctionBlock) initialState() *FunctionState {
+	return &FunctionState{Initial: zeroUint32Value, extras: map[interface{}]Value{"count": zeroUint32Value}, isUpdated: false}
+}
+
+func (d *DenseRankFunctionBlock) run(initialState *FunctionState, args ...Value) (*FunctionState, error) {
+	count := initialState.extras["count"].uint32Value + 1
+	rank := initialState.Initial.uint32Value + 1
+	if initialState.isUpdated && isAPeer(initialState, args) {
+		rank = initialState.Initial.uint32Value
+	}
+	return rankedState(rank, count, args), nil
+}
+
+func (d *DenseRankFunctionBlock) finalValue(currentState *FunctionState, _ []Value) (Value, error) {
+	return currentState.Initial, nil
+}
+
+func isAPeer(state *FunctionState, orderValues []Value) bool {
+	for index, value := range orderValues {
+		previous, ok := state.extras[index]
+		if !ok || previous.CompareTo(value) != CompareToEqual {
+			return false
+		}
+	}
+	return true
+}
+
+func rankedState(rank uint32, count uint32, orderValues []Value) *FunctionState {
+	extras := map[interface{}]Value{"count": Uint32Value(count)}
+	for index, value := range orderValues {
+		extras[index] = value
+	}
+	return &FunctionState{Initial: Uint32Value(rank), extras: extras, isUpdated: true}
+}
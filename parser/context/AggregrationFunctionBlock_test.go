@@ -4,6 +4,7 @@
 package context
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -352,3 +353,117 @@ func TestMaxGivenTheInitialStateIsNotUpdated(t *testing.T) {
 		t.Fatalf("Expected max to be %v, received %v", "pqr", actualValue)
 	}
 }
+
+func TestRowNumber(t *testing.T) {
+	allFunctions := NewFunctions()
+	initialState := allFunctions.InitialState("rownumber")
+
+	state, _ := allFunctions.ExecuteAggregate("rownumber", initialState)
+	state, _ = allFunctions.ExecuteAggregate("rownumber", state)
+
+	finalValue, _ := allFunctions.FinalValue("rownumber", state, nil)
+	actualValue := finalValue.GetAsString()
+	if actualValue != "2" {
+		t.Fatalf("Expected rownumber to be %v, received %v", "2", actualValue)
+	}
+}
+
+func TestRankWithPeers(t *testing.T) {
+	allFunctions := NewFunctions()
+	state := allFunctions.InitialState("rank")
+
+	var ranks []string
+	for _, size := range []int{30, 20, 20, 10} {
+		state, _ = allFunctions.ExecuteAggregate("rank", state, IntValue(size))
+		finalValue, _ := allFunctions.FinalValue("rank", state, nil)
+		ranks = append(ranks, finalValue.GetAsString())
+	}
+	if strings.Join(ranks, ",") != "1,2,2,4" {
+		t.Fatalf("Expected ranks to be %v, received %v", "1,2,2,4", ranks)
+	}
+}
+
+func TestDenseRankWithPeers(t *testing.T) {
+	allFunctions := NewFunctions()
+	state := allFunctions.InitialState("dense_rank")
+
+	var ranks []string
+	for _, size := range []int{30, 20, 20, 10} {
+		state, _ = allFunctions.ExecuteAggregate("dense_rank", state, IntValue(size))
+		finalValue, _ := allFunctions.FinalValue("dense_rank", state, nil)
+		ranks = append(ranks, finalValue.GetAsString())
+	}
+	if strings.Join(ranks, ",") != "1,2,2,3" {
+		t.Fatalf("Expected ranks to be %v, received %v", "1,2,2,3", ranks)
+	}
+}
+
+func TestRankWithoutOrderValues(t *testing.T) {
+	allFunctions := NewFunctions()
+	state := allFunctions.InitialState("rank")
+
+	state, _ = allFunctions.ExecuteAggregate("rank", state)
+	state, _ = allFunctions.ExecuteAggregate("rank", state)
+
+	finalValue, _ := allFunctions.FinalValue("rank", state, nil)
+	actualValue := finalValue.GetAsString()
+	if actualValue != "1" {
+		t.Fatalf("Expected rank to be %v, received %v", "1", actualValue)
+	}
+}
+
+func TestCountSkippingNulls(t *testing.T) {
+	allFunctions := NewFunctions()
+	state := allFunctions.InitialState("count")
+
+	state, _ = allFunctions.ExecuteAggregate("count", state, StringValue("arg1"))
+	state, _ = allFunctions.ExecuteAggregate("count", state, NullValue)
+	state, _ = allFunctions.ExecuteAggregate("count", state, StringValue("arg1"))
+
+	finalValue, _ := allFunctions.FinalValue("count", state, nil)
+	actualValue := finalValue.GetAsString()
+	if actualValue != "2" {
+		t.Fatalf("Expected count to be %v, received %v", "2", actualValue)
+	}
+}
+
+func TestCountWithOnlyNulls(t *testing.T) {
+	allFunctions := NewFunctions()
+	state := allFunctions.InitialState("count")
+
+	state, _ = allFunctions.ExecuteAggregate("count", state, NullValue)
+
+	finalValue, _ := allFunctions.FinalValue("count", state, nil)
+	actualValue := finalValue.GetAsString()
+	if actualValue != "0" {
+		t.Fatalf("Expected count to be %v, received %v", "0", actualValue)
+	}
+}
+
+func TestAverageSkippingNulls(t *testing.T) {
+	allFunctions := NewFunctions()
+	state := allFunctions.InitialState("avg")
+
+	state, _ = allFunctions.ExecuteAggregate("avg", state, IntValue(10))
+	state, _ = allFunctions.ExecuteAggregate("avg", state, NullValue)
+	state, _ = allFunctions.ExecuteAggregate("avg", state, IntValue(20))
+
+	finalValue, _ := allFunctions.FinalValue("avg", state, nil)
+	actualValue := finalValue.GetAsString()
+	if actualValue != "15.00" {
+		t.Fatalf("Expected average to be %v, received %v", "15.00", actualValue)
+	}
+}
+
+func TestMaxWithOnlyNulls(t *testing.T) {
+	allFunctions := NewFunctions()
+	state := allFunctions.InitialState("max")
+
+	state, _ = allFunctions.ExecuteAggregate("max", state, NullValue)
+	state, _ = allFunctions.ExecuteAggregate("max", state, NullValue)
+
+	finalValue, _ := allFunctions.FinalValue("max", state, []Value{NullValue})
+	if !finalValue.IsNull() {
+		t.Fatalf("Expected max to be null, received %v", finalValue)
+	}
+}
@@ -13,7 +13,7 @@ type MimeTypeAttributeEvaluationBlock struct{}
 func (m MimeTypeAttributeEvaluationBlock) evaluate(filePath string) Value {
 	mime, err := mimetype.DetectFile(filePath)
 	if err != nil {
-		return StringValue("NA")
+		return NullValue
 	}
 	return StringValue(mime.String())
 }
@@ -31,7 +31,7 @@ func TestMimeTypeForANonExistingFile(t *testing.T) {
 	block := MimeTypeAttributeEvaluationBlock{}
 	value := block.evaluate("non-existent")
 
-	if value.GetAsString() != "NA" {
-		t.Fatalf("Expected %v while determining the mime type of a non-existent file, received %v", "NA", value.GetAsString())
+	if !value.IsNull() {
+		t.Fatalf("Expected null while determining the mime type of a non-existent file, received %v", value.GetAsString())
 	}
 }
@@ -22,6 +22,7 @@ const (
 	AttributeCreatedTime        = "createdtime"
 	AttributeModifiedTime       = "modifiedtime"
 	AttributeAccessedTime       = "accessedtime"
+	AttributeAge                = "age"
 	AttributeExtension          = "extension"
 	AttributePermission         = "permission"
 	AttributeUserRead           = "userread"
@@ -40,6 +41,9 @@ const (
 	AttributeGroupId            = "groupid"
 	AttributeGroupName          = "groupname"
 	AttributeMimeType           = "mimetype"
+	AttributeRoot               = "root"
+	AttributePathFromRoot       = "pathfromroot"
+	AttributeDepth              = "depth"
 )
 
 var attributeDefinitions = map[string]*AttributeDefinition{
@@ -95,6 +99,10 @@ var attributeDefinitions = map[string]*AttributeDefinition{
 		aliases:     []string{"accessedtime", "accesstime", "atime"},
 		description: "Returns the access time of the file.",
 	},
+	AttributeAge: {
+		aliases:     []string{"age"},
+		description: "Returns the duration since the file was modified, like 3d 4h 12m 5s. \nFor example, age > 7d selects the files modified more than 7 days ago, formatduration(age, hour) returns 3d 4h.",
+	},
 	AttributeExtension: {
 		aliases:     []string{"extension", "ext"},
 		description: "Return the file extension. \nFor example, extension of the file 'sample.log' is '.log'.",
@@ -165,9 +173,21 @@ var attributeDefinitions = map[string]*AttributeDefinition{
 	},
 	AttributeMimeType: {
 		aliases:             []string{"mimetype", "mime"},
-		description:         "Returns the mime type of a file.",
+		description:         "Returns the mime type of a file, null if the mime type can not be detected, for example for a directory.",
 		lazyEvaluationBlock: MimeTypeAttributeEvaluationBlock{},
 	},
+	AttributeRoot: {
+		aliases:     []string{"root"},
+		description: "Returns the source directory (from the 'from' clause) that the file was found in.",
+	},
+	AttributePathFromRoot: {
+		aliases:     []string{"pathfromroot", "relpath", "rpath"},
+		description: "Returns the path of the file relative to its source directory. \nFor example, relpath can match the same files in two directories, select a.relpath from ./src as a join ./backup as b on eq(a.relpath, b.relpath).",
+	},
+	AttributeDepth: {
+		aliases:     []string{"depth"},
+		description: "Returns the depth of the file relative to its source directory. \nFor example, files directly inside the source directory are at depth 1, files inside their directories are at depth 2.",
+	},
 }
 
 type AllAttributes struct {
@@ -189,6 +209,22 @@ func (attributes *AllAttributes) IsASupportedAttribute(attribute string) bool {
 	return ok
 }
 
+func (attributes *AllAttributes) CanonicalNameOf(attribute string) string {
+	definition, ok := attributes.supportedAttributes[strings.ToLower(attribute)]
+	if !ok {
+		if qualifier, name, isQualified := QualifiedAttribute(attribute); isQualified && attributes.IsASupportedAttribute(name) {
+			return qualifier + "." + attributes.CanonicalNameOf(name)
+		}
+		return ""
+	}
+	for name, aDefinition := range attributeDefinitions {
+		if aDefinition == definition {
+			return name
+		}
+	}
+	return ""
+}
+
 func (attributes *AllAttributes) AllAttributeWithAliases() map[string][]string {
 	supportedAttributes := make(map[string][]string)
 	for _, definition := range attributeDefinitions {
@@ -221,6 +257,14 @@ func (attributes *AllAttributes) attributeDefinitionFor(attribute string) *Attri
 	return nil
 }
 
+func QualifiedAttribute(attribute string) (string, string, bool) {
+	index := strings.Index(attribute, ".")
+	if index <= 0 || index == len(attribute)-1 {
+		return "", "", false
+	}
+	return attribute[:index], attribute[index+1:], true
+}
+
 func IsAWildcardAttribute(attribute string) bool {
 	return attribute == "*"
 }
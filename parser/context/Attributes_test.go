@@ -104,3 +104,21 @@ func TestAttributesOnWildcard(t *testing.T) {
 		t.Fatalf("Expected attributes on wildcard to be %v, received %v", expected, attributes)
 	}
 }
+
+func TestCanonicalNameOfAnAttributeAlias(t *testing.T) {
+	attributes := NewAttributes()
+	canonicalName := attributes.CanonicalNameOf("fName")
+
+	if canonicalName != AttributeName {
+		t.Fatalf("Expected canonical name to be %v, received %v", AttributeName, canonicalName)
+	}
+}
+
+func TestCanonicalNameOfAnUnknownAttribute(t *testing.T) {
+	attributes := NewAttributes()
+	canonicalName := attributes.CanonicalNameOf("unknown")
+
+	if canonicalName != "" {
+		t.Fatalf("Expected canonical name to be blank, received %v", canonicalName)
+	}
+}
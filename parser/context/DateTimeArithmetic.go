@@ -0,0 +1,93 @@
+package context
+
+import (
+	"fmt"
+	"goselect/parser/error/messages"
+	"strings"
+	"time"
+)
+
+const (
+	DateTimeUnitSecond = "second"
+	DateTimeUnitMinute = "minute"
+	DateTimeUnitHour   = "hour"
+	DateTimeUnitDay    = "day"
+	DateTimeUnitWeek   = "week"
+	DateTimeUnitMonth  = "month"
+	DateTimeUnitYear   = "year"
+)
+
+var dateTimeUnits = []string{
+	DateTimeUnitSecond,
+	DateTimeUnitMinute,
+	DateTimeUnitHour,
+	DateTimeUnitDay,
+	DateTimeUnitWeek,
+	DateTimeUnitMonth,
+	DateTimeUnitYear,
+}
+
+/*
+unit:    second, minute, hour, day, week, month or year, along with their plurals like days
+example: dateadd(mtime, -7, days), datetrunc(mtime, week)
+*/
+func dateTimeUnitOf(value Value) (string, error) {
+	unit := strings.TrimSuffix(strings.ToLower(value.GetAsString()), "s")
+	for _, supportedUnit := range dateTimeUnits {
+		if unit == supportedUnit {
+			return unit, nil
+		}
+	}
+	return "", fmt.Errorf(messages.ErrorMessageIncorrectDateTimeUnit, strings.Join(dateTimeUnits, ", "), value.GetAsString())
+}
+
+func addToDateTime(aTime time.Time, amount int, unit string) time.Time {
+	switch unit {
+	case DateTimeUnitSecond:
+		return aTime.Add(time.Duration(amount) * time.Second)
+	case DateTimeUnitMinute:
+		return aTime.Add(time.Duration(amount) * time.Minute)
+	case DateTimeUnitHour:
+		return aTime.Add(time.Duration(amount) * time.Hour)
+	case DateTimeUnitDay:
+		return aTime.AddDate(0, 0, amount)
+	case DateTimeUnitWeek:
+		return aTime.AddDate(0, 0, 7*amount)
+	case DateTimeUnitMonth:
+		return addMonths(aTime, amount)
+	default:
+		return addMonths(aTime, 12*amount)
+	}
+}
+
+func addMonths(aTime time.Time, months int) time.Time {
+	year, month, day := aTime.Date()
+	hour, minute, second := aTime.Clock()
+	firstOfTheMonth := time.Date(year, month+time.Month(months), 1, hour, minute, second, aTime.Nanosecond(), aTime.Location())
+	if lastDay := firstOfTheMonth.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return firstOfTheMonth.AddDate(0, 0, day-1)
+}
+
+func truncateDateTime(aTime time.Time, unit string) time.Time {
+	year, month, day := aTime.Date()
+	hour, minute, second := aTime.Clock()
+	switch unit {
+	case DateTimeUnitSecond:
+		return time.Date(year, month, day, hour, minute, second, 0, aTime.Location())
+	case DateTimeUnitMinute:
+		return time.Date(year, month, day, hour, minute, 0, 0, aTime.Location())
+	case DateTimeUnitHour:
+		return time.Date(year, month, day, hour, 0, 0, 0, aTime.Location())
+	case DateTimeUnitDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, aTime.Location())
+	case DateTimeUnitWeek:
+		daysSinceMonday := (int(aTime.Weekday()) + 6) % 7
+		return time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, aTime.Location())
+	case DateTimeUnitMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, aTime.Location())
+	default:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, aTime.Location())
+	}
+}
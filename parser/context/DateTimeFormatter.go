@@ -2,7 +2,10 @@ package context
 
 import (
 	"errors"
+	"fmt"
 	"goselect/parser/error/messages"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -13,12 +16,45 @@ const (
 	layoutDateTimestampFull = "2006-01-02T15:04:05.000Z"
 )
 
+const (
+	directivesForParsing    = "%Y %y %m %d %e %H %I %M %S %p %b %B %a %A %j %Z %z %F %T %%"
+	directivesForFormatting = directivesForParsing + " %s %u %w %V %G"
+)
+
+var formatIdRegexp = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+var layoutsByDirective = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'b': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'j': "002",
+	'Z': "MST",
+	'z': "-0700",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'%': "%",
+}
+
 type FormatDefinition struct {
-	Format string
-	Id     string
+	Format   string
+	Id       string
+	strftime bool
 }
 
-var formatDefinitions = map[string]FormatDefinition{
+type DateTimeFormats map[string]FormatDefinition
+
+var formatDefinitions = DateTimeFormats{
 	"dt": {
 		Format: layoutDate,
 		Id:     "dt",
@@ -33,15 +69,135 @@ var formatDefinitions = map[string]FormatDefinition{
 	},
 }
 
-func parse(str, id string) (time.Time, error) {
+var layoutProbes = []time.Time{
+	time.Date(2019, 11, 23, 21, 37, 48, 0, time.FixedZone("XYZ", 3*60*60+30*60)),
+	time.Date(2031, 7, 9, 8, 6, 3, 0, time.FixedZone("ZYX", -8*60*60)),
+}
+
+func formatDateTime(aTime time.Time, patternOrId string) (string, error) {
+	return formatDefinitions.format(aTime, patternOrId)
+}
+
+func SupportedFormats() map[string]FormatDefinition {
+	return formatDefinitions.copy()
+}
+
+func (formats DateTimeFormats) copy() DateTimeFormats {
+	copied := make(DateTimeFormats, len(formats))
+	for id, definition := range formats {
+		copied[id] = definition
+	}
+	return copied
+}
+
+/*
+pattern: strftime-style directives like %Y-%m-%d, every other character is copied as it is
+format:  %Y %y %m %d %e %H %I %M %S %p %b %B %a %A %j %Z %z %F %T %% along with %s %u %w %V %G
+parse:   all the directives except %s %u %w %V %G, the other text can not look like a date/time layout (1, 2, Jan, Mon, PM ..)
+example: register("dmy", "%d-%m-%Y") allows parsedatetime(18-10-2022, dmy) and formatdatetime(mtime, dmy)
+*/
+func (formats DateTimeFormats) register(id string, pattern string) error {
+	if !formatIdRegexp.MatchString(id) {
+		return fmt.Errorf(messages.ErrorMessageInvalidDateTimeFormatId, id)
+	}
+	if _, err := formatWith(time.Time{}, pattern); err != nil {
+		return err
+	}
 	idToLower := strings.ToLower(id)
-	definition, ok := formatDefinitions[idToLower]
+	if existing, ok := formats[idToLower]; ok && (!existing.strftime || existing.Format != pattern) {
+		return fmt.Errorf(messages.ErrorMessageDateTimeFormatIdAlreadyDefined, id)
+	}
+	formats[idToLower] = FormatDefinition{Format: pattern, Id: idToLower, strftime: true}
+	return nil
+}
+
+func (formats DateTimeFormats) parse(str, id string, location *time.Location) (time.Time, error) {
+	layout, err := formats.layoutOf(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(layout, str, location)
+}
+
+func (formats DateTimeFormats) layoutOf(id string) (string, error) {
+	definition, ok := formats[strings.ToLower(id)]
 	if !ok {
-		return time.Time{}, errors.New(messages.ErrorMessageUnsupportedDateTimeFormat)
+		return "", errors.New(messages.ErrorMessageUnsupportedDateTimeFormat)
+	}
+	if definition.strftime {
+		return parsingLayoutOf(definition.Format)
 	}
-	return time.Parse(definition.Format, str)
+	return definition.Format, nil
 }
 
-func SupportedFormats() map[string]FormatDefinition {
-	return formatDefinitions
+func (formats DateTimeFormats) format(aTime time.Time, patternOrId string) (string, error) {
+	definition, ok := formats[strings.ToLower(patternOrId)]
+	if !ok {
+		return formatWith(aTime, patternOrId)
+	}
+	if definition.strftime {
+		return formatWith(aTime, definition.Format)
+	}
+	return aTime.Format(definition.Format), nil
+}
+
+func formatWith(aTime time.Time, pattern string) (string, error) {
+	var formatted strings.Builder
+	for index := 0; index < len(pattern); index++ {
+		if pattern[index] != '%' {
+			formatted.WriteByte(pattern[index])
+			continue
+		}
+		if index == len(pattern)-1 {
+			return "", fmt.Errorf(messages.ErrorMessageUnsupportedDateTimeDirective, pattern, directivesForFormatting, "%")
+		}
+		index = index + 1
+		directive := pattern[index]
+		if layout, ok := layoutsByDirective[directive]; ok {
+			formatted.WriteString(aTime.Format(layout))
+			continue
+		}
+		isoYear, isoWeek := aTime.ISOWeek()
+		switch directive {
+		case 's':
+			formatted.WriteString(strconv.FormatInt(aTime.Unix(), 10))
+		case 'u':
+			formatted.WriteString(strconv.Itoa((int(aTime.Weekday())+6)%7 + 1))
+		case 'w':
+			formatted.WriteString(strconv.Itoa(int(aTime.Weekday())))
+		case 'V':
+			formatted.WriteString(fmt.Sprintf("%02d", isoWeek))
+		case 'G':
+			formatted.WriteString(strconv.Itoa(isoYear))
+		default:
+			return "", fmt.Errorf(messages.ErrorMessageUnsupportedDateTimeDirective, pattern, directivesForFormatting, "%"+string(directive))
+		}
+	}
+	return formatted.String(), nil
+}
+
+func parsingLayoutOf(pattern string) (string, error) {
+	var layout strings.Builder
+	for index := 0; index < len(pattern); index++ {
+		if pattern[index] != '%' {
+			layout.WriteByte(pattern[index])
+			continue
+		}
+		if index == len(pattern)-1 {
+			return "", fmt.Errorf(messages.ErrorMessageUnsupportedDateTimeDirective, pattern, directivesForParsing, "%")
+		}
+		index = index + 1
+		directiveLayout, ok := layoutsByDirective[pattern[index]]
+		if !ok {
+			return "", fmt.Errorf(messages.ErrorMessageUnsupportedDateTimeDirective, pattern, directivesForParsing, "%"+string(pattern[index]))
+		}
+		layout.WriteString(directiveLayout)
+	}
+	for _, probe := range layoutProbes {
+		formatted, _ := formatWith(probe, pattern)
+		if probe.Format(layout.String()) != formatted {
+			return "", fmt.Errorf(messages.ErrorMessageDateTimeFormatNotParseable, pattern)
+		}
+	}
+	return layout.String(), nil
 }
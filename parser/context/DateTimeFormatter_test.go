@@ -5,10 +5,11 @@ package context
 
 import (
 	"testing"
+	"time"
 )
 
 func TestParseDateTime1(t *testing.T) {
-	time, _ := parse("2009-08-28", "dt")
+	time, _ := formatDefinitions.parse("2009-08-28", "dt", time.UTC)
 	dateAsStr := formatDate(time).GetAsString()
 	expected := "2009-August-28"
 
@@ -18,7 +19,7 @@ func TestParseDateTime1(t *testing.T) {
 }
 
 func TestParseDateTime2(t *testing.T) {
-	time, _ := parse("2009-08-28T10:14:28", "ts")
+	time, _ := formatDefinitions.parse("2009-08-28T10:14:28", "ts", time.UTC)
 	expected := "2009-08-28 10:14:28 +0000 UTC"
 
 	if expected != time.String() {
@@ -27,7 +28,7 @@ func TestParseDateTime2(t *testing.T) {
 }
 
 func TestParseDateTime3(t *testing.T) {
-	time, _ := parse("2009-08-28T10:14:29.009Z", "tsfull")
+	time, _ := formatDefinitions.parse("2009-08-28T10:14:29.009Z", "tsfull", time.UTC)
 	expected := "2009-08-28 10:14:29.009 +0000 UTC"
 
 	if expected != time.String() {
@@ -36,7 +37,7 @@ func TestParseDateTime3(t *testing.T) {
 }
 
 func TestParseDateTime4(t *testing.T) {
-	_, err := parse("2009-08-28T10:14:29.009Z", "unknown")
+	_, err := formatDefinitions.parse("2009-08-28T10:14:29.009Z", "unknown", time.UTC)
 
 	if err == nil {
 		t.Fatalf("Expected an error while parsing a date/time with an unknown Id")
@@ -44,7 +45,7 @@ func TestParseDateTime4(t *testing.T) {
 }
 
 func TestParseDateTime5(t *testing.T) {
-	_, err := parse("2009-August-28", "dt")
+	_, err := formatDefinitions.parse("2009-August-28", "dt", time.UTC)
 
 	if err == nil {
 		t.Fatalf("Expected an error while parsing a date/time in an unsupported Format")
@@ -57,3 +58,148 @@ func TestDateTimeSupportedFormats(t *testing.T) {
 		t.Fatalf("Expected length of supported formats to be greater than zero but was zer")
 	}
 }
+
+func TestDateTimeSupportedFormatsIsACopy(t *testing.T) {
+	delete(SupportedFormats(), "dt")
+	if _, ok := SupportedFormats()["dt"]; !ok {
+		t.Fatalf("Expected the supported formats to be unaffected by a change in the returned formats")
+	}
+}
+
+func TestRegisterFormatAndParse(t *testing.T) {
+	formats := newTimeContext().formats
+	if err := formats.register("dmy", "%d-%m-%Y"); err != nil {
+		t.Fatalf("Expected no error while registering a format, received %v", err)
+	}
+	aTime, _ := formats.parse("18-10-2022", "dmy", time.UTC)
+	expected := "2022-10-18 00:00:00 +0000 UTC"
+
+	if expected != aTime.String() {
+		t.Fatalf("Expected parsing of date/time to return %v, received %v", expected, aTime.String())
+	}
+}
+
+func TestRegisterFormatIsScopedToItsFormats(t *testing.T) {
+	_ = newTimeContext().formats.register("dmy", "%d-%m-%Y")
+	if _, err := newTimeContext().formats.parse("18-10-2022", "dmy", time.UTC); err == nil {
+		t.Fatalf("Expected an error while parsing with a format registered in other formats")
+	}
+}
+
+func TestRegisterTheSameFormatTwice(t *testing.T) {
+	formats := newTimeContext().formats
+	_ = formats.register("ym", "%Y-%m")
+	if err := formats.register("YM", "%Y-%m"); err != nil {
+		t.Fatalf("Expected no error while registering the same format twice, received %v", err)
+	}
+}
+
+func TestRegisterADifferentFormatWithAnExistingId(t *testing.T) {
+	if err := newTimeContext().formats.register("dt", "%d/%m/%Y"); err == nil {
+		t.Fatalf("Expected an error while registering a different format with an existing id")
+	}
+}
+
+func TestRegisterFormatWithAnInvalidId(t *testing.T) {
+	if err := newTimeContext().formats.register("1month", "%Y-%m"); err == nil {
+		t.Fatalf("Expected an error while registering a format with an invalid id")
+	}
+}
+
+func TestRegisterFormatWithAnUnsupportedDirective(t *testing.T) {
+	if err := newTimeContext().formats.register("quarter", "%Y-%Q"); err == nil {
+		t.Fatalf("Expected an error while registering a format with an unsupported directive")
+	}
+}
+
+func TestRegisterFormatWithADirectiveOnlyForFormatting(t *testing.T) {
+	formats := newTimeContext().formats
+	if err := formats.register("isoweek", "%G-W%V"); err != nil {
+		t.Fatalf("Expected no error while registering a format with a directive only for formatting, received %v", err)
+	}
+	formatted, _ := formats.format(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), "isoweek")
+	expected := "2022-W52"
+
+	if expected != formatted {
+		t.Fatalf("Expected formatted date/time to be %v, received %v", expected, formatted)
+	}
+	if _, err := formats.parse("2022-W52", "isoweek", time.UTC); err == nil {
+		t.Fatalf("Expected an error while parsing with a format that has a directive only for formatting")
+	}
+}
+
+func TestFormatDateTimeWithARegisteredFormatKeepsItsText(t *testing.T) {
+	formats := newTimeContext().formats
+	_ = formats.register("quarter", "Q1 Monday Jan PM MST-%Y")
+	formatted, _ := formats.format(time.Date(2026, 2, 3, 9, 5, 7, 0, time.UTC), "quarter")
+	expected := "Q1 Monday Jan PM MST-2026"
+
+	if expected != formatted {
+		t.Fatalf("Expected formatted date/time to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestParseDateTimeWithARegisteredFormatHavingALayoutInItsText(t *testing.T) {
+	formats := newTimeContext().formats
+	_ = formats.register("quarter", "Q1-%Y")
+	if _, err := formats.parse("Q1-2026", "quarter", time.UTC); err == nil {
+		t.Fatalf("Expected an error while parsing with a format that has a date/time layout in its text")
+	}
+}
+
+func TestParseDateTimeWithARegisteredFormatHavingText(t *testing.T) {
+	formats := newTimeContext().formats
+	_ = formats.register("week", "week of %d.%m.%Y at %Hh")
+	aTime, err := formats.parse("week of 18.10.2022 at 10h", "week", time.UTC)
+	expected := "2022-10-18 10:00:00 +0000 UTC"
+
+	if err != nil || expected != aTime.String() {
+		t.Fatalf("Expected parsing of date/time to return %v, received %v, %v", expected, aTime.String(), err)
+	}
+}
+
+func TestFormatDateTimeWithAPattern(t *testing.T) {
+	aTime := time.Date(2022, 10, 3, 9, 5, 7, 0, time.UTC)
+	formatted, _ := formatDateTime(aTime, "%Y/%m/%d %I:%M:%S %p %a %j 100%%")
+	expected := "2022/10/03 09:05:07 AM Mon 276 100%"
+
+	if expected != formatted {
+		t.Fatalf("Expected formatted date/time to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatDateTimeWithDirectivesOnlyForFormatting(t *testing.T) {
+	aTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	formatted, _ := formatDateTime(aTime, "%s %u %w %G-W%V")
+	expected := "1672531200 7 0 2022-W52"
+
+	if expected != formatted {
+		t.Fatalf("Expected formatted date/time to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatDateTimeWithAFormatId(t *testing.T) {
+	aTime := time.Date(2022, 10, 3, 9, 5, 7, 0, time.UTC)
+	formatted, _ := formatDateTime(aTime, "ts")
+	expected := "2022-10-03T09:05:07"
+
+	if expected != formatted {
+		t.Fatalf("Expected formatted date/time to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatDateTimeWithAnUnsupportedDirective(t *testing.T) {
+	_, err := formatDateTime(time.Now(), "%Y-%Q")
+
+	if err == nil {
+		t.Fatalf("Expected an error while formatting a date/time with an unsupported directive")
+	}
+}
+
+func TestFormatDateTimeWithATrailingPercent(t *testing.T) {
+	_, err := formatDateTime(time.Now(), "%Y%")
+
+	if err == nil {
+		t.Fatalf("Expected an error while formatting a date/time with a trailing %%")
+	}
+}
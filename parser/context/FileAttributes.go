@@ -18,9 +18,14 @@ type EvaluatingValue struct {
 
 type FileAttributes struct {
 	attributes map[string]EvaluatingValue
+	sources    map[string]*FileAttributes
 }
 
 func ToFileAttributes(directory string, file fs.FileInfo, ctx *ParsingApplicationContext) *FileAttributes {
+	return ToFileAttributesFromRoot(directory, directory, file, ctx)
+}
+
+func ToFileAttributesFromRoot(root string, directory string, file fs.FileInfo, ctx *ParsingApplicationContext) *FileAttributes {
 	fileAttributes := newFileAttributes()
 	hiddenFile, _ := platform.IsHiddenFile(file.Name())
 
@@ -28,12 +33,14 @@ func ToFileAttributes(directory string, file fs.FileInfo, ctx *ParsingApplicatio
 	fileAttributes.setExtension(file, hiddenFile, ctx.allAttributes)
 	fileAttributes.setSize(file, ctx.allAttributes)
 	fileAttributes.setFileType(directory, file, ctx.allAttributes)
-	fileAttributes.setTimes(file, ctx.allAttributes)
+	fileAttributes.setTimes(file, ctx.allAttributes, ctx.timeContext())
 	fileAttributes.setPath(directory, file, ctx.allAttributes)
 	fileAttributes.setPermission(file, ctx.allAttributes)
 	fileAttributes.setBlock(file, ctx.allAttributes)
 	fileAttributes.setUserGroup(file, ctx.allAttributes)
 	fileAttributes.setMimeType(directory, file, ctx.allAttributes)
+	fileAttributes.setRoot(root, ctx.allAttributes)
+	fileAttributes.setPathFromRoot(root, directory, file, ctx.allAttributes)
 
 	return fileAttributes
 }
@@ -48,9 +55,33 @@ func (fileAttributes *FileAttributes) Get(attribute string) Value {
 		fileAttributes.setAllAliasesForEvaluatedAttribute(value, evaluatingValue.aliases)
 		return value
 	}
+	if qualifier, attribute, ok := QualifiedAttribute(attribute); ok && fileAttributes.sources != nil {
+		if source, ok := fileAttributes.sources[strings.ToLower(qualifier)]; ok {
+			if source == nil {
+				return NullValue
+			}
+			return source.Get(attribute)
+		}
+	}
 	return EmptyValue
 }
 
+func (fileAttributes *FileAttributes) Qualified(alias string) *FileAttributes {
+	return &FileAttributes{
+		attributes: fileAttributes.attributes,
+		sources:    map[string]*FileAttributes{strings.ToLower(alias): fileAttributes},
+	}
+}
+
+func (fileAttributes *FileAttributes) JoinWith(alias string, other *FileAttributes) *FileAttributes {
+	sources := make(map[string]*FileAttributes, len(fileAttributes.sources)+1)
+	for qualifier, source := range fileAttributes.sources {
+		sources[qualifier] = source
+	}
+	sources[strings.ToLower(alias)] = other
+	return &FileAttributes{attributes: fileAttributes.attributes, sources: sources}
+}
+
 func newFileAttributes() *FileAttributes {
 	return &FileAttributes{attributes: make(map[string]EvaluatingValue)}
 }
@@ -84,11 +115,12 @@ func (fileAttributes *FileAttributes) setFileType(directory string, file fs.File
 	fileAttributes.setAllAliasesForEvaluatedAttribute(booleanValueUsing(hiddenFile), attributes.aliasesFor(AttributeNameIsHidden))
 }
 
-func (fileAttributes *FileAttributes) setTimes(file fs.FileInfo, attributes *AllAttributes) {
+func (fileAttributes *FileAttributes) setTimes(file fs.FileInfo, attributes *AllAttributes, timeContext *TimeContext) {
 	created, modified, accessed := platform.FileTimes(file)
-	fileAttributes.setAllAliasesForEvaluatedAttribute(DateTimeValue(created), attributes.aliasesFor(AttributeCreatedTime))
-	fileAttributes.setAllAliasesForEvaluatedAttribute(DateTimeValue(modified), attributes.aliasesFor(AttributeModifiedTime))
-	fileAttributes.setAllAliasesForEvaluatedAttribute(DateTimeValue(accessed), attributes.aliasesFor(AttributeAccessedTime))
+	fileAttributes.setAllAliasesForEvaluatedAttribute(DateTimeValue(created.In(timeContext.location)), attributes.aliasesFor(AttributeCreatedTime))
+	fileAttributes.setAllAliasesForEvaluatedAttribute(DateTimeValue(modified.In(timeContext.location)), attributes.aliasesFor(AttributeModifiedTime))
+	fileAttributes.setAllAliasesForEvaluatedAttribute(DateTimeValue(accessed.In(timeContext.location)), attributes.aliasesFor(AttributeAccessedTime))
+	fileAttributes.setAllAliasesForEvaluatedAttribute(DurationValue(timeContext.now().Sub(modified)), attributes.aliasesFor(AttributeAge))
 }
 
 func (fileAttributes *FileAttributes) setPath(directory string, file fs.FileInfo, attributes *AllAttributes) {
@@ -100,6 +132,19 @@ func (fileAttributes *FileAttributes) setPath(directory string, file fs.FileInfo
 	fileAttributes.setAllAliasesForEvaluatedAttribute(StringValue(newPath), attributes.aliasesFor(AttributePath))
 }
 
+func (fileAttributes *FileAttributes) setRoot(root string, attributes *AllAttributes) {
+	fileAttributes.setAllAliasesForEvaluatedAttribute(StringValue(root), attributes.aliasesFor(AttributeRoot))
+}
+
+func (fileAttributes *FileAttributes) setPathFromRoot(root string, directory string, file fs.FileInfo, attributes *AllAttributes) {
+	pathFromRoot, err := filepath.Rel(root, fileAttributes.filePath(directory, file))
+	if err == nil {
+		fileAttributes.setAllAliasesForEvaluatedAttribute(StringValue(pathFromRoot), attributes.aliasesFor(AttributePathFromRoot))
+		depth := strings.Count(filepath.ToSlash(pathFromRoot), "/") + 1
+		fileAttributes.setAllAliasesForEvaluatedAttribute(Int64Value(int64(depth)), attributes.aliasesFor(AttributeDepth))
+	}
+}
+
 func (fileAttributes *FileAttributes) setExtension(file fs.FileInfo, hiddenFile bool, attributes *AllAttributes) {
 	if hiddenFile {
 		fileAttributes.setAllAliasesForEvaluatedAttribute(StringValue(""), attributes.aliasesFor(AttributeExtension))
@@ -26,6 +26,98 @@ func TestUnsupportedAttribute(t *testing.T) {
 	}
 }
 
+func TestQualifiedAttribute(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(nil, NewAttributes())
+	fileAttributes := ToFileAttributes("../test/resources/TestResultsWithProjections/single/", file, context).Qualified("a")
+	value := fileAttributes.Get("a.name").GetAsString()
+
+	if value != "TestResultsWithProjections_A.txt" {
+		t.Fatalf("Expected value for a.name to be %v, received %v", "TestResultsWithProjections_A.txt", value)
+	}
+}
+
+func TestQualifiedAttributeOfAJoinedSource(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	otherFile, err := os.Stat("../test/resources/TestResultsWithProjections/multi/TestResultsWithProjections_A.log")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(nil, NewAttributes())
+	fileAttributes := ToFileAttributes("../test/resources/TestResultsWithProjections/single/", file, context).Qualified("a")
+	joined := fileAttributes.JoinWith("b", ToFileAttributes("../test/resources/TestResultsWithProjections/multi/", otherFile, context))
+
+	if value := joined.Get("b.ext").GetAsString(); value != ".log" {
+		t.Fatalf("Expected value for b.ext to be %v, received %v", ".log", value)
+	}
+	if value := joined.Get("ext").GetAsString(); value != ".txt" {
+		t.Fatalf("Expected value for ext to be %v, received %v", ".txt", value)
+	}
+}
+
+func TestQualifiedAttributeOfAMissingJoinedSource(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(nil, NewAttributes())
+	joined := ToFileAttributes("../test/resources/TestResultsWithProjections/single/", file, context).Qualified("a").JoinWith("b", nil)
+	value := joined.Get("b.name")
+
+	if !value.IsNull() {
+		t.Fatalf("Expected value for b.name to be null, received %v", value)
+	}
+}
+
+func TestPathFromRoot(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(nil, NewAttributes())
+	fileAttributes := ToFileAttributesFromRoot("../test/resources", "../test/resources/TestResultsWithProjections/single/", file, context)
+	value := fileAttributes.Get(AttributePathFromRoot).GetAsString()
+	expected := "TestResultsWithProjections/single/TestResultsWithProjections_A.txt"
+
+	if value != expected {
+		t.Fatalf("Expected value for relpath to be %v, received %v", expected, value)
+	}
+}
+
+func TestDepthFromRoot(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(nil, NewAttributes())
+	fileAttributes := ToFileAttributesFromRoot("../test/resources", "../test/resources/TestResultsWithProjections/single/", file, context)
+	value, _ := fileAttributes.Get(AttributeDepth).GetNumericAsFloat64()
+
+	if value != 3 {
+		t.Fatalf("Expected value for depth to be %v, received %v", 3, value)
+	}
+}
+
+func TestDepthOfAFileInsideTheRoot(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(nil, NewAttributes())
+	fileAttributes := ToFileAttributes("../test/resources/TestResultsWithProjections/single/", file, context)
+	value, _ := fileAttributes.Get(AttributeDepth).GetNumericAsFloat64()
+
+	if value != 1 {
+		t.Fatalf("Expected value for depth to be %v, received %v", 1, value)
+	}
+}
+
 func TestFileName(t *testing.T) {
 	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
 	if err != nil {
@@ -357,7 +449,7 @@ func TestAccessTime(t *testing.T) {
 	fileAttributes := ToFileAttributes(fmt.Sprintf("%v", directoryName), file, context)
 
 	accessTimeValue := fileAttributes.Get(AttributeAccessedTime)
-	expected := formatDate(time.Now()).GetAsString()
+	expected := formatDate(context.timeContext().now()).GetAsString()
 	actual := formatDate(accessTimeValue.timeValue).GetAsString()
 
 	if expected != actual {
@@ -379,7 +471,7 @@ func TestModifiedTime(t *testing.T) {
 	fileAttributes := ToFileAttributes(fmt.Sprintf("%v", directoryName), file, context)
 
 	modifiedTime := fileAttributes.Get(AttributeModifiedTime)
-	expected := formatDate(time.Now()).GetAsString()
+	expected := formatDate(context.timeContext().now()).GetAsString()
 	actual := formatDate(modifiedTime.timeValue).GetAsString()
 
 	if expected != actual {
@@ -401,7 +493,7 @@ func TestCreatedTime(t *testing.T) {
 	fileAttributes := ToFileAttributes(fmt.Sprintf("%v", directoryName), file, context)
 
 	createdTime := fileAttributes.Get(AttributeCreatedTime)
-	expected := formatDate(time.Now()).GetAsString()
+	expected := formatDate(context.timeContext().now()).GetAsString()
 	actual := formatDate(createdTime.timeValue).GetAsString()
 
 	if expected != actual {
@@ -438,3 +530,84 @@ func TestMimeType2(t *testing.T) {
 		t.Fatalf("Expected mime type to be %v, received %v", expected, mimeType)
 	}
 }
+
+func TestFileRoot(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(nil, NewAttributes())
+	fileAttributes := ToFileAttributesFromRoot("../test/resources/", "../test/resources/TestResultsWithProjections/single/", file, context)
+	root := fileAttributes.Get(AttributeRoot).GetAsString()
+
+	if root != "../test/resources/" {
+		t.Fatalf("Expected root to be %v, received %v", "../test/resources/", root)
+	}
+}
+
+func TestModifiedTimeInTheTimeZone(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(NewFunctions(), NewAttributes())
+	_ = context.SetTimeZone("Asia/Tokyo")
+	fileAttributes := ToFileAttributes("../test/resources/TestResultsWithProjections/single/", file, context)
+	modifiedTime, _ := fileAttributes.Get("mtime").GetDateTime()
+
+	if modifiedTime.Location().String() != "Asia/Tokyo" {
+		t.Fatalf("Expected the modified time to be in %v, received %v", "Asia/Tokyo", modifiedTime.Location())
+	}
+}
+
+func TestAge(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(nil, NewAttributes())
+	fileAttributes := ToFileAttributes("../test/resources/TestResultsWithProjections/single/", file, context)
+	age, err := fileAttributes.Get("age").GetDuration()
+
+	if err != nil || age != context.timeContext().now().Sub(file.ModTime()) {
+		t.Fatalf("Expected age to be the duration since the modification, received %v", age)
+	}
+}
+
+func TestAgeOfAllTheFilesIsAsOfTheSameInstant(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	functions := NewFunctions()
+	ticks := time.Date(2031, 8, 22, 23, 30, 0, 0, time.UTC)
+	functions.timeContext.clock = func() time.Time {
+		ticks = ticks.Add(time.Minute)
+		return ticks
+	}
+	context := NewContext(functions, NewAttributes())
+	firstAge, _ := ToFileAttributes("../test/resources/TestResultsWithProjections/single/", file, context).Get("age").GetDuration()
+	secondAge, _ := ToFileAttributes("../test/resources/TestResultsWithProjections/single/", file, context).Get("age").GetDuration()
+
+	if firstAge != secondAge {
+		t.Fatalf("Expected the age to be as of the same instant, received %v and %v", firstAge, secondAge)
+	}
+}
+
+func TestAgeIsTheDurationSinceTheModificationAsOfNow(t *testing.T) {
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/single/TestResultsWithProjections_A.txt")
+	if err != nil {
+		panic(err)
+	}
+	context := NewContext(functionsAt(time.Date(2031, 8, 22, 23, 30, 0, 0, time.UTC)), NewAttributes())
+	_ = context.SetTimeZone("Asia/Tokyo")
+	fileAttributes := ToFileAttributes("../test/resources/TestResultsWithProjections/single/", file, context)
+	age, _ := fileAttributes.Get("age").GetDuration()
+	modifiedTime, _ := fileAttributes.Get("mtime").GetDateTime()
+	now, _ := context.AllFunctions().Execute("now")
+	nowTime, _ := now.GetDateTime()
+
+	if age != nowTime.Sub(modifiedTime) {
+		t.Fatalf("Expected age to be %v, received %v", nowTime.Sub(modifiedTime), age)
+	}
+}
@@ -1,25 +1,33 @@
 package context
 
 import (
+	"errors"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"strings"
-	"time"
 )
 
 type FunctionDefinition struct {
 	aliases        []string
 	tags           map[string]bool
 	block          FunctionBlock
+	timeBlock      TimeFunctionBlock
 	description    string
 	aggregateBlock AggregationFunctionBlock
 	isAggregate    bool
+	isWindow       bool
+	acceptsNull    bool
+	maxParameters  int
 }
 
 type FunctionBlock interface {
 	run(args ...Value) (Value, error)
 }
 
+type TimeFunctionBlock interface {
+	run(timeContext *TimeContext, args ...Value) (Value, error)
+}
+
 type AggregationFunctionBlock interface {
 	initialState() *FunctionState
 	run(initialState *FunctionState, args ...Value) (*FunctionState, error)
@@ -28,11 +36,18 @@ type AggregationFunctionBlock interface {
 
 type AllFunctions struct {
 	supportedFunctions map[string]*FunctionDefinition
+	timeContext        *TimeContext
+	lenient            bool
+}
+
+type conversionError struct {
+	err error
 }
 
 type FunctionState struct {
 	Initial   Value
 	isUpdated bool
+	hasNulls  bool
 	extras    map[interface{}]Value
 }
 
@@ -52,6 +67,10 @@ const (
 	FunctionNameAnd                 = "and"
 	FunctionNameNot                 = "not"
 	FunctionNameLike                = "like"
+	FunctionNameRegexMatch          = "regexmatch"
+	FunctionNameRegexExtract        = "regexextract"
+	FunctionNameRegexReplace        = "regexreplace"
+	FunctionNameIn                  = "in"
 	FunctionNameLower               = "lower"
 	FunctionNameUpper               = "upper"
 	FunctionNameTitle               = "title"
@@ -61,6 +80,11 @@ const (
 	FunctionNameLeftTrim            = "ltrim"
 	FunctionNameRightTrim           = "rtrim"
 	FunctionNameIfBlank             = "ifblank"
+	FunctionNameIf                  = "if"
+	FunctionNameIsNull              = "isnull"
+	FunctionNameIsNotNull           = "isnotnull"
+	FunctionNameCoalesce            = "coalesce"
+	FunctionNameNullIf              = "nullif"
 	FunctionNameStartsWith          = "startswith"
 	FunctionNameEndsWith            = "endswith"
 	FunctionNameNow                 = "now"
@@ -73,6 +97,13 @@ const (
 	FunctionNameHoursDifference     = "hoursdifference"
 	FunctionNameDaysDifference      = "daysdifference"
 	FunctionNameDateTimeParse       = "parsedatetime"
+	FunctionNameDateAdd             = "dateadd"
+	FunctionNameDateTrunc           = "datetrunc"
+	FunctionNameFormatDateTime      = "formatdatetime"
+	FunctionNameUnixTime            = "unixtime"
+	FunctionNameFromUnixTime        = "fromunixtime"
+	FunctionNameFormatDuration      = "formatduration"
+	FunctionNameAtTimeZone          = "attimezone"
 	FunctionNameWorkingDirectory    = "cwd"
 	FunctionNameConcat              = "concat"
 	FunctionNameConcatWithSeparator = "concatws"
@@ -88,280 +119,450 @@ const (
 	FunctionNameIsFileTypeArchive   = "isarchive"
 	FunctionNameFormatSize          = "formatsize"
 	FunctionNameParseSize           = "parsesize"
+	FunctionNameDirName             = "dirname"
+	FunctionNameCast                = "cast"
+	FunctionNameToInt               = "toint"
+	FunctionNameToFloat             = "tofloat"
+	FunctionNameToString            = "tostring"
+	FunctionNameToDateTime          = "todatetime"
 	FunctionNameCount               = "count"
 	FunctionNameCountDistinct       = "countdistinct"
 	FunctionNameSum                 = "sum"
 	FunctionNameAverage             = "average"
 	FunctionNameMin                 = "min"
 	FunctionNameMax                 = "max"
+	FunctionNameRowNumber           = "rownumber"
+	FunctionNameRank                = "rank"
+	FunctionNameDenseRank           = "denserank"
 )
 
+const unlimitedParameters = -1
+
 var executionCache = NewFunctionExecutionCache()
 
 var functionDefinitions = map[string]*FunctionDefinition{
 	FunctionNameIdentity: {
-		aliases:     []string{"identity", "iden"},
-		description: "Returns the provided parameter value as it is, if the parameter value is not an attribute. \nFor example, identity(demo) will return the string demo, identity(name) will return the file name.",
-		block:       IdentityFunctionBlock{},
+		aliases:       []string{"identity", "iden"},
+		description:   "Returns the provided parameter value as it is, if the parameter value is not an attribute. \nFor example, identity(demo) will return the string demo, identity(name) will return the file name.",
+		maxParameters: 1,
+		block:         IdentityFunctionBlock{},
 	},
 	FunctionNameAdd: {
-		aliases:     []string{"add", "addition"},
-		description: "Takes variable number of numeric type parameter values and returns the addition of all the values. \nFor example, add(1, 2) will return 3.00.",
-		block:       AddFunctionBlock{},
+		aliases:       []string{"add", "addition"},
+		description:   "Takes variable number of numeric type parameter values and returns the addition of all the values. \nFor example, add(1, 2) will return 3.00.",
+		maxParameters: unlimitedParameters,
+		block:         AddFunctionBlock{},
 	},
 	FunctionNameSubtract: {
-		aliases:     []string{"sub", "subtract"},
-		description: "Takes 2 numeric type parameter values A and B and returns the result of A-B. \nFor example, sub(4, 5) will return -1.00.",
-		block:       SubtractFunctionBlock{},
+		aliases:       []string{"sub", "subtract"},
+		description:   "Takes 2 numeric type parameter values A and B and returns the result of A-B. \nFor example, sub(4, 5) will return -1.00.",
+		maxParameters: 2,
+		block:         SubtractFunctionBlock{},
 	},
 	FunctionNameMultiply: {
-		aliases:     []string{"mul", "multiply"},
-		description: "Takes variable number of numeric type parameter values and returns the product of all the values. \nFor example, mul(3, 2) will return 6.00.",
-		block:       MultiplyFunctionBlock{},
+		aliases:       []string{"mul", "multiply"},
+		description:   "Takes variable number of numeric type parameter values and returns the product of all the values. \nFor example, mul(3, 2) will return 6.00.",
+		maxParameters: unlimitedParameters,
+		block:         MultiplyFunctionBlock{},
 	},
 	FunctionNameDivide: {
-		aliases:     []string{"div", "divide"},
-		description: "Takes 2 numeric type parameter values A and B and returns the result of A/B. \nFor example, div(4, 5) will return 0.80.",
-		block:       DivideFunctionBlock{},
+		aliases:       []string{"div", "divide"},
+		description:   "Takes 2 numeric type parameter values A and B and returns the result of A/B. \nFor example, div(4, 5) will return 0.80.",
+		maxParameters: 2,
+		block:         DivideFunctionBlock{},
 	},
 	FunctionNameEqual: {
-		aliases:     []string{"equal", "eq", "equals"},
-		description: "Takes 2 parameter values A and B and returns true if A is equal to B, false otherwise.",
-		block:       EqualFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"equal", "eq", "equals"},
+		description:   "Takes 2 parameter values A and B and returns true if A is equal to B, false otherwise.",
+		maxParameters: 2,
+		block:         EqualFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameNotEqual: {
-		aliases:     []string{"notequal", "ne", "notequals"},
-		description: "Takes 2 parameter values A and B and returns true if A is not equal to B, false otherwise.",
-		block:       NotEqualFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"notequal", "ne", "notequals"},
+		description:   "Takes 2 parameter values A and B and returns true if A is not equal to B, false otherwise.",
+		maxParameters: 2,
+		block:         NotEqualFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameLessThan: {
-		aliases:     []string{"lt", "lessthan", "less"},
-		description: "Takes 2 parameter values A and B and returns true if A is less than B, false otherwise.",
-		block:       LessThanFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"lt", "lessthan", "less"},
+		description:   "Takes 2 parameter values A and B and returns true if A is less than B, false otherwise.",
+		maxParameters: 2,
+		block:         LessThanFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameGreaterThan: {
-		aliases:     []string{"gt", "greater", "greaterthan"},
-		description: "Takes 2 parameter values A and B and returns true if A is greater than B, false otherwise.",
-		block:       GreaterThanFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"gt", "greater", "greaterthan"},
+		description:   "Takes 2 parameter values A and B and returns true if A is greater than B, false otherwise.",
+		maxParameters: 2,
+		block:         GreaterThanFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameLessThanEqual: {
-		aliases:     []string{"lte", "lessthanequal", "lessequal", "le"},
-		description: "Takes 2 parameter values A and B and returns true if A is less than or equal to B, false otherwise.",
-		block:       LessThanEqualFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"lte", "lessthanequal", "lessequal", "le"},
+		description:   "Takes 2 parameter values A and B and returns true if A is less than or equal to B, false otherwise.",
+		maxParameters: 2,
+		block:         LessThanEqualFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameGreaterThanEqual: {
-		aliases:     []string{"gte", "greaterthanequal", "greaterequal", "ge"},
-		description: "Takes 2 parameter values A and B and returns true if A is greater than or equal to B, false otherwise.",
-		block:       GreaterThanEqualFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"gte", "greaterthanequal", "greaterequal", "ge"},
+		description:   "Takes 2 parameter values A and B and returns true if A is greater than or equal to B, false otherwise.",
+		maxParameters: 2,
+		block:         GreaterThanEqualFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameOr: {
-		aliases:     []string{"or"},
-		description: "Takes variable number of boolean parameter values and returns true if any of them evaluates to true, false otherwise. \nFor example, or(eq(add(1, 2), 3), false) will return true.",
-		block:       OrFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"or"},
+		description:   "Takes variable number of boolean parameter values and returns true if any of them evaluates to true, false otherwise. \nFor example, or(eq(add(1, 2), 3), false) will return true.",
+		maxParameters: unlimitedParameters,
+		block:         OrFunctionBlock{},
+		acceptsNull:   true,
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameAnd: {
-		aliases:     []string{"and"},
-		description: "Takes variable number of boolean parameter values and returns true if all of them evaluate to true, false otherwise. \nFor example, or(eq(add(1, 2), 3), false) will return false.",
-		block:       AndFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"and"},
+		description:   "Takes variable number of boolean parameter values and returns true if all of them evaluate to true, false otherwise. \nFor example, or(eq(add(1, 2), 3), false) will return false.",
+		maxParameters: unlimitedParameters,
+		block:         AndFunctionBlock{},
+		acceptsNull:   true,
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameNot: {
-		aliases:     []string{"not"},
-		description: "Takes a single boolean parameter value and returns its negation.",
-		block:       NotFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"not"},
+		description:   "Takes a single boolean parameter value and returns its negation.",
+		maxParameters: 1,
+		block:         NotFunctionBlock{},
+		acceptsNull:   true,
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameLike: {
-		aliases:     []string{"like"},
-		description: "Takes 2 parameter values and returns true if the first parameter value matches the regular expression represented by the second parameter value, false otherwise.",
-		block:       LikeFunctionBlock{executionCache: executionCache},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"like"},
+		description:   "Takes 2 parameter values and returns true if the first parameter value matches the regular expression represented by the second parameter value, false otherwise.",
+		maxParameters: 2,
+		block:         LikeFunctionBlock{executionCache: executionCache},
+		tags:          map[string]bool{"where": true},
+	},
+	FunctionNameRegexMatch: {
+		aliases:       []string{"regexmatch", "rmatch"},
+		description:   "Takes 2 parameter values and returns true if the first parameter value matches the regular expression represented by the second parameter value, false otherwise. \nFor example, regexmatch(name, '^v[0-9]+') returns true for the file names that start with a version.",
+		maxParameters: 2,
+		block:         RegexMatchFunctionBlock{executionCache: executionCache},
+		tags:          map[string]bool{"where": true},
+	},
+	FunctionNameRegexExtract: {
+		aliases:       []string{"regexextract", "rextract"},
+		description:   "Takes a parameter value, a regular expression and an optional group, and returns the first match of the regular expression or of its group, blank if there is no match. \nThe group is either an index starting with 1 or the name of a named group, the whole match is returned without a group. \nFor example, regexextract(name, 'app-([0-9.]+)', 1) returns 1.2.3 for app-1.2.3.tar.gz, regexextract(name, '(?P<year>[0-9]{4})-[0-9]{2}', year) returns 2022 for report-2022-10.pdf.",
+		maxParameters: 3,
+		block:         RegexExtractFunctionBlock{executionCache: executionCache},
+	},
+	FunctionNameRegexReplace: {
+		aliases:       []string{"regexreplace", "rreplace"},
+		description:   "Takes a parameter value, a regular expression and a replacement, and replaces all the matches of the regular expression with the replacement. \nThe replacement can refer to the groups with $1 or ${name}. \nFor example, regexreplace(name, '([a-z]+)-([0-9]+)', '$2-$1') returns 42-app for app-42.",
+		maxParameters: 3,
+		block:         RegexReplaceFunctionBlock{executionCache: executionCache},
+	},
+	FunctionNameIn: {
+		aliases:       []string{"in"},
+		description:   "Takes a parameter value A followed by one or more values and returns true if A is equal to any of them, null if A or any of them is null, false otherwise. \nFor example, in(ext, .go, .mod, .sum) returns true for the files with the extension .go, .mod or .sum. \nIt can also be written as ext in (.go, .mod, .sum) or as basename in (select basename from ../other).",
+		maxParameters: unlimitedParameters,
+		acceptsNull:   true,
+		block:         InFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameLower: {
-		aliases:     []string{"lower", "low"},
-		description: "Takes a single parameter value and returns the value in lower case.",
-		block:       LowerFunctionBlock{},
+		aliases:       []string{"lower", "low"},
+		description:   "Takes a single parameter value and returns the value in lower case.",
+		maxParameters: 1,
+		block:         LowerFunctionBlock{},
 	},
 	FunctionNameUpper: {
-		aliases:     []string{"upper", "up"},
-		description: "Takes a single parameter value and returns the value in upper case.",
-		block:       UpperFunctionBlock{},
+		aliases:       []string{"upper", "up"},
+		description:   "Takes a single parameter value and returns the value in upper case.",
+		maxParameters: 1,
+		block:         UpperFunctionBlock{},
 	},
 	FunctionNameTitle: {
-		aliases:     []string{"title"},
-		description: "Takes a single parameter value and returns the value in title case.",
+		aliases:       []string{"title"},
+		description:   "Takes a single parameter value and returns the value in title case.",
+		maxParameters: 1,
 		block: TitleFunctionBlock{
 			caser: cases.Title(language.English),
 		},
 	},
 	FunctionNameBase64: {
-		aliases:     []string{"base64", "b64"},
-		description: "Takes a single parameter value and returns the base64 encoding of the value.",
-		block:       Base64FunctionBlock{},
+		aliases:       []string{"base64", "b64"},
+		description:   "Takes a single parameter value and returns the base64 encoding of the value.",
+		maxParameters: 1,
+		block:         Base64FunctionBlock{},
 	},
 	FunctionNameLength: {
-		aliases:     []string{"length", "len"},
-		description: "Takes a single parameter value and returns its length.",
-		block:       LengthFunctionBlock{},
+		aliases:       []string{"length", "len"},
+		description:   "Takes a single parameter value and returns its length.",
+		maxParameters: 1,
+		block:         LengthFunctionBlock{},
 	},
 	FunctionNameTrim: {
-		aliases:     []string{"trim"},
-		description: "Takes a single parameter value and returns its value after removing leading and trailing space character(s).",
-		block:       TrimFunctionBlock{},
+		aliases:       []string{"trim"},
+		description:   "Takes a single parameter value and returns its value after removing leading and trailing space character(s).",
+		maxParameters: 1,
+		block:         TrimFunctionBlock{},
 	},
 	FunctionNameLeftTrim: {
-		aliases:     []string{"ltrim", "lefttrim"},
-		description: "Takes a single parameter value and returns its value after removing leading space character(s).",
-		block:       LeftTrimFunctionBlock{},
+		aliases:       []string{"ltrim", "lefttrim"},
+		description:   "Takes a single parameter value and returns its value after removing leading space character(s).",
+		maxParameters: 1,
+		block:         LeftTrimFunctionBlock{},
 	},
 	FunctionNameRightTrim: {
-		aliases:     []string{"rtrim", "righttrim"},
-		description: "Takes a single parameter value and returns its value after removing trailing space character(s).",
-		block:       RightTrimFunctionBlock{},
+		aliases:       []string{"rtrim", "righttrim"},
+		description:   "Takes a single parameter value and returns its value after removing trailing space character(s).",
+		maxParameters: 1,
+		block:         RightTrimFunctionBlock{},
 	},
 	FunctionNameIfBlank: {
-		aliases:     []string{"ifblank"},
-		description: "Takes two parameter values and returns the first one if it is not empty \nand doesn't consist solely of whitespace characters, \nelse returns the second parameter value.",
-		block:       IfBlankFunctionBlock{},
+		aliases:       []string{"ifblank"},
+		description:   "Takes two parameter values and returns the first one if it is not null, not empty \nand doesn't consist solely of whitespace characters, \nelse returns the second parameter value.",
+		maxParameters: 2,
+		block:         IfBlankFunctionBlock{},
+		acceptsNull:   true,
+	},
+	FunctionNameIf: {
+		aliases:       []string{"if", "iif"},
+		description:   "Takes three parameter values, a boolean condition C and two values A and B, and returns A if C is true, else returns B. \nFor example, if(gt(size, 1mb), large, small) will return large for the files bigger than 1mb. \nIt can also be written as case when size > 1mb then large else small end, multiple 'when' conditions are evaluated in order.",
+		maxParameters: 3,
+		block:         IfFunctionBlock{},
+		acceptsNull:   true,
+	},
+	FunctionNameIsNull: {
+		aliases:       []string{"isnull"},
+		description:   "Takes a single parameter value and returns true if it is null, false otherwise. \nFor example, isnull(b.name) returns true for the files of a left join that have no matching file on the right side.",
+		maxParameters: 1,
+		block:         IsNullFunctionBlock{},
+		tags:          map[string]bool{"where": true},
+		acceptsNull:   true,
+	},
+	FunctionNameIsNotNull: {
+		aliases:       []string{"isnotnull"},
+		description:   "Takes a single parameter value and returns true if it is not null, false otherwise.",
+		maxParameters: 1,
+		block:         IsNotNullFunctionBlock{},
+		tags:          map[string]bool{"where": true},
+		acceptsNull:   true,
+	},
+	FunctionNameCoalesce: {
+		aliases:       []string{"coalesce"},
+		description:   "Takes variable number of parameter values and returns the first one that is not null, null if all of them are null. \nFor example, coalesce(b.size, 0) returns 0 for the files of a left join that have no matching file on the right side.",
+		maxParameters: unlimitedParameters,
+		block:         CoalesceFunctionBlock{},
+		acceptsNull:   true,
+	},
+	FunctionNameNullIf: {
+		aliases:       []string{"nullif"},
+		description:   "Takes 2 parameter values A and B and returns null if A is equal to B, A otherwise. \nFor example, nullif(ext, .log) returns null for the log files.",
+		maxParameters: 2,
+		block:         NullIfFunctionBlock{},
+		acceptsNull:   true,
 	},
 	FunctionNameStartsWith: {
-		aliases:     []string{"startswith"},
-		description: "Takes two parameter values and returns true if the first parameter value starts with the second one.",
-		block:       StartsWithFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"startswith"},
+		description:   "Takes two parameter values and returns true if the first parameter value starts with the second one.",
+		maxParameters: 2,
+		block:         StartsWithFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameEndsWith: {
-		aliases:     []string{"endswith"},
-		description: "Takes two parameter values and returns true if the first parameter value ends with the second one.",
-		block:       EndsWithFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"endswith"},
+		description:   "Takes two parameter values and returns true if the first parameter value ends with the second one.",
+		maxParameters: 2,
+		block:         EndsWithFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameNow: {
-		aliases:     []string{"now"},
-		description: "Returns the current date/time.",
-		block:       NowFunctionBlock{},
+		aliases:       []string{"now"},
+		description:   "Returns the current date/time.",
+		maxParameters: 0,
+		timeBlock:     NowFunctionBlock{},
 	},
 	FunctionNameCurrentDay: {
-		aliases:     []string{"cday", "currentday"},
-		description: "Returns the current day. If today is 9th September 2022, cday() will return 9.",
-		block:       CurrentDayFunctionBlock{},
+		aliases:       []string{"cday", "currentday"},
+		description:   "Returns the current day. If today is 9th September 2022, cday() will return 9.",
+		maxParameters: 0,
+		timeBlock:     CurrentDayFunctionBlock{},
 	},
 	FunctionNameCurrentDate: {
-		aliases:     []string{"cdate", "currentdate"},
-		description: "Returns the current date formatted as year-month-day. \nIf today is 9th September 2022, cdate() will return 2022-September-09.",
-		block:       CurrentDateFunctionBlock{},
+		aliases:       []string{"cdate", "currentdate"},
+		description:   "Returns the current date formatted as year-month-day. \nIf today is 9th September 2022, cdate() will return 2022-September-09.",
+		maxParameters: 0,
+		timeBlock:     CurrentDateFunctionBlock{},
 	},
 	FunctionNameCurrentMonth: {
-		aliases:     []string{"cmonth", "cmon", "currentmonth", "currentmon"},
-		description: "Returns the current month. \nIf today is 9th September 2022, cmonth() will return September.",
-		block:       CurrentMonthFunctionBlock{},
+		aliases:       []string{"cmonth", "cmon", "currentmonth", "currentmon"},
+		description:   "Returns the current month. \nIf today is 9th September 2022, cmonth() will return September.",
+		maxParameters: 0,
+		timeBlock:     CurrentMonthFunctionBlock{},
 	},
 	FunctionNameCurrentYear: {
-		aliases:     []string{"cyear", "cyr", "currentyear", "currentyr"},
-		description: "Returns the current year. \nIf today is 9th September 2022, cyr() will return 2022.",
-		block:       CurrentYearFunctionBlock{},
+		aliases:       []string{"cyear", "cyr", "currentyear", "currentyr"},
+		description:   "Returns the current year. \nIf today is 9th September 2022, cyr() will return 2022.",
+		maxParameters: 0,
+		timeBlock:     CurrentYearFunctionBlock{},
 	},
 	FunctionNameDayOfWeek: {
-		aliases:     []string{"dayofweek", "dow"},
-		description: "Returns the day of the week. \nIf today is a Friday, dow() will return Friday.",
-		block:       DayOfWeekFunctionBlock{},
+		aliases:       []string{"dayofweek", "dow"},
+		description:   "Returns the day of the week. \nIf today is a Friday, dow() will return Friday.",
+		maxParameters: 0,
+		timeBlock:     DayOfWeekFunctionBlock{},
 	},
 	FunctionNameExtract: {
-		aliases:     []string{"extract"},
-		description: "Returns the extracted component from date/time. extract allows the extraction of date, day, year, month and weekday from date/time. \nFor example, extract(atime, month) will extract 'month' from the access time of a file.",
-		block:       ExtractFunctionBlock{},
+		aliases:       []string{"extract"},
+		description:   "Returns the extracted component from date/time. extract allows the extraction of date, day, year, month and weekday from date/time. \nFor example, extract(atime, month) will extract 'month' from the access time of a file.",
+		maxParameters: 2,
+		block:         ExtractFunctionBlock{},
 	},
 	FunctionNameHoursDifference: {
-		aliases:     []string{"hoursdifference", "hourdifference", "hoursdiff", "hourdiff"},
-		description: "Returns the difference between 2 date/times in hours.",
-		block:       HoursDifferenceFunctionBlock{},
+		aliases:       []string{"hoursdifference", "hourdifference", "hoursdiff", "hourdiff"},
+		description:   "Returns the difference between 2 date/times in hours.",
+		maxParameters: 2,
+		timeBlock:     HoursDifferenceFunctionBlock{},
 	},
 	FunctionNameDaysDifference: {
-		aliases:     []string{"daysdifference", "daydifference", "daysdiff", "daydiff"},
-		description: "Returns the difference between 2 date/times in days.",
-		block:       DaysDifferenceFunctionBlock{},
+		aliases:       []string{"daysdifference", "daydifference", "daysdiff", "daydiff"},
+		description:   "Returns the difference between 2 date/times in days.",
+		maxParameters: 2,
+		timeBlock:     DaysDifferenceFunctionBlock{},
 	},
 	FunctionNameDateTimeParse: {
-		aliases:     []string{"parsedatetime", "parsedttime", "parsedttm", "parsedatetm"},
-		description: "Returns the time representation after parsing the input string. \nIt takes 2 parameters, the first parameter is a string to be parsed and the second is the format identifier. Example, parsedatetime(2022-09-09, dt) \nreturns the date/time represented by the given input.",
-		block:       ParseDateTimeFunctionBlock{},
+		aliases:       []string{"parsedatetime", "parsedttime", "parsedttm", "parsedatetm"},
+		description:   "Returns the time representation after parsing the input string. \nIt takes 2 parameters, the first parameter is a string to be parsed and the second is the format identifier. Example, parsedatetime(2022-09-09, dt) \nreturns the date/time represented by the given input.",
+		maxParameters: 2,
+		timeBlock:     ParseDateTimeFunctionBlock{},
+	},
+	FunctionNameDateAdd: {
+		aliases:       []string{"dateadd"},
+		description:   "Takes a date/time, an integer amount and a unit, and returns the date/time after adding the amount of the unit. \nThe unit is one of second, minute, hour, day, week, month or year, along with their plurals. Adding months or years keeps the day within the month. \nFor example, dateadd(mtime, -7, day) returns the modification time a week earlier.",
+		maxParameters: 3,
+		block:         DateAddFunctionBlock{},
+	},
+	FunctionNameDateTrunc: {
+		aliases:       []string{"datetrunc"},
+		description:   "Takes a date/time and a unit, and returns the date/time truncated to the start of the unit, weeks start on Monday. \nThe unit is one of second, minute, hour, day, week, month or year. \nFor example, datetrunc(mtime, month) returns the first day of the month of the modification time.",
+		maxParameters: 2,
+		block:         DateTruncFunctionBlock{},
+	},
+	FunctionNameFormatDateTime: {
+		aliases:       []string{"formatdatetime", "fmtdatetime", "fmtdt"},
+		description:   "Takes a date/time and a strftime-style pattern or a date/time format id, and returns the formatted date/time. \nThe pattern supports %Y %y %m %d %e %H %I %M %S %p %b %B %a %A %j %Z %z %F %T %s %u %w %V %G and %%. \nFor example, formatdatetime(mtime, %Y-%m) returns 2022-10 for a file modified in October 2022, formatdatetime(mtime, dt) returns 2022-10-18.",
+		maxParameters: 2,
+		timeBlock:     FormatDateTimeFunctionBlock{},
+	},
+	FunctionNameUnixTime: {
+		aliases:       []string{"unixtime"},
+		description:   "Takes a date/time and returns the number of seconds since the unix epoch. \nFor example, unixtime(mtime) returns 1666051200 for a file modified at 2022-10-18T00:00:00 UTC.",
+		maxParameters: 1,
+		block:         UnixTimeFunctionBlock{},
+	},
+	FunctionNameFromUnixTime: {
+		aliases:       []string{"fromunixtime"},
+		description:   "Takes the number of seconds since the unix epoch and returns the date/time in the time zone of the query, UTC by default. \nFor example, fromunixtime(1666051200) returns 2022-10-18T00:00:00 UTC.",
+		maxParameters: 1,
+		timeBlock:     FromUnixTimeFunctionBlock{},
+	},
+	FunctionNameFormatDuration: {
+		aliases:       []string{"formatduration", "fmtduration"},
+		description:   "Takes a duration and an optional unit, and returns the duration formatted in days, hours, minutes and seconds down to the unit. \nThe unit is one of second, minute, hour or day, the default is second. \nFor example, formatduration(age, hour) returns 3d 4h for a file modified 3 days, 4 hours and 12 minutes ago.",
+		maxParameters: 2,
+		block:         FormatDurationFunctionBlock{},
+	},
+	FunctionNameAtTimeZone: {
+		aliases:       []string{"attimezone", "attz"},
+		description:   "Takes a date/time and a time zone, and returns the same instant in the time zone. \nThe time zone is a name like Europe/Berlin, UTC, Local for the system time zone or an offset like +05:30. \nFor example, attimezone(mtime, Europe/Berlin) returns 2022-10-03 13:48:05 +0200 CEST for a file modified at 2022-10-03 11:48:05 UTC.",
+		maxParameters: 2,
+		block:         AtTimeZoneFunctionBlock{},
 	},
 	FunctionNameWorkingDirectory: {
-		aliases:     []string{"cwd", "wd"},
-		description: "Returns working directory.",
-		block:       WorkingDirectoryFunctionBlock{},
+		aliases:       []string{"cwd", "wd"},
+		description:   "Returns working directory.",
+		maxParameters: 0,
+		block:         WorkingDirectoryFunctionBlock{},
 	},
 	FunctionNameConcat: {
-		aliases:     []string{"concat"},
-		description: "Takes variable number of parameter values and returns a string concatenated of all these values.",
-		block:       ConcatFunctionBlock{},
+		aliases:       []string{"concat"},
+		description:   "Takes variable number of parameter values and returns a string concatenated of all these values.",
+		maxParameters: unlimitedParameters,
+		block:         ConcatFunctionBlock{},
 	},
 	FunctionNameConcatWithSeparator: {
-		aliases:     []string{"concatws", "concatwithseparator"},
-		description: "Takes variable number of parameter values and returns a string concatenated of all these values. \nThis function uses the last parameter value as a separator.",
-		block:       ConcatWithSeparatorFunctionBlock{},
+		aliases:       []string{"concatws", "concatwithseparator"},
+		description:   "Takes variable number of parameter values and returns a string concatenated of all these values. \nThis function uses the last parameter value as a separator.",
+		maxParameters: unlimitedParameters,
+		block:         ConcatWithSeparatorFunctionBlock{},
 	},
 	FunctionNameContains: {
-		aliases:     []string{"contains"},
-		description: "Returns true, if the second parameter value is present within the first. \nFor example, contains(hello, lo) will return true.",
-		block:       ContainsFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"contains"},
+		description:   "Returns true, if the second parameter value is present within the first. \nFor example, contains(hello, lo) will return true.",
+		maxParameters: 2,
+		block:         ContainsFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameSubstring: {
-		aliases:     []string{"substr", "str"},
-		description: "Returns a substring from the main string. \nsubstr() takes 3 parameter values, first parameter value is the main string, second is the starting index (starting from 0) and the optional third \nparameter value is the end index(inclusive).",
-		block:       SubstringFunctionBlock{},
+		aliases:       []string{"substr", "str"},
+		description:   "Returns a substring from the main string. \nsubstr() takes 3 parameter values, first parameter value is the main string, second is the starting index (starting from 0) and the optional third \nparameter value is the end index(inclusive).",
+		maxParameters: 3,
+		block:         SubstringFunctionBlock{},
 	},
 	FunctionNameReplace: {
-		aliases:     []string{"replace"},
-		description: "Replaces the first occurrence of an old string with the new string. \nFor example, replace(name, test, best) will replace the first occurrence of the string 'test' with 'best' in the file name.",
-		block:       ReplaceFunctionBlock{},
+		aliases:       []string{"replace"},
+		description:   "Replaces the first occurrence of an old string with the new string. \nFor example, replace(name, test, best) will replace the first occurrence of the string 'test' with 'best' in the file name.",
+		maxParameters: 3,
+		block:         ReplaceFunctionBlock{},
 	},
 	FunctionNameReplaceAll: {
-		aliases:     []string{"replaceall"},
-		description: "Replaces all the occurrences of an old string with the new string. \nFor example, replaceall(name, test, best) will replace all the occurrences of the string 'test' with 'best' in the file name.",
-		block:       ReplaceAllFunctionBlock{},
+		aliases:       []string{"replaceall"},
+		description:   "Replaces all the occurrences of an old string with the new string. \nFor example, replaceall(name, test, best) will replace all the occurrences of the string 'test' with 'best' in the file name.",
+		maxParameters: 3,
+		block:         ReplaceAllFunctionBlock{},
 	},
 	FunctionNameIsFileTypeText: {
-		aliases:     []string{"istext", "istxt"},
-		description: "Returns true if the mime type of a file is text/plain, false otherwise.  \nFor example, the common use of this function is with mime attribute, istext(mime).",
-		block:       IsFileTypeTextFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"istext", "istxt"},
+		description:   "Returns true if the mime type of a file is text/plain, false otherwise.  \nFor example, the common use of this function is with mime attribute, istext(mime).",
+		maxParameters: 1,
+		block:         IsFileTypeTextFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameIsFileTypeImage: {
-		aliases:     []string{"isimage", "isimg"},
-		description: "Returns true if the mime type of a file is an image, false otherwise.  \nFor example, the common use of this function is with mime attribute, isimage(mime).",
-		block:       IsFileTypeImageFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"isimage", "isimg"},
+		description:   "Returns true if the mime type of a file is an image, false otherwise.  \nFor example, the common use of this function is with mime attribute, isimage(mime).",
+		maxParameters: 1,
+		block:         IsFileTypeImageFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameIsFileTypeAudio: {
-		aliases:     []string{"isaudio"},
-		description: "Returns true if the mime type of a file is an audio, false otherwise.  \nFor example, the common use of this function is with mime attribute, isaudio(mime).",
-		block:       IsFileTypeAudioFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"isaudio"},
+		description:   "Returns true if the mime type of a file is an audio, false otherwise.  \nFor example, the common use of this function is with mime attribute, isaudio(mime).",
+		maxParameters: 1,
+		block:         IsFileTypeAudioFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameIsFileTypeVideo: {
-		aliases:     []string{"isvideo"},
-		description: "Returns true if the mime type of a file is video, false otherwise.  \nFor example, the common use of this function is with mime attribute, isvideo(mime).",
-		block:       IsFileTypeVideoFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"isvideo"},
+		description:   "Returns true if the mime type of a file is video, false otherwise.  \nFor example, the common use of this function is with mime attribute, isvideo(mime).",
+		maxParameters: 1,
+		block:         IsFileTypeVideoFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameIsFileTypePdf: {
-		aliases:     []string{"ispdf"},
-		description: "Returns true if the mime type of a file is pdf, false otherwise.  \nFor example, the common use of this function is with mime attribute, ispdf(mime).",
-		block:       IsFileTypePdfFunctionBlock{},
-		tags:        map[string]bool{"where": true},
+		aliases:       []string{"ispdf"},
+		description:   "Returns true if the mime type of a file is pdf, false otherwise.  \nFor example, the common use of this function is with mime attribute, ispdf(mime).",
+		maxParameters: 1,
+		block:         IsFileTypePdfFunctionBlock{},
+		tags:          map[string]bool{"where": true},
 	},
 	FunctionNameIsFileTypeArchive: {
-		aliases:     []string{"isarchive"},
-		description: "Returns true if the mime type of a file is an archive, false otherwise.  \nFor example, the common use of this function is with mime attribute, isarchive(mime).",
+		aliases:       []string{"isarchive"},
+		description:   "Returns true if the mime type of a file is an archive, false otherwise.  \nFor example, the common use of this function is with mime attribute, isarchive(mime).",
+		maxParameters: 1,
 		block: IsFileTypeArchiveFunctionBlock{
 			matchingMimeTypes: map[string]bool{
 				"application/x-7z-compressed":   true,
@@ -391,51 +592,116 @@ var functionDefinitions = map[string]*FunctionDefinition{
 		tags: map[string]bool{"where": true},
 	},
 	FunctionNameFormatSize: {
-		aliases:     []string{"formatsize", "fmtsize"},
-		description: "Returns a human readable file size in IEC units.  \nThese include B, KiB, MiB, GiB, TiB, PiB, EiB. This function takes a single parameter.",
-		block:       FormatSizeFunctionBlock{},
+		aliases:       []string{"formatsize", "fmtsize"},
+		description:   "Returns a human readable file size in IEC units.  \nThese include B, KiB, MiB, GiB, TiB, PiB, EiB. This function takes a single parameter.",
+		maxParameters: 1,
+		block:         FormatSizeFunctionBlock{},
 	},
 	FunctionNameParseSize: {
-		aliases:     []string{"parsesize", "psize"},
-		description: "Parses the input string into the number of bytes it represents.  \nFor example, parsesize(42 MB) returns 42000000, parsesize(42 mib) returns 44040192, parsesize(10.23 Mib) returns 10726932.  \nSize unit must be one of the following: B, KiB, MiB, GiB, TiB, PiB, EiB, kB, MB, GB, TB, PB, EB.",
-		block:       ParseSizeFunctionBlock{},
+		aliases:       []string{"parsesize", "psize"},
+		description:   "Parses the input string into the number of bytes it represents.  \nFor example, parsesize(42 MB) returns 42000000, parsesize(42 mib) returns 44040192, parsesize(10.23 Mib) returns 10726932.  \nSize unit must be one of the following: B, KiB, MiB, GiB, TiB, PiB, EiB, kB, MB, GB, TB, PB, EB.",
+		maxParameters: 1,
+		block:         ParseSizeFunctionBlock{},
+	},
+	FunctionNameDirName: {
+		aliases:       []string{"dirname", "dname"},
+		description:   "Returns all but the last element of a path, the directory of the path. \nFor example, dirname(path) returns ./logs for the file ./logs/app.log.",
+		maxParameters: 1,
+		block:         DirNameFunctionBlock{},
+	},
+	FunctionNameCast: {
+		aliases:       []string{"cast"},
+		description:   "Takes a parameter value and a type, and returns the value converted to the type. The type is one of int, float, string, bool, datetime or size. \nFor example, cast(basename as int) returns 42 for the file 42.log, cast(2022-10-18 as datetime) returns the date. \nA value that can not be converted is an error, or null with --lenient.",
+		maxParameters: 2,
+		timeBlock:     CastFunctionBlock{},
+	},
+	FunctionNameToInt: {
+		aliases:       []string{"toint"},
+		description:   "Takes a single parameter value and returns it converted to an integer, a floating point value is truncated. \nFor example, toint(basename) returns 42 for the file 42.log.",
+		maxParameters: 1,
+		block:         ToIntFunctionBlock{},
+	},
+	FunctionNameToFloat: {
+		aliases:       []string{"tofloat"},
+		description:   "Takes a single parameter value and returns it converted to a floating point value. \nFor example, tofloat(basename) returns 1.50 for the file 1.5.log.",
+		maxParameters: 1,
+		block:         ToFloatFunctionBlock{},
+	},
+	FunctionNameToString: {
+		aliases:       []string{"tostring", "tostr"},
+		description:   "Takes a single parameter value and returns it converted to a string. \nFor example, tostring(size) returns 1024 as a string for a file of 1 KiB.",
+		maxParameters: 1,
+		block:         ToStringFunctionBlock{},
+	},
+	FunctionNameToDateTime: {
+		aliases:       []string{"todatetime"},
+		description:   "Takes a parameter value and an optional date/time format id, and returns the value converted to a date/time. \nWithout a format id, the formats tsfull, ts and dt are tried in order and a number is read as the seconds since the unix epoch. \nFor example, todatetime(basename, dt) returns the date for the file 2022-10-18.log.",
+		maxParameters: 2,
+		timeBlock:     ToDateTimeFunctionBlock{},
 	},
 	FunctionNameCount: {
 		aliases:        []string{"count"},
 		description:    "count is an aggregate function that returns the total number of entries in the source directory. It does not take any parameter.",
+		maxParameters:  1,
 		isAggregate:    true,
 		aggregateBlock: &CountFunctionBlock{},
 	},
 	FunctionNameCountDistinct: {
 		aliases:        []string{"countdistinct", "countd"},
 		description:    "countdistinct is an aggregate function that returns the distinct number of entries based on the parameter type. \nFor example, countdistinct(ext) will return the count of the distinct file extensions in the source directory.",
+		maxParameters:  1,
 		isAggregate:    true,
 		aggregateBlock: &CountDistinctFunctionBlock{},
 	},
 	FunctionNameSum: {
 		aliases:        []string{"summation", "sum"},
 		description:    "sum is an aggregate function that returns the sum of all the values corresponding to the provided parameter. \nFor example, sum(size) will return the sum of size of all the files in the source directory.",
+		maxParameters:  1,
 		isAggregate:    true,
 		aggregateBlock: &SumFunctionBlock{},
 	},
 	FunctionNameAverage: {
 		aliases:        []string{"average", "avg"},
 		description:    "average is an aggregate function that returns the average of all the values corresponding to the provided parameter. \nFor example, avg(size) will return the average file size in the source directory.",
+		maxParameters:  1,
 		isAggregate:    true,
 		aggregateBlock: &AverageFunctionBlock{},
 	},
 	FunctionNameMin: {
 		aliases:        []string{"min"},
 		description:    "min is an aggregate function that returns the minimum of all the values corresponding to the provided parameter. \nFor example, min(size) will return the minimum file size in the source directory.",
+		maxParameters:  1,
 		isAggregate:    true,
 		aggregateBlock: &MinFunctionBlock{},
 	},
 	FunctionNameMax: {
 		aliases:        []string{"max"},
 		description:    "max is an aggregate function that returns the maximum of all the values corresponding to the provided parameter. \nFor example, max(size) will return the maximum file size in the source directory.",
+		maxParameters:  1,
 		isAggregate:    true,
 		aggregateBlock: &MaxFunctionBlock{},
 	},
+	FunctionNameRowNumber: {
+		aliases:        []string{"rownumber", "row_number", "rownum"},
+		description:    "rownumber is a window function that returns the number of the row in its partition, starting from 1. \nFor example, rownumber() over (partition by dirname(path) order by size desc) numbers the files of every directory from the largest one.",
+		maxParameters:  0,
+		isWindow:       true,
+		aggregateBlock: &RowNumberFunctionBlock{},
+	},
+	FunctionNameRank: {
+		aliases:        []string{"rank"},
+		description:    "rank is a window function that returns the rank of the row in its partition, the rows with equal order by values share a rank and leave a gap. \nFor example, rank() over (order by size desc) returns 1, 2, 2, 4 for the sizes 30, 20, 20, 10.",
+		maxParameters:  0,
+		isWindow:       true,
+		aggregateBlock: &RankFunctionBlock{},
+	},
+	FunctionNameDenseRank: {
+		aliases:        []string{"denserank", "dense_rank"},
+		description:    "denserank is a window function that returns the rank of the row in its partition, the rows with equal order by values share a rank without leaving a gap. \nFor example, denserank() over (order by size desc) returns 1, 2, 2, 3 for the sizes 30, 20, 20, 10.",
+		maxParameters:  0,
+		isWindow:       true,
+		aggregateBlock: &DenseRankFunctionBlock{},
+	},
 }
 
 func NewFunctions() *AllFunctions {
@@ -447,6 +713,15 @@ func NewFunctions() *AllFunctions {
 	}
 	return &AllFunctions{
 		supportedFunctions: supportedFunctions,
+		timeContext:        newTimeContext(),
+	}
+}
+
+func (functions *AllFunctions) InLenientMode() *AllFunctions {
+	return &AllFunctions{
+		supportedFunctions: functions.supportedFunctions,
+		timeContext:        functions.timeContext,
+		lenient:            true,
 	}
 }
 
@@ -463,6 +738,19 @@ func (functions *AllFunctions) ContainsATag(function string, tag string) bool {
 	return definition.tags[strings.ToLower(tag)]
 }
 
+func (functions *AllFunctions) CanonicalNameOf(function string) string {
+	definition, ok := functions.supportedFunctions[strings.ToLower(function)]
+	if !ok {
+		return ""
+	}
+	for name, aDefinition := range functionDefinitions {
+		if aDefinition == definition {
+			return name
+		}
+	}
+	return ""
+}
+
 func (functions *AllFunctions) IsAnAggregateFunction(function string) bool {
 	fn, ok := functions.supportedFunctions[strings.ToLower(function)]
 	if ok {
@@ -471,6 +759,22 @@ func (functions *AllFunctions) IsAnAggregateFunction(function string) bool {
 	return false
 }
 
+func (functions *AllFunctions) IsAWindowFunction(function string) bool {
+	fn, ok := functions.supportedFunctions[strings.ToLower(function)]
+	if ok {
+		return fn.isWindow
+	}
+	return false
+}
+
+func (functions *AllFunctions) AcceptsParameters(function string, count int) bool {
+	fn, ok := functions.supportedFunctions[strings.ToLower(function)]
+	if !ok {
+		return false
+	}
+	return fn.maxParameters == unlimitedParameters || count <= fn.maxParameters
+}
+
 func (functions *AllFunctions) AllFunctionsWithAliases() map[string][]string {
 	aliasesByFunction := make(map[string][]string, len(functionDefinitions))
 	for function, definition := range functionDefinitions {
@@ -498,16 +802,42 @@ func (functions *AllFunctions) DescriptionOf(fn string) string {
 }
 
 func (functions *AllFunctions) Execute(fn string, args ...Value) (Value, error) {
-	return functions.supportedFunctions[strings.ToLower(fn)].block.run(args...)
+	function := functions.supportedFunctions[strings.ToLower(fn)]
+	if !function.acceptsNull && anyNull(args) {
+		return NullValue, nil
+	}
+	var value Value
+	var err error
+	if function.timeBlock != nil {
+		value, err = function.timeBlock.run(functions.timeContext, args...)
+	} else {
+		value, err = function.block.run(args...)
+	}
+	var conversionFailure conversionError
+	if err != nil && functions.lenient && errors.As(err, &conversionFailure) {
+		return NullValue, nil
+	}
+	return value, err
 }
 
 func (functions *AllFunctions) ExecuteAggregate(fn string, initialState *FunctionState, args ...Value) (*FunctionState, error) {
-	return functions.supportedFunctions[strings.ToLower(fn)].aggregateBlock.run(initialState, args...)
+	function := functions.supportedFunctions[strings.ToLower(fn)]
+	if function.isAggregate && anyNull(args) {
+		skipped := *initialState
+		skipped.hasNulls = true
+		return &skipped, nil
+	}
+	state, err := function.aggregateBlock.run(initialState, args...)
+	if err != nil {
+		return nil, err
+	}
+	state.hasNulls = state.hasNulls || initialState.hasNulls
+	return state, nil
 }
 
 func (functions *AllFunctions) InitialState(fn string) *FunctionState {
 	function := functions.supportedFunctions[strings.ToLower(fn)]
-	if function.isAggregate {
+	if function.isAggregate || function.isWindow {
 		return function.aggregateBlock.initialState()
 	}
 	return nil
@@ -515,22 +845,21 @@ func (functions *AllFunctions) InitialState(fn string) *FunctionState {
 
 func (functions *AllFunctions) FinalValue(fn string, state *FunctionState, values []Value) (Value, error) {
 	function := functions.supportedFunctions[strings.ToLower(fn)]
-	if function.isAggregate {
+	if function.isAggregate || function.isWindow {
 		return function.aggregateBlock.finalValue(state, values)
 	}
 	return EmptyValue, nil
 }
 
-var nowFunc = func() time.Time {
-	return time.Now()
-}
-
-func now() time.Time {
-	return nowFunc().UTC()
+func anyNull(args []Value) bool {
+	for _, arg := range args {
+		if arg.IsNull() {
+			return true
+		}
+	}
+	return false
 }
 
-func resetClock() {
-	nowFunc = func() time.Time {
-		return time.Now()
-	}
+func (conversionError conversionError) Error() string {
+	return conversionError.err.Error()
 }
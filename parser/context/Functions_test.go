@@ -41,6 +41,25 @@ func TestDescriptionOfAnUnSupportedFunction(t *testing.T) {
 	}
 }
 
+func TestAcceptsParametersUpToTheMaximum(t *testing.T) {
+	functions := NewFunctions()
+
+	if !functions.AcceptsParameters("lower", 1) {
+		t.Fatalf("Expected lower to accept 1 parameter")
+	}
+	if functions.AcceptsParameters("lower", 2) {
+		t.Fatalf("Expected lower to not accept 2 parameters")
+	}
+}
+
+func TestAcceptsParametersForAFunctionWithVariableParameters(t *testing.T) {
+	functions := NewFunctions()
+
+	if !functions.AcceptsParameters("concat", 5) {
+		t.Fatalf("Expected concat to accept 5 parameters")
+	}
+}
+
 func TestFinalValueOfAggregateFunction(t *testing.T) {
 	functions := NewFunctions()
 	value, _ := functions.FinalValue("count", &FunctionState{Initial: Uint32Value(15), isUpdated: true}, []Value{})
@@ -1,16 +1,62 @@
 package context
 
+import (
+	"goselect/parser/tokenizer"
+	"strings"
+	"time"
+)
+
 type ParsingApplicationContext struct {
 	allFunctions  *AllFunctions
 	allAttributes *AllAttributes
+	sourceAliases map[string]bool
+	strict        bool
+	times         *TimeContext
 }
 
+// NewContext shares the time context of the functions, so that the whole query sees the same time zone, formats and instant.
 func NewContext(functions *AllFunctions, attributes *AllAttributes) *ParsingApplicationContext {
-	return &ParsingApplicationContext{allFunctions: functions, allAttributes: attributes}
+	times := newTimeContext()
+	if functions != nil {
+		times = functions.timeContext
+	}
+	return &ParsingApplicationContext{allFunctions: functions, allAttributes: attributes, times: times}
+}
+
+func (context *ParsingApplicationContext) WithSourceAliases(aliases []string) *ParsingApplicationContext {
+	sourceAliases := make(map[string]bool, len(aliases))
+	for _, alias := range aliases {
+		sourceAliases[strings.ToLower(alias)] = true
+	}
+	return &ParsingApplicationContext{
+		allFunctions:  context.allFunctions,
+		allAttributes: context.allAttributes,
+		sourceAliases: sourceAliases,
+		strict:        context.strict,
+		times:         context.times,
+	}
+}
+
+func (context *ParsingApplicationContext) InStrictMode() *ParsingApplicationContext {
+	return &ParsingApplicationContext{
+		allFunctions:  context.allFunctions,
+		allAttributes: context.allAttributes,
+		sourceAliases: context.sourceAliases,
+		strict:        true,
+		times:         context.times,
+	}
+}
+
+func (context *ParsingApplicationContext) IsInStrictMode() bool {
+	return context.strict
 }
 
 func (context *ParsingApplicationContext) IsASupportedAttribute(attribute string) bool {
-	return context.allAttributes.IsASupportedAttribute(attribute)
+	if context.allAttributes.IsASupportedAttribute(attribute) {
+		return true
+	}
+	qualifier, attribute, ok := QualifiedAttribute(attribute)
+	return ok && context.sourceAliases[strings.ToLower(qualifier)] && context.allAttributes.IsASupportedAttribute(attribute)
 }
 
 func (context *ParsingApplicationContext) IsASupportedFunction(functionName string) bool {
@@ -25,10 +71,38 @@ func (context *ParsingApplicationContext) IsAnAggregateFunction(functionName str
 	return context.allFunctions.IsAnAggregateFunction(functionName)
 }
 
+func (context *ParsingApplicationContext) IsAWindowFunction(functionName string) bool {
+	return context.allFunctions.IsAWindowFunction(functionName)
+}
+
 func (context *ParsingApplicationContext) InitialState(functionName string) *FunctionState {
 	return context.allFunctions.InitialState(functionName)
 }
 
+func (context *ParsingApplicationContext) AllAttributes() *AllAttributes {
+	return context.allAttributes
+}
+
 func (context *ParsingApplicationContext) AllFunctions() *AllFunctions {
 	return context.allFunctions
 }
+
+func (context *ParsingApplicationContext) RegisterFormat(id string, pattern string) error {
+	return context.times.formats.register(id, pattern)
+}
+
+func (context *ParsingApplicationContext) ToValue(token tokenizer.Token) (Value, error) {
+	return toValue(token, context.times.now())
+}
+
+func (context *ParsingApplicationContext) SetTimeZone(zone string) error {
+	return context.times.setTimeZone(zone)
+}
+
+func (context *ParsingApplicationContext) TimeZone() *time.Location {
+	return context.times.location
+}
+
+func (context *ParsingApplicationContext) timeContext() *TimeContext {
+	return context.times
+}
@@ -121,3 +121,38 @@ func TestAllFunctions(t *testing.T) {
 		t.Fatalf("Expected allFunctions to be non-nil but was nil")
 	}
 }
+
+func TestIsASupportedQualifiedAttribute(t *testing.T) {
+	context := NewContext(nil, NewAttributes()).WithSourceAliases([]string{"a", "b"})
+	isASupportedAttribute := context.IsASupportedAttribute("B.size")
+
+	if isASupportedAttribute != true {
+		t.Fatalf("Expected B.size to be a supported attribute but was not")
+	}
+}
+
+func TestIsNotASupportedQualifiedAttributeGivenAnUnknownAlias(t *testing.T) {
+	context := NewContext(nil, NewAttributes()).WithSourceAliases([]string{"a"})
+	isASupportedAttribute := context.IsASupportedAttribute("readme.size")
+
+	if isASupportedAttribute != false {
+		t.Fatalf("Expected readme.size to be an un-supported attribute but was")
+	}
+}
+
+func TestIsNotASupportedQualifiedAttributeGivenAnUnknownAttribute(t *testing.T) {
+	context := NewContext(nil, NewAttributes()).WithSourceAliases([]string{"a"})
+	isASupportedAttribute := context.IsASupportedAttribute("a.unknown")
+
+	if isASupportedAttribute != false {
+		t.Fatalf("Expected a.unknown to be an un-supported attribute but was")
+	}
+}
+
+func TestStrictModeIsRetainedWithSourceAliases(t *testing.T) {
+	context := NewContext(nil, NewAttributes()).InStrictMode().WithSourceAliases([]string{"a"})
+
+	if !context.IsInStrictMode() {
+		t.Fatalf("Expected the context to be in the strict mode but was not")
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/dustin/go-humanize"
 	"golang.org/x/text/cases"
 	"goselect/parser/error/messages"
+	"math"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -28,7 +30,11 @@ type GreaterThanEqualFunctionBlock struct{}
 type OrFunctionBlock struct{}
 type AndFunctionBlock struct{}
 type NotFunctionBlock struct{}
+type InFunctionBlock struct{}
 type LikeFunctionBlock struct{ executionCache *FunctionExecutionCache }
+type RegexMatchFunctionBlock struct{ executionCache *FunctionExecutionCache }
+type RegexExtractFunctionBlock struct{ executionCache *FunctionExecutionCache }
+type RegexReplaceFunctionBlock struct{ executionCache *FunctionExecutionCache }
 type LowerFunctionBlock struct{}
 type UpperFunctionBlock struct{}
 type TitleFunctionBlock struct{ caser cases.Caser }
@@ -38,6 +44,11 @@ type TrimFunctionBlock struct{}
 type LeftTrimFunctionBlock struct{}
 type RightTrimFunctionBlock struct{}
 type IfBlankFunctionBlock struct{}
+type IfFunctionBlock struct{}
+type IsNullFunctionBlock struct{}
+type IsNotNullFunctionBlock struct{}
+type CoalesceFunctionBlock struct{}
+type NullIfFunctionBlock struct{}
 type StartsWithFunctionBlock struct{}
 type EndsWithFunctionBlock struct{}
 type NowFunctionBlock struct{}
@@ -50,6 +61,13 @@ type ExtractFunctionBlock struct{}
 type HoursDifferenceFunctionBlock struct{}
 type DaysDifferenceFunctionBlock struct{}
 type ParseDateTimeFunctionBlock struct{}
+type DateAddFunctionBlock struct{}
+type DateTruncFunctionBlock struct{}
+type FormatDateTimeFunctionBlock struct{}
+type UnixTimeFunctionBlock struct{}
+type FromUnixTimeFunctionBlock struct{}
+type FormatDurationFunctionBlock struct{}
+type AtTimeZoneFunctionBlock struct{}
 type WorkingDirectoryFunctionBlock struct{}
 type ConcatFunctionBlock struct{}
 type ConcatWithSeparatorFunctionBlock struct{}
@@ -67,6 +85,12 @@ type IsFileTypeArchiveFunctionBlock struct {
 }
 type FormatSizeFunctionBlock struct{}
 type ParseSizeFunctionBlock struct{}
+type DirNameFunctionBlock struct{}
+type CastFunctionBlock struct{}
+type ToIntFunctionBlock struct{}
+type ToFloatFunctionBlock struct{}
+type ToStringFunctionBlock struct{}
+type ToDateTimeFunctionBlock struct{}
 
 func (receiver IdentityFunctionBlock) run(args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameIdentity, 1); err != nil {
@@ -139,7 +163,7 @@ func (d DivideFunctionBlock) run(args ...Value) (Value, error) {
 }
 
 func (e EqualFunctionBlock) run(args ...Value) (Value, error) {
-	if err := ensureNParametersOrError(args, FunctionNameEqual, 2); err != nil {
+	if err := ensureNComparableParametersOrError(args, FunctionNameEqual, 2); err != nil {
 		return EmptyValue, err
 	}
 	if args[0].CompareTo(args[1]) == CompareToEqual {
@@ -149,7 +173,7 @@ func (e EqualFunctionBlock) run(args ...Value) (Value, error) {
 }
 
 func (n NotEqualFunctionBlock) run(args ...Value) (Value, error) {
-	if err := ensureNParametersOrError(args, FunctionNameEqual, 2); err != nil {
+	if err := ensureNComparableParametersOrError(args, FunctionNameEqual, 2); err != nil {
 		return EmptyValue, err
 	}
 	if args[0].CompareTo(args[1]) == CompareToEqual {
@@ -158,8 +182,29 @@ func (n NotEqualFunctionBlock) run(args ...Value) (Value, error) {
 	return trueBooleanValue, nil
 }
 
+// run follows the SQL null semantics, 1 in (1, null) is true, 2 in (1, null) and null in (1, 2) are null.
+func (i InFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNComparableParametersOrError(args, FunctionNameIn, 2); err != nil {
+		return EmptyValue, err
+	}
+	hasANull := args[0].IsNull()
+	for _, arg := range args[1:] {
+		if arg.IsNull() {
+			hasANull = true
+			continue
+		}
+		if !args[0].IsNull() && args[0].CompareTo(arg) == CompareToEqual {
+			return trueBooleanValue, nil
+		}
+	}
+	if hasANull {
+		return NullValue, nil
+	}
+	return falseBooleanValue, nil
+}
+
 func (l LessThanFunctionBlock) run(args ...Value) (Value, error) {
-	if err := ensureNParametersOrError(args, FunctionNameLessThan, 2); err != nil {
+	if err := ensureNComparableParametersOrError(args, FunctionNameLessThan, 2); err != nil {
 		return EmptyValue, err
 	}
 	if args[0].CompareTo(args[1]) == CompareToLessThan {
@@ -169,7 +214,7 @@ func (l LessThanFunctionBlock) run(args ...Value) (Value, error) {
 }
 
 func (g GreaterThanFunctionBlock) run(args ...Value) (Value, error) {
-	if err := ensureNParametersOrError(args, FunctionNameGreaterThan, 2); err != nil {
+	if err := ensureNComparableParametersOrError(args, FunctionNameGreaterThan, 2); err != nil {
 		return EmptyValue, err
 	}
 	if args[0].CompareTo(args[1]) == CompareToGreaterThan {
@@ -179,7 +224,7 @@ func (g GreaterThanFunctionBlock) run(args ...Value) (Value, error) {
 }
 
 func (l LessThanEqualFunctionBlock) run(args ...Value) (Value, error) {
-	if err := ensureNParametersOrError(args, FunctionNameLessThanEqual, 2); err != nil {
+	if err := ensureNComparableParametersOrError(args, FunctionNameLessThanEqual, 2); err != nil {
 		return EmptyValue, err
 	}
 	if args[0].CompareTo(args[1]) == CompareToLessThan || args[0].CompareTo(args[1]) == CompareToEqual {
@@ -189,7 +234,7 @@ func (l LessThanEqualFunctionBlock) run(args ...Value) (Value, error) {
 }
 
 func (g GreaterThanEqualFunctionBlock) run(args ...Value) (Value, error) {
-	if err := ensureNParametersOrError(args, FunctionNameGreaterThanEqual, 2); err != nil {
+	if err := ensureNComparableParametersOrError(args, FunctionNameGreaterThanEqual, 2); err != nil {
 		return EmptyValue, err
 	}
 	if args[0].CompareTo(args[1]) == CompareToGreaterThan || args[0].CompareTo(args[1]) == CompareToEqual {
@@ -202,7 +247,12 @@ func (o OrFunctionBlock) run(args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameOr, 1); err != nil {
 		return EmptyValue, err
 	}
+	hasNull := false
 	for _, arg := range args {
+		if arg.IsNull() {
+			hasNull = true
+			continue
+		}
 		result, err := arg.GetBoolean()
 		if err != nil {
 			return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameOr, err)
@@ -211,6 +261,9 @@ func (o OrFunctionBlock) run(args ...Value) (Value, error) {
 			return trueBooleanValue, nil
 		}
 	}
+	if hasNull {
+		return NullValue, nil
+	}
 	return falseBooleanValue, nil
 }
 
@@ -218,7 +271,12 @@ func (a AndFunctionBlock) run(args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameAnd, 1); err != nil {
 		return EmptyValue, err
 	}
+	hasNull := false
 	for _, arg := range args {
+		if arg.IsNull() {
+			hasNull = true
+			continue
+		}
 		result, err := arg.GetBoolean()
 		if err != nil {
 			return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameAnd, err)
@@ -227,6 +285,9 @@ func (a AndFunctionBlock) run(args ...Value) (Value, error) {
 			return falseBooleanValue, nil
 		}
 	}
+	if hasNull {
+		return NullValue, nil
+	}
 	return trueBooleanValue, nil
 }
 
@@ -234,6 +295,9 @@ func (n NotFunctionBlock) run(args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameNot, 1); err != nil {
 		return EmptyValue, err
 	}
+	if args[0].IsNull() {
+		return NullValue, nil
+	}
 	result, err := args[0].GetBoolean()
 	if err != nil {
 		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameNot, err)
@@ -245,19 +309,58 @@ func (l LikeFunctionBlock) run(args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameLike, 2); err != nil {
 		return EmptyValue, err
 	}
-	toMatch := args[0].GetAsString()
-	pattern := args[1]
-	cached, ok := l.executionCache.Get(pattern)
+	compiled, err := compiledPattern(l.executionCache, args[1])
+	if err != nil {
+		return EmptyValue, err
+	}
+	return booleanValueUsing(compiled.MatchString(args[0].GetAsString())), nil
+}
 
-	if !ok {
-		if compiled, err := regexp.Compile(pattern.GetAsString()); err != nil {
+func (r RegexMatchFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameRegexMatch, 2); err != nil {
+		return EmptyValue, err
+	}
+	compiled, err := compiledPattern(r.executionCache, args[1])
+	if err != nil {
+		return EmptyValue, err
+	}
+	return booleanValueUsing(compiled.MatchString(args[0].GetAsString())), nil
+}
+
+func (r RegexExtractFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameRegexExtract, 2); err != nil {
+		return EmptyValue, err
+	}
+	compiled, err := compiledPattern(r.executionCache, args[1])
+	if err != nil {
+		return EmptyValue, err
+	}
+	group := 0
+	if len(args) >= 3 && args[2].valueType != ValueTypeUndefined {
+		if group, err = groupIndexOf(compiled, args[2]); err != nil {
 			return EmptyValue, err
-		} else {
-			l.executionCache.Put(pattern, compiled)
-			return booleanValueUsing(compiled.MatchString(toMatch)), nil
 		}
 	}
-	return booleanValueUsing(cached.(*regexp.Regexp).MatchString(toMatch)), nil
+	matches := compiled.FindStringSubmatch(args[0].GetAsString())
+	if matches == nil {
+		return StringValue(""), nil
+	}
+	return StringValue(matches[group]), nil
+}
+
+func (r RegexReplaceFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameRegexReplace, 2); err != nil {
+		return EmptyValue, err
+	}
+	compiled, err := compiledPattern(r.executionCache, args[1])
+	if err != nil {
+		return EmptyValue, err
+	}
+	replacement := ""
+	if len(args) >= 3 {
+		replacement = args[2].GetAsString()
+	}
+	return StringValue(compiled.ReplaceAllString(args[0].GetAsString(), replacement)), nil
 }
 
 func (l LowerFunctionBlock) run(args ...Value) (Value, error) {
@@ -321,12 +424,65 @@ func (i IfBlankFunctionBlock) run(args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameIfBlank, 2); err != nil {
 		return EmptyValue, err
 	}
-	if len(strings.TrimSpace(args[0].GetAsString())) == 0 {
+	if args[0].IsNull() || len(strings.TrimSpace(args[0].GetAsString())) == 0 {
 		return args[1], nil
 	}
 	return args[0], nil
 }
 
+func (i IfFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameIf, 3); err != nil {
+		return EmptyValue, err
+	}
+	condition, err := args[0].GetBoolean()
+	if err != nil {
+		return EmptyValue, err
+	}
+	if condition {
+		return args[1], nil
+	}
+	return args[2], nil
+}
+
+func (i IsNullFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameIsNull, 1); err != nil {
+		return EmptyValue, err
+	}
+	return booleanValueUsing(args[0].IsNull()), nil
+}
+
+func (i IsNotNullFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameIsNotNull, 1); err != nil {
+		return EmptyValue, err
+	}
+	return booleanValueUsing(!args[0].IsNull()), nil
+}
+
+func (c CoalesceFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameCoalesce, 1); err != nil {
+		return EmptyValue, err
+	}
+	for _, arg := range args {
+		if !arg.IsNull() {
+			return arg, nil
+		}
+	}
+	return NullValue, nil
+}
+
+func (n NullIfFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNComparableParametersOrError(args, FunctionNameNullIf, 2); err != nil {
+		return EmptyValue, err
+	}
+	if args[0].IsNull() || args[1].IsNull() {
+		return args[0], nil
+	}
+	if args[0].CompareTo(args[1]) == CompareToEqual {
+		return NullValue, nil
+	}
+	return args[0], nil
+}
+
 func (s StartsWithFunctionBlock) run(args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameStartsWith, 2); err != nil {
 		return EmptyValue, err
@@ -341,28 +497,28 @@ func (e EndsWithFunctionBlock) run(args ...Value) (Value, error) {
 	return booleanValueUsing(strings.HasSuffix(args[0].GetAsString(), args[1].GetAsString())), nil
 }
 
-func (n NowFunctionBlock) run(_ ...Value) (Value, error) {
-	return DateTimeValue(now()), nil
+func (n NowFunctionBlock) run(timeContext *TimeContext, _ ...Value) (Value, error) {
+	return DateTimeValue(timeContext.now()), nil
 }
 
-func (c CurrentDayFunctionBlock) run(_ ...Value) (Value, error) {
-	return IntValue(now().Day()), nil
+func (c CurrentDayFunctionBlock) run(timeContext *TimeContext, _ ...Value) (Value, error) {
+	return IntValue(timeContext.now().Day()), nil
 }
 
-func (c CurrentDateFunctionBlock) run(_ ...Value) (Value, error) {
-	return formatDate(now()), nil
+func (c CurrentDateFunctionBlock) run(timeContext *TimeContext, _ ...Value) (Value, error) {
+	return formatDate(timeContext.now()), nil
 }
 
-func (c CurrentMonthFunctionBlock) run(_ ...Value) (Value, error) {
-	return StringValue(now().Month().String()), nil
+func (c CurrentMonthFunctionBlock) run(timeContext *TimeContext, _ ...Value) (Value, error) {
+	return StringValue(timeContext.now().Month().String()), nil
 }
 
-func (c CurrentYearFunctionBlock) run(_ ...Value) (Value, error) {
-	return IntValue(now().Year()), nil
+func (c CurrentYearFunctionBlock) run(timeContext *TimeContext, _ ...Value) (Value, error) {
+	return IntValue(timeContext.now().Year()), nil
 }
 
-func (d DayOfWeekFunctionBlock) run(_ ...Value) (Value, error) {
-	return StringValue(now().Weekday().String()), nil
+func (d DayOfWeekFunctionBlock) run(timeContext *TimeContext, _ ...Value) (Value, error) {
+	return StringValue(timeContext.now().Weekday().String()), nil
 }
 
 func (w WorkingDirectoryFunctionBlock) run(_ ...Value) (Value, error) {
@@ -544,6 +700,13 @@ func (f ParseSizeFunctionBlock) run(args ...Value) (Value, error) {
 	return Uint64Value(v), nil
 }
 
+func (d DirNameFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameDirName, 1); err != nil {
+		return EmptyValue, err
+	}
+	return StringValue(filepath.Dir(args[0].GetAsString())), nil
+}
+
 func mimeTypeMatches(expectedMimeType string, arg Value) bool {
 	mimeType := arg.GetAsString()
 	return strings.Contains(mimeType, expectedMimeType)
@@ -574,7 +737,7 @@ func (e ExtractFunctionBlock) run(args ...Value) (Value, error) {
 	}
 }
 
-func (h HoursDifferenceFunctionBlock) run(args ...Value) (Value, error) {
+func (h HoursDifferenceFunctionBlock) run(timeContext *TimeContext, args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameHoursDifference, 1); err != nil {
 		return EmptyValue, err
 	}
@@ -582,7 +745,7 @@ func (h HoursDifferenceFunctionBlock) run(args ...Value) (Value, error) {
 	if err != nil {
 		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameHoursDifference, err)
 	}
-	bTime := now()
+	bTime := timeContext.now()
 	if len(args) > 1 {
 		bTime, err = args[1].GetDateTime()
 		if err != nil {
@@ -593,7 +756,7 @@ func (h HoursDifferenceFunctionBlock) run(args ...Value) (Value, error) {
 	return Float64Value(duration.Hours()), nil
 }
 
-func (d DaysDifferenceFunctionBlock) run(args ...Value) (Value, error) {
+func (d DaysDifferenceFunctionBlock) run(timeContext *TimeContext, args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameDaysDifference, 1); err != nil {
 		return EmptyValue, err
 	}
@@ -601,7 +764,7 @@ func (d DaysDifferenceFunctionBlock) run(args ...Value) (Value, error) {
 	if err != nil {
 		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameDaysDifference, err)
 	}
-	bTime := now()
+	bTime := timeContext.now()
 	if len(args) > 1 {
 		bTime, err = args[1].GetDateTime()
 		if err != nil {
@@ -613,23 +776,251 @@ func (d DaysDifferenceFunctionBlock) run(args ...Value) (Value, error) {
 	return Float64Value(days), nil
 }
 
-func (p ParseDateTimeFunctionBlock) run(args ...Value) (Value, error) {
+func (p ParseDateTimeFunctionBlock) run(timeContext *TimeContext, args ...Value) (Value, error) {
 	if err := ensureNParametersOrError(args, FunctionNameDateTimeParse, 2); err != nil {
 		return EmptyValue, err
 	}
 
 	timeAsStr := args[0].GetAsString()
 	formatId := args[1].GetAsString()
-	parsed, err := parse(timeAsStr, formatId)
+	parsed, err := timeContext.formats.parse(timeAsStr, formatId, timeContext.location)
 	if err != nil {
 		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameDateTimeParse, err)
 	}
 	return DateTimeValue(parsed), nil
 }
 
+func (d DateAddFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameDateAdd, 3); err != nil {
+		return EmptyValue, err
+	}
+	aTime, err := args[0].GetDateTime()
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameDateAdd, err)
+	}
+	amount, err := args[1].GetNumericAsFloat64()
+	if err != nil || amount != math.Trunc(amount) {
+		return EmptyValue, fmt.Errorf(
+			messages.ErrorMessageFunctionNamePrefixWithExistingError,
+			FunctionNameDateAdd,
+			fmt.Errorf(messages.ErrorMessageExpectedIntegerAmount, args[1].GetAsString()),
+		)
+	}
+	unit, err := dateTimeUnitOf(args[2])
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameDateAdd, err)
+	}
+	return DateTimeValue(addToDateTime(aTime, int(amount), unit)), nil
+}
+
+func (d DateTruncFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameDateTrunc, 2); err != nil {
+		return EmptyValue, err
+	}
+	aTime, err := args[0].GetDateTime()
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameDateTrunc, err)
+	}
+	unit, err := dateTimeUnitOf(args[1])
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameDateTrunc, err)
+	}
+	return DateTimeValue(truncateDateTime(aTime, unit)), nil
+}
+
+func (f FormatDateTimeFunctionBlock) run(timeContext *TimeContext, args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameFormatDateTime, 2); err != nil {
+		return EmptyValue, err
+	}
+	aTime, err := args[0].GetDateTime()
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameFormatDateTime, err)
+	}
+	formatted, err := timeContext.formats.format(aTime, args[1].GetAsString())
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameFormatDateTime, err)
+	}
+	return StringValue(formatted), nil
+}
+
+func (u UnixTimeFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameUnixTime, 1); err != nil {
+		return EmptyValue, err
+	}
+	aTime, err := args[0].GetDateTime()
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameUnixTime, err)
+	}
+	return Int64Value(aTime.Unix()), nil
+}
+
+func (f FromUnixTimeFunctionBlock) run(timeContext *TimeContext, args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameFromUnixTime, 1); err != nil {
+		return EmptyValue, err
+	}
+	seconds, err := args[0].GetNumericAsFloat64()
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameFromUnixTime, err)
+	}
+	wholeSeconds, fraction := math.Modf(seconds)
+	return DateTimeValue(time.Unix(int64(wholeSeconds), int64(fraction*float64(time.Second))).In(timeContext.location)), nil
+}
+
+func (f FormatDurationFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameFormatDuration, 1); err != nil {
+		return EmptyValue, err
+	}
+	duration, err := args[0].GetDuration()
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameFormatDuration, err)
+	}
+	precision := time.Second
+	if len(args) > 1 {
+		if precision, err = durationPrecisionOf(args[1]); err != nil {
+			return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameFormatDuration, err)
+		}
+	}
+	return StringValue(formatDuration(duration, precision, " ")), nil
+}
+
+func (a AtTimeZoneFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameAtTimeZone, 2); err != nil {
+		return EmptyValue, err
+	}
+	aTime, err := args[0].GetDateTime()
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameAtTimeZone, err)
+	}
+	aLocation, err := timeZoneOf(args[1].GetAsString())
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameAtTimeZone, err)
+	}
+	return DateTimeValue(aTime.In(aLocation)), nil
+}
+
+func (c CastFunctionBlock) run(timeContext *TimeContext, args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameCast, 2); err != nil {
+		return EmptyValue, err
+	}
+	if strings.EqualFold(args[1].GetAsString(), CastTypeDateTime) {
+		return castToDateTimeWith(FunctionNameCast, args[0], timeContext.location)
+	}
+	return castWith(FunctionNameCast, args[0], args[1].GetAsString())
+}
+
+func (t ToIntFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameToInt, 1); err != nil {
+		return EmptyValue, err
+	}
+	return castWith(FunctionNameToInt, args[0], CastTypeInt)
+}
+
+func (t ToFloatFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameToFloat, 1); err != nil {
+		return EmptyValue, err
+	}
+	return castWith(FunctionNameToFloat, args[0], CastTypeFloat)
+}
+
+func (t ToStringFunctionBlock) run(args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameToString, 1); err != nil {
+		return EmptyValue, err
+	}
+	return castWith(FunctionNameToString, args[0], CastTypeString)
+}
+
+func (t ToDateTimeFunctionBlock) run(timeContext *TimeContext, args ...Value) (Value, error) {
+	if err := ensureNParametersOrError(args, FunctionNameToDateTime, 1); err != nil {
+		return EmptyValue, err
+	}
+	if len(args) == 1 || args[0].valueType == ValueTypeDateTime {
+		return castToDateTimeWith(FunctionNameToDateTime, args[0], timeContext.location)
+	}
+	formatId := args[1].GetAsString()
+	layout, err := timeContext.formats.layoutOf(formatId)
+	if err != nil {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, FunctionNameToDateTime, err)
+	}
+	parsed, err := time.ParseInLocation(layout, strings.TrimSpace(args[0].GetAsString()), timeContext.location)
+	if err != nil {
+		return EmptyValue, conversionError{
+			err: fmt.Errorf(
+				messages.ErrorMessageFunctionNamePrefixWithExistingError,
+				FunctionNameToDateTime,
+				fmt.Errorf(messages.ErrorMessageCannotCast, args[0].GetAsString(), CastTypeDateTime),
+			),
+		}
+	}
+	return DateTimeValue(parsed), nil
+}
+
+func castWith(functionName string, value Value, castType string) (Value, error) {
+	castTo, ok := castFunctions[strings.ToLower(castType)]
+	if !ok {
+		return EmptyValue, fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, functionName, fmt.Errorf(messages.ErrorMessageUnsupportedCastType, strings.Join(castTypes, ", "), castType))
+	}
+	converted, err := castTo(value)
+	if err != nil {
+		return EmptyValue, conversionError{err: fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, functionName, err)}
+	}
+	return converted, nil
+}
+
+func castToDateTimeWith(functionName string, value Value, location *time.Location) (Value, error) {
+	converted, err := castToDateTime(value, location)
+	if err != nil {
+		return EmptyValue, conversionError{err: fmt.Errorf(messages.ErrorMessageFunctionNamePrefixWithExistingError, functionName, err)}
+	}
+	return converted, nil
+}
+
 func formatDate(time time.Time) Value {
 	return StringValue(strconv.Itoa(time.Year()) + "-" + time.Month().String() + "-" + fmt.Sprintf("%02v", time.Day()))
 }
+
+func compiledPattern(executionCache *FunctionExecutionCache, pattern Value) (*regexp.Regexp, error) {
+	if cached, ok := executionCache.Get(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	compiled, err := regexp.Compile(pattern.GetAsString())
+	if err != nil {
+		return nil, err
+	}
+	executionCache.Put(pattern, compiled)
+	return compiled, nil
+}
+
+func groupIndexOf(compiled *regexp.Regexp, group Value) (int, error) {
+	index, err := strconv.Atoi(group.GetAsString())
+	if err != nil {
+		index = compiled.SubexpIndex(group.GetAsString())
+	}
+	if index < 0 || index > compiled.NumSubexp() {
+		return -1, fmt.Errorf(
+			messages.ErrorMessageFunctionNamePrefixWithExistingError,
+			FunctionNameRegexExtract,
+			fmt.Sprintf(messages.ErrorMessageIncorrectRegexGroup, group.GetAsString(), compiled.NumSubexp(), compiled.String()),
+		)
+	}
+	return index, nil
+}
+
+func ensureNComparableParametersOrError(parameters []Value, fn string, n int) error {
+	if err := ensureNParametersOrError(parameters, fn, n); err != nil {
+		return err
+	}
+	for _, parameter := range parameters[1:] {
+		if parameters[0].isANumber() && parameter.isADuration() || parameters[0].isADuration() && parameter.isANumber() {
+			return fmt.Errorf(
+				messages.ErrorMessageFunctionNamePrefixWithExistingError,
+				fn,
+				fmt.Errorf(messages.ErrorMessageNumberComparedWithDuration, parameters[0].GetAsString(), parameter.GetAsString()),
+			)
+		}
+	}
+	return nil
+}
+
 func ensureNParametersOrError(parameters []Value, fn string, n int) error {
 	nonNilParameterCount := func() int {
 		count := 0
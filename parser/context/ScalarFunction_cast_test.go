@@ -0,0 +1,180 @@
+//go:build unit
+// +build unit
+
+package context
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCastWithMissingParameterValue(t *testing.T) {
+	_, err := NewFunctions().Execute("cast", StringValue("42"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing cast with a missing parameter value")
+	}
+}
+
+func TestCastWithAnUnsupportedType(t *testing.T) {
+	_, err := NewFunctions().Execute("cast", StringValue("42"), StringValue("blob"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing cast with an unsupported type")
+	}
+}
+
+func TestCastWithAnUnsupportedTypeInLenientMode(t *testing.T) {
+	_, err := NewFunctions().InLenientMode().Execute("cast", StringValue("42"), StringValue("blob"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing cast with an unsupported type in the lenient mode")
+	}
+}
+
+func TestCastAStringToInt(t *testing.T) {
+	value, _ := NewFunctions().Execute("cast", StringValue(" 42 "), StringValue("int"))
+
+	if value.CompareTo(Int64Value(42)) != CompareToEqual {
+		t.Fatalf("Expected cast to be %v, received %v", 42, value)
+	}
+}
+
+func TestCastAStringThatIsNotANumberToInt(t *testing.T) {
+	_, err := NewFunctions().Execute("cast", StringValue("report"), StringValue("int"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while casting report to int")
+	}
+}
+
+func TestCastAStringThatIsNotANumberToIntInLenientMode(t *testing.T) {
+	value, err := NewFunctions().InLenientMode().Execute("cast", StringValue("report"), StringValue("int"))
+
+	if err != nil || !value.IsNull() {
+		t.Fatalf("Expected cast to be null in the lenient mode, received %v with error %v", value, err)
+	}
+}
+
+func TestCastAFloatToInt(t *testing.T) {
+	value, _ := NewFunctions().Execute("cast", Float64Value(2.75), StringValue("INT"))
+
+	if value.CompareTo(Int64Value(2)) != CompareToEqual {
+		t.Fatalf("Expected cast to be %v, received %v", 2, value)
+	}
+}
+
+func TestCastABooleanToInt(t *testing.T) {
+	value, _ := NewFunctions().Execute("toint", BooleanValue(true))
+
+	if value.CompareTo(Int64Value(1)) != CompareToEqual {
+		t.Fatalf("Expected toint to be %v, received %v", 1, value)
+	}
+}
+
+func TestCastAStringToFloat(t *testing.T) {
+	value, _ := NewFunctions().Execute("tofloat", StringValue("1.5"))
+
+	if value.CompareTo(Float64Value(1.5)) != CompareToEqual {
+		t.Fatalf("Expected tofloat to be %v, received %v", 1.5, value)
+	}
+}
+
+func TestCastANumberToString(t *testing.T) {
+	value, _ := NewFunctions().Execute("tostring", Float64Value(1.5))
+
+	if value.valueType != ValueTypeString || value.GetAsString() != "1.5" {
+		t.Fatalf("Expected tostring to be the string %v, received %v", "1.5", value)
+	}
+}
+
+func TestCastAStringToBool(t *testing.T) {
+	value, _ := NewFunctions().Execute("cast", StringValue("y"), StringValue("bool"))
+
+	if actualValue, _ := value.GetBoolean(); actualValue != true {
+		t.Fatalf("Expected cast to be %v, received %v", true, value)
+	}
+}
+
+func TestCastAStringThatIsNotABooleanToBool(t *testing.T) {
+	_, err := NewFunctions().Execute("cast", StringValue("yes please"), StringValue("bool"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while casting yes please to bool")
+	}
+}
+
+func TestCastAStringToSize(t *testing.T) {
+	value, _ := NewFunctions().Execute("cast", StringValue("1 KiB"), StringValue("size"))
+
+	if value.CompareTo(Uint64Value(1024)) != CompareToEqual {
+		t.Fatalf("Expected cast to be %v, received %v", 1024, value)
+	}
+}
+
+func TestCastANegativeNumberToSize(t *testing.T) {
+	_, err := NewFunctions().Execute("cast", Int64Value(-1), StringValue("size"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while casting -1 to size")
+	}
+}
+
+func TestCastAStringToDateTime(t *testing.T) {
+	value, _ := NewFunctions().Execute("cast", StringValue("2022-10-18T10:30:00"), StringValue("datetime"))
+	expected := time.Date(2022, 10, 18, 10, 30, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected cast to be %v, received %v", expected, value)
+	}
+}
+
+func TestCastANumberToDateTime(t *testing.T) {
+	value, _ := NewFunctions().Execute("todatetime", Int64Value(86400))
+	expected := time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected todatetime to be %v, received %v", expected, value)
+	}
+}
+
+func TestToDateTimeWithAFormatId(t *testing.T) {
+	value, _ := NewFunctions().Execute("todatetime", StringValue("2022-10-18"), StringValue("dt"))
+	expected := time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected todatetime to be %v, received %v", expected, value)
+	}
+}
+
+func TestToDateTimeWithAnUnsupportedFormatIdInLenientMode(t *testing.T) {
+	_, err := NewFunctions().InLenientMode().Execute("todatetime", StringValue("2022-10-18"), StringValue("unknown"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing todatetime with an unsupported format id in the lenient mode")
+	}
+}
+
+func TestToDateTimeWithAValueNotInTheFormat(t *testing.T) {
+	_, err := NewFunctions().Execute("todatetime", StringValue("18-10-2022"), StringValue("dt"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing todatetime with a value not in the format")
+	}
+}
+
+func TestToDateTimeWithAValueNotInTheFormatInLenientMode(t *testing.T) {
+	value, err := NewFunctions().InLenientMode().Execute("todatetime", StringValue("18-10-2022"), StringValue("dt"))
+
+	if err != nil || !value.IsNull() {
+		t.Fatalf("Expected todatetime to be null in the lenient mode, received %v with error %v", value, err)
+	}
+}
+
+func TestCastANull(t *testing.T) {
+	value, _ := NewFunctions().Execute("toint", NullValue)
+
+	if !value.IsNull() {
+		t.Fatalf("Expected toint of null to be null, received %v", value)
+	}
+}
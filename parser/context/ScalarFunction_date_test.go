@@ -0,0 +1,196 @@
+//go:build unit
+// +build unit
+
+package context
+
+import (
+	"goselect/parser/tokenizer"
+	"testing"
+	"time"
+)
+
+func TestDateAddDays(t *testing.T) {
+	value, _ := NewFunctions().Execute("dateadd", DateTimeValue(time.Date(2022, 10, 3, 11, 48, 5, 0, time.UTC)), Int64Value(-7), StringValue("day"))
+	expected := time.Date(2022, 9, 26, 11, 48, 5, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected dateadd to be %v, received %v", expected, value)
+	}
+}
+
+func TestDateAddHoursWithAPluralUnit(t *testing.T) {
+	value, _ := NewFunctions().Execute("dateadd", DateTimeValue(time.Date(2022, 10, 3, 23, 0, 0, 0, time.UTC)), Int64Value(2), StringValue("Hours"))
+	expected := time.Date(2022, 10, 4, 1, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected dateadd to be %v, received %v", expected, value)
+	}
+}
+
+func TestDateAddAMonthToTheEndOfAMonth(t *testing.T) {
+	value, _ := NewFunctions().Execute("dateadd", DateTimeValue(time.Date(2022, 1, 31, 10, 0, 0, 0, time.UTC)), Int64Value(1), StringValue("month"))
+	expected := time.Date(2022, 2, 28, 10, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected dateadd to be %v, received %v", expected, value)
+	}
+}
+
+func TestDateAddAYearToALeapDay(t *testing.T) {
+	value, _ := NewFunctions().Execute("dateadd", DateTimeValue(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)), Int64Value(-1), StringValue("year"))
+	expected := time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected dateadd to be %v, received %v", expected, value)
+	}
+}
+
+func TestDateAddWithAFractionalAmount(t *testing.T) {
+	_, err := NewFunctions().Execute("dateadd", DateTimeValue(time.Now()), Float64Value(1.5), StringValue("day"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing dateadd with a fractional amount")
+	}
+}
+
+func TestDateAddWithAnUnsupportedUnit(t *testing.T) {
+	_, err := NewFunctions().Execute("dateadd", DateTimeValue(time.Now()), Int64Value(1), StringValue("fortnight"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing dateadd with an unsupported unit")
+	}
+}
+
+func TestDateAddWithMissingParameterValue(t *testing.T) {
+	_, err := NewFunctions().Execute("dateadd", DateTimeValue(time.Now()), Int64Value(1))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing dateadd with a missing parameter value")
+	}
+}
+
+func TestDateTruncToAWeek(t *testing.T) {
+	value, _ := NewFunctions().Execute("datetrunc", DateTimeValue(time.Date(2022, 10, 9, 11, 48, 5, 0, time.UTC)), StringValue("week"))
+	expected := time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected datetrunc to be %v, received %v", expected, value)
+	}
+}
+
+func TestDateTruncToAWeekAcrossAMonth(t *testing.T) {
+	value, _ := NewFunctions().Execute("datetrunc", DateTimeValue(time.Date(2022, 10, 1, 11, 48, 5, 0, time.UTC)), StringValue("week"))
+	expected := time.Date(2022, 9, 26, 0, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected datetrunc to be %v, received %v", expected, value)
+	}
+}
+
+func TestDateTruncToAMonth(t *testing.T) {
+	value, _ := NewFunctions().Execute("datetrunc", DateTimeValue(time.Date(2022, 10, 18, 11, 48, 5, 0, time.UTC)), StringValue("month"))
+	expected := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected datetrunc to be %v, received %v", expected, value)
+	}
+}
+
+func TestDateTruncToAYear(t *testing.T) {
+	value, _ := NewFunctions().Execute("datetrunc", DateTimeValue(time.Date(2022, 10, 18, 11, 48, 5, 0, time.UTC)), StringValue("year"))
+	expected := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected datetrunc to be %v, received %v", expected, value)
+	}
+}
+
+func TestDateTruncWithANonDateTime(t *testing.T) {
+	_, err := NewFunctions().Execute("datetrunc", StringValue("2022-10-18"), StringValue("month"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing datetrunc with a value that is not a date/time")
+	}
+}
+
+func TestFormatDateTime(t *testing.T) {
+	value, _ := NewFunctions().Execute("formatdatetime", DateTimeValue(time.Date(2022, 10, 3, 11, 48, 5, 0, time.UTC)), StringValue("%Y-%m"))
+
+	if value.GetAsString() != "2022-10" {
+		t.Fatalf("Expected formatdatetime to be %v, received %v", "2022-10", value)
+	}
+}
+
+func TestFormatDateTimeWithAnUnsupportedPattern(t *testing.T) {
+	_, err := NewFunctions().Execute("formatdatetime", DateTimeValue(time.Now()), StringValue("%Q"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing formatdatetime with an unsupported pattern")
+	}
+}
+
+func TestUnixTime(t *testing.T) {
+	value, _ := NewFunctions().Execute("unixtime", DateTimeValue(time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)))
+
+	if value.CompareTo(Int64Value(1666051200)) != CompareToEqual {
+		t.Fatalf("Expected unixtime to be %v, received %v", 1666051200, value)
+	}
+}
+
+func TestFromUnixTime(t *testing.T) {
+	value, _ := NewFunctions().Execute("fromunixtime", Int64Value(1666051200))
+	expected := time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected fromunixtime to be %v, received %v", expected, value)
+	}
+}
+
+func TestFromUnixTimeWithANonNumericValue(t *testing.T) {
+	_, err := NewFunctions().Execute("fromunixtime", StringValue("yesterday"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing fromunixtime with a non-numeric value")
+	}
+}
+
+func TestFormatDurationDownToHours(t *testing.T) {
+	value, _ := NewFunctions().Execute("formatduration", DurationValue(3*24*time.Hour+4*time.Hour+12*time.Minute), StringValue("hour"))
+
+	if value.GetAsString() != "3d 4h" {
+		t.Fatalf("Expected formatduration to be %v, received %v", "3d 4h", value)
+	}
+}
+
+func TestFormatDurationWithAnUnsupportedUnit(t *testing.T) {
+	_, err := NewFunctions().Execute("formatduration", DurationValue(time.Hour), StringValue("month"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing formatduration with an unsupported unit")
+	}
+}
+
+func TestFormatDurationWithANonDuration(t *testing.T) {
+	_, err := NewFunctions().Execute("formatduration", Int64Value(3600))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing formatduration with a value that is not a duration")
+	}
+}
+
+func TestGreaterThanWithANumberAndADuration(t *testing.T) {
+	duration, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(tokenizer.NewToken(tokenizer.RawString, "2m"))
+	_, err := NewFunctions().Execute("gt", Uint64Value(200), duration)
+
+	if err == nil {
+		t.Fatalf("Expected an error while comparing a number with a duration")
+	}
+}
+
+func TestInWithADurationAndNumbers(t *testing.T) {
+	_, err := NewFunctions().Execute("in", DurationValue(time.Minute), StringValue("1m"), Int64Value(60))
+
+	if err == nil {
+		t.Fatalf("Expected an error while comparing a duration with a number")
+	}
+}
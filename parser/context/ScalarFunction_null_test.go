@@ -0,0 +1,133 @@
+//go:build unit
+// +build unit
+
+package context
+
+import (
+	"testing"
+)
+
+func TestFunctionsPropagateNull(t *testing.T) {
+	value, err := NewFunctions().Execute("eq", NullValue, StringValue("a"))
+
+	if err != nil || !value.IsNull() {
+		t.Fatalf("Expected eq with null to be null, received %v with error %v", value, err)
+	}
+}
+
+func TestIsNull(t *testing.T) {
+	value, _ := NewFunctions().Execute("isnull", NullValue)
+
+	actualValue, _ := value.GetBoolean()
+	if actualValue != true {
+		t.Fatalf("Expected isnull to be %v, received %v", true, actualValue)
+	}
+}
+
+func TestIsNullForABlankString(t *testing.T) {
+	value, _ := NewFunctions().Execute("isnull", StringValue(""))
+
+	actualValue, _ := value.GetBoolean()
+	if actualValue != false {
+		t.Fatalf("Expected isnull to be %v, received %v", false, actualValue)
+	}
+}
+
+func TestIsNotNull(t *testing.T) {
+	value, _ := NewFunctions().Execute("isnotnull", StringValue("a"))
+
+	actualValue, _ := value.GetBoolean()
+	if actualValue != true {
+		t.Fatalf("Expected isnotnull to be %v, received %v", true, actualValue)
+	}
+}
+
+func TestIsNullWithMissingParameterValue(t *testing.T) {
+	_, err := NewFunctions().Execute("isnull")
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing isnull with a missing parameter value")
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	value, _ := NewFunctions().Execute("coalesce", NullValue, NullValue, StringValue("a"), StringValue("b"))
+
+	if value.GetAsString() != "a" {
+		t.Fatalf("Expected coalesce to be %v, received %v", "a", value.GetAsString())
+	}
+}
+
+func TestCoalesceWithAllNulls(t *testing.T) {
+	value, _ := NewFunctions().Execute("coalesce", NullValue, NullValue)
+
+	if !value.IsNull() {
+		t.Fatalf("Expected coalesce to be null, received %v", value)
+	}
+}
+
+func TestNullIf(t *testing.T) {
+	value, _ := NewFunctions().Execute("nullif", StringValue("a"), StringValue("a"))
+
+	if !value.IsNull() {
+		t.Fatalf("Expected nullif to be null, received %v", value)
+	}
+}
+
+func TestNullIfWithDifferentValues(t *testing.T) {
+	value, _ := NewFunctions().Execute("nullif", StringValue("a"), StringValue("b"))
+
+	if value.GetAsString() != "a" {
+		t.Fatalf("Expected nullif to be %v, received %v", "a", value.GetAsString())
+	}
+}
+
+func TestAndWithNull(t *testing.T) {
+	functions := NewFunctions()
+
+	value, _ := functions.Execute("and", BooleanValue(true), NullValue)
+	if !value.IsNull() {
+		t.Fatalf("Expected and(true, null) to be null, received %v", value)
+	}
+	value, _ = functions.Execute("and", NullValue, BooleanValue(false))
+	if actualValue, _ := value.GetBoolean(); value.IsNull() || actualValue != false {
+		t.Fatalf("Expected and(null, false) to be false, received %v", value)
+	}
+}
+
+func TestOrWithNull(t *testing.T) {
+	functions := NewFunctions()
+
+	value, _ := functions.Execute("or", BooleanValue(false), NullValue)
+	if !value.IsNull() {
+		t.Fatalf("Expected or(false, null) to be null, received %v", value)
+	}
+	value, _ = functions.Execute("or", NullValue, BooleanValue(true))
+	if actualValue, _ := value.GetBoolean(); actualValue != true {
+		t.Fatalf("Expected or(null, true) to be true, received %v", value)
+	}
+}
+
+func TestNotWithNull(t *testing.T) {
+	value, _ := NewFunctions().Execute("not", NullValue)
+
+	if !value.IsNull() {
+		t.Fatalf("Expected not(null) to be null, received %v", value)
+	}
+}
+
+func TestIfWithANullCondition(t *testing.T) {
+	value, _ := NewFunctions().Execute("if", NullValue, StringValue("a"), StringValue("b"))
+
+	if value.GetAsString() != "b" {
+		t.Fatalf("Expected if with a null condition to be %v, received %v", "b", value.GetAsString())
+	}
+}
+
+func TestIfBlankWithNull(t *testing.T) {
+	value, _ := NewFunctions().Execute("ifblank", NullValue, StringValue("NA"))
+
+	if value.GetAsString() != "NA" {
+		t.Fatalf("Expected ifblank with null to be %v, received %v", "NA", value.GetAsString())
+	}
+}
@@ -0,0 +1,146 @@
+//go:build unit
+// +build unit
+
+package context
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRegexMatchWithMissingParameterValue(t *testing.T) {
+	_, err := NewFunctions().Execute("regexmatch", StringValue("sample.log"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing regexmatch with a missing parameter value")
+	}
+}
+
+func TestRegexMatchWithInvalidRegex(t *testing.T) {
+	_, err := NewFunctions().Execute("regexmatch", StringValue("name"), StringValue("*"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing regexmatch with invalid regex")
+	}
+}
+
+func TestRegexMatch1(t *testing.T) {
+	value, _ := NewFunctions().Execute("regexmatch", StringValue("app-1.2.3.tar.gz"), StringValue("^app-[0-9]+"))
+
+	actualValue, _ := value.GetBoolean()
+	if actualValue != true {
+		t.Fatalf("Expected regexmatch to be %v, received %v", true, actualValue)
+	}
+}
+
+func TestRegexMatch2(t *testing.T) {
+	value, _ := NewFunctions().Execute("rmatch", StringValue("app.tar.gz"), StringValue("^app-[0-9]+"))
+
+	actualValue, _ := value.GetBoolean()
+	if actualValue != false {
+		t.Fatalf("Expected regexmatch to be %v, received %v", false, actualValue)
+	}
+}
+
+func TestRegexExtractTheWholeMatch(t *testing.T) {
+	value, _ := NewFunctions().Execute("regexextract", StringValue("app-1.2.3.tar.gz"), StringValue("[0-9]+[.][0-9]+[.][0-9]+"))
+	expected := "1.2.3"
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected regexextract to be %v, received %v", expected, actualValue)
+	}
+}
+
+func TestRegexExtractAGroupByIndex(t *testing.T) {
+	value, _ := NewFunctions().Execute("regexextract", StringValue("app-1.2.3.tar.gz"), StringValue("app-([0-9.]+)[.]tar"), IntValue(1))
+	expected := "1.2.3"
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected regexextract to be %v, received %v", expected, actualValue)
+	}
+}
+
+func TestRegexExtractANamedGroup(t *testing.T) {
+	value, _ := NewFunctions().Execute("rextract", StringValue("report-2022-10.pdf"), StringValue("(?P<year>[0-9]{4})-(?P<month>[0-9]{2})"), StringValue("month"))
+	expected := "10"
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected regexextract to be %v, received %v", expected, actualValue)
+	}
+}
+
+func TestRegexExtractWithoutAMatch(t *testing.T) {
+	value, _ := NewFunctions().Execute("regexextract", StringValue("README.md"), StringValue("([0-9]+)"), IntValue(1))
+
+	actualValue := value.GetAsString()
+	if actualValue != "" {
+		t.Fatalf("Expected regexextract to be blank, received %v", actualValue)
+	}
+}
+
+func TestRegexExtractWithAGroupIndexOutOfRange(t *testing.T) {
+	_, err := NewFunctions().Execute("regexextract", StringValue("app-1.2.3"), StringValue("app-([0-9.]+)"), IntValue(2))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing regexextract with a group index out of range")
+	}
+}
+
+func TestRegexExtractWithAnUnknownGroupName(t *testing.T) {
+	_, err := NewFunctions().Execute("regexextract", StringValue("app-1.2.3"), StringValue("app-(?P<version>[0-9.]+)"), StringValue("release"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing regexextract with an unknown group name")
+	}
+}
+
+func TestRegexReplaceWithBackReferences(t *testing.T) {
+	value, _ := NewFunctions().Execute("regexreplace", StringValue("app-42.log"), StringValue("([a-z]+)-([0-9]+)"), StringValue("$2-$1"))
+	expected := "42-app.log"
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected regexreplace to be %v, received %v", expected, actualValue)
+	}
+}
+
+func TestRegexReplaceWithANamedBackReference(t *testing.T) {
+	value, _ := NewFunctions().Execute("rreplace", StringValue("app-42.log"), StringValue("-(?P<build>[0-9]+)"), StringValue("_v${build}"))
+	expected := "app_v42.log"
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected regexreplace to be %v, received %v", expected, actualValue)
+	}
+}
+
+func TestRegexReplaceAllTheMatches(t *testing.T) {
+	value, _ := NewFunctions().Execute("regexreplace", StringValue("a1b22c333"), StringValue("[0-9]+"), StringValue("#"))
+	expected := "a#b#c#"
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected regexreplace to be %v, received %v", expected, actualValue)
+	}
+}
+
+func TestRegexFunctionsShareTheCompiledPattern(t *testing.T) {
+	cache := NewFunctionExecutionCache()
+	pattern := StringValue("app-([0-9]+)")
+
+	_, _ = RegexMatchFunctionBlock{executionCache: cache}.run(StringValue("app-42"), pattern)
+	cached, ok := cache.Get(pattern)
+	if !ok {
+		t.Fatalf("Expected the compiled pattern %v to be cached", pattern.GetAsString())
+	}
+	value, _ := RegexExtractFunctionBlock{executionCache: cache}.run(StringValue("app-42"), pattern, IntValue(1))
+	if value.GetAsString() != "42" {
+		t.Fatalf("Expected regexextract to be %v, received %v", "42", value.GetAsString())
+	}
+	if again, _ := cache.Get(pattern); again.(*regexp.Regexp) != cached.(*regexp.Regexp) {
+		t.Fatalf("Expected the compiled pattern %v to be reused", pattern.GetAsString())
+	}
+}
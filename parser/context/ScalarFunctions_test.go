@@ -4,7 +4,7 @@
 package context
 
 import (
-	"math"
+	"goselect/parser/tokenizer"
 	"os"
 	"testing"
 	"time"
@@ -174,6 +174,57 @@ func TestEqualsWithMissingParameterValue(t *testing.T) {
 	}
 }
 
+func TestInWithMissingParameterValue(t *testing.T) {
+	_, err := NewFunctions().Execute("in", StringValue(".go"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing in with a single parameter value")
+	}
+}
+
+func TestInReturningTrue(t *testing.T) {
+	value, _ := NewFunctions().Execute("in", StringValue(".mod"), StringValue(".go"), StringValue(".mod"), StringValue(".sum"))
+
+	actualValue, _ := value.GetBoolean()
+	if actualValue != true {
+		t.Fatalf("Expected in to be %v, received %v", true, actualValue)
+	}
+}
+
+func TestInReturningFalse(t *testing.T) {
+	value, _ := NewFunctions().Execute("in", StringValue(".txt"), StringValue(".go"), StringValue(".mod"))
+
+	actualValue, _ := value.GetBoolean()
+	if actualValue != false {
+		t.Fatalf("Expected in to be %v, received %v", false, actualValue)
+	}
+}
+
+func TestInReturningTrueGivenAMatchAndANull(t *testing.T) {
+	value, _ := NewFunctions().Execute("in", Int64Value(1), Int64Value(1), NullValue)
+
+	actualValue, _ := value.GetBoolean()
+	if actualValue != true {
+		t.Fatalf("Expected in to be %v, received %v", true, actualValue)
+	}
+}
+
+func TestInReturningNullGivenANullAndNoMatch(t *testing.T) {
+	value, _ := NewFunctions().Execute("in", Int64Value(2), Int64Value(1), NullValue)
+
+	if !value.IsNull() {
+		t.Fatalf("Expected in to be null, received %v", value)
+	}
+}
+
+func TestInReturningNullGivenANullValue(t *testing.T) {
+	value, _ := NewFunctions().Execute("in", NullValue, Int64Value(1), Int64Value(2))
+
+	if !value.IsNull() {
+		t.Fatalf("Expected in to be null, received %v", value)
+	}
+}
+
 func TestEqualsReturningTrue(t *testing.T) {
 	value, _ := NewFunctions().Execute("eq", StringValue("one"), StringValue("one"))
 
@@ -664,27 +715,43 @@ func TestTrimWithoutAnyParameter(t *testing.T) {
 	}
 }
 
-func TestNow(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
+func functionsAt(aTime time.Time) *AllFunctions {
+	functions := NewFunctions()
+	functions.timeContext.clock = func() time.Time {
+		return aTime
 	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
-	value, _ := NewFunctions().Execute("now")
-	expected := nowFunc()
+	return functions
+}
+
+func TestNow(t *testing.T) {
+	functions := functionsAt(time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC))
+	value, _ := functions.Execute("now")
+	expected := time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
 
 	if !value.timeValue.Equal(expected) {
 		t.Fatalf("Expected now to return %v, received %v", expected, value.timeValue)
 	}
 }
 
-func TestNowAsString(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
+func TestNowIsTheSameInstantThroughoutAQuery(t *testing.T) {
+	functions := NewFunctions()
+	ticks := time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
+	functions.timeContext.clock = func() time.Time {
+		ticks = ticks.Add(time.Hour)
+		return ticks
+	}
+	first, _ := functions.Execute("now")
+	second, _ := functions.Execute("now")
+	value, _ := NewContext(functions, NewAttributes()).ToValue(tokenizer.NewToken(tokenizer.RawString, "0 seconds ago"))
+
+	if first.CompareTo(second) != CompareToEqual || first.CompareTo(value) != CompareToEqual {
+		t.Fatalf("Expected now and 0 seconds ago to be the same instant, received %v, %v and %v", first, second, value)
 	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
-	value, _ := NewFunctions().Execute("now")
+}
+
+func TestNowAsString(t *testing.T) {
+	functions := functionsAt(time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC))
+	value, _ := functions.Execute("now")
 	expected := "2022-08-22 15:08:00 +0000 UTC"
 
 	if value.GetAsString() != expected {
@@ -693,13 +760,9 @@ func TestNowAsString(t *testing.T) {
 }
 
 func TestDay(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("cday")
+	value, _ := functions.Execute("cday")
 	expected := 22
 
 	actualValue, _ := value.GetInt()
@@ -709,13 +772,9 @@ func TestDay(t *testing.T) {
 }
 
 func TestDate(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("cdate")
+	value, _ := functions.Execute("cdate")
 	expected := "2022-August-22"
 
 	actualValue := value.GetAsString()
@@ -725,13 +784,9 @@ func TestDate(t *testing.T) {
 }
 
 func TestMonth1(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("cmonth")
+	value, _ := functions.Execute("cmonth")
 	expected := "August"
 
 	actualValue := value.GetAsString()
@@ -741,13 +796,9 @@ func TestMonth1(t *testing.T) {
 }
 
 func TestMonth2(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("cmon")
+	value, _ := functions.Execute("cmon")
 	expected := "August"
 
 	actualValue := value.GetAsString()
@@ -757,13 +808,9 @@ func TestMonth2(t *testing.T) {
 }
 
 func TestYear1(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("cyear")
+	value, _ := functions.Execute("cyear")
 	expected := 2022
 
 	actualValue, _ := value.GetInt()
@@ -773,13 +820,9 @@ func TestYear1(t *testing.T) {
 }
 
 func TestYear2(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 22, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("cyr")
+	value, _ := functions.Execute("cyr")
 	expected := 2022
 
 	actualValue, _ := value.GetInt()
@@ -789,13 +832,9 @@ func TestYear2(t *testing.T) {
 }
 
 func TestDayOfWeek1(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("dayOfWeek")
+	value, _ := functions.Execute("dayOfWeek")
 	expected := "Sunday"
 
 	actualValue := value.GetAsString()
@@ -805,13 +844,9 @@ func TestDayOfWeek1(t *testing.T) {
 }
 
 func TestDayOfWeek2(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("dayofweek")
+	value, _ := functions.Execute("dayofweek")
 	expected := "Sunday"
 
 	actualValue := value.GetAsString()
@@ -837,7 +872,7 @@ func TestExtractWithIllegalParameterValue(t *testing.T) {
 }
 
 func TestExtractWithInvalidExtractionKey(t *testing.T) {
-	_, err := NewFunctions().Execute("extract", DateTimeValue(now()), StringValue("unknown"))
+	_, err := NewFunctions().Execute("extract", DateTimeValue(time.Now()), StringValue("unknown"))
 
 	if err == nil {
 		t.Fatalf("Expected an error while executing extract with invalid extraction key")
@@ -845,13 +880,9 @@ func TestExtractWithInvalidExtractionKey(t *testing.T) {
 }
 
 func TestExtractDay(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("extract", DateTimeValue(now()), StringValue("day"))
+	value, _ := functions.Execute("extract", DateTimeValue(functions.timeContext.now()), StringValue("day"))
 	expected := "28"
 
 	actualValue := value.GetAsString()
@@ -861,13 +892,9 @@ func TestExtractDay(t *testing.T) {
 }
 
 func TestExtractYear(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("extract", DateTimeValue(now()), StringValue("year"))
+	value, _ := functions.Execute("extract", DateTimeValue(functions.timeContext.now()), StringValue("year"))
 	expected := "2022"
 
 	actualValue := value.GetAsString()
@@ -877,13 +904,9 @@ func TestExtractYear(t *testing.T) {
 }
 
 func TestExtractMonth(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("extract", DateTimeValue(now()), StringValue("month"))
+	value, _ := functions.Execute("extract", DateTimeValue(functions.timeContext.now()), StringValue("month"))
 	expected := "August"
 
 	actualValue := value.GetAsString()
@@ -893,13 +916,9 @@ func TestExtractMonth(t *testing.T) {
 }
 
 func TestExtractWeekDay(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("extract", DateTimeValue(now()), StringValue("weekday"))
+	value, _ := functions.Execute("extract", DateTimeValue(functions.timeContext.now()), StringValue("weekday"))
 	expected := "Sunday"
 
 	actualValue := value.GetAsString()
@@ -909,13 +928,9 @@ func TestExtractWeekDay(t *testing.T) {
 }
 
 func TestExtractDate(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("extract", DateTimeValue(now()), StringValue("date"))
+	value, _ := functions.Execute("extract", DateTimeValue(functions.timeContext.now()), StringValue("date"))
 	expected := "2022-August-28"
 
 	actualValue := value.GetAsString()
@@ -925,13 +940,9 @@ func TestExtractDate(t *testing.T) {
 }
 
 func TestFormatDate1(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 5, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 5, 15, 8, 00, 0, time.UTC))
 
-	value := formatDate(now())
+	value := formatDate(functions.timeContext.now())
 	expected := "2022-August-05"
 
 	if value.GetAsString() != expected {
@@ -940,13 +951,9 @@ func TestFormatDate1(t *testing.T) {
 }
 
 func TestFormatDate2(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 26, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 26, 15, 8, 00, 0, time.UTC))
 
-	value := formatDate(now())
+	value := formatDate(functions.timeContext.now())
 	expected := "2022-August-26"
 
 	if value.GetAsString() != expected {
@@ -971,7 +978,7 @@ func TestHoursDifferenceWithIllegalParameterValue1(t *testing.T) {
 }
 
 func TestHoursDifferenceWithIllegalParameterValue2(t *testing.T) {
-	_, err := NewFunctions().Execute("hoursdiff", DateTimeValue(now()), IntValue(5))
+	_, err := NewFunctions().Execute("hoursdiff", DateTimeValue(time.Now()), IntValue(5))
 
 	if err == nil {
 		t.Fatalf("Expected an error while executing hoursdiff with illegal parameter value")
@@ -979,18 +986,14 @@ func TestHoursDifferenceWithIllegalParameterValue2(t *testing.T) {
 }
 
 func TestHoursDifference1(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("hoursdiff",
+	value, _ := functions.Execute("hoursdiff",
 		DateTimeValue(
 			time.Date(2022, 8, 28, 14, 8, 00, 0, time.UTC),
 		),
 		DateTimeValue(
-			now(),
+			functions.timeContext.now(),
 		),
 	)
 	expected := 1.00
@@ -1002,18 +1005,14 @@ func TestHoursDifference1(t *testing.T) {
 }
 
 func TestHoursDifference2(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 15, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 15, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("hoursdiff",
+	value, _ := functions.Execute("hoursdiff",
 		DateTimeValue(
 			time.Date(2022, 8, 28, 12, 45, 00, 0, time.UTC),
 		),
 		DateTimeValue(
-			now(),
+			functions.timeContext.now(),
 		),
 	)
 	expected := 2.5
@@ -1041,7 +1040,7 @@ func TestDaysDifferenceWithIllegalParameterValue1(t *testing.T) {
 }
 
 func TestDaysDifferenceWithIllegalParameterValue2(t *testing.T) {
-	_, err := NewFunctions().Execute("daysdiff", DateTimeValue(now()), IntValue(5))
+	_, err := NewFunctions().Execute("daysdiff", DateTimeValue(time.Now()), IntValue(5))
 
 	if err == nil {
 		t.Fatalf("Expected an error while executing daysdiff with illegal parameter value")
@@ -1049,18 +1048,14 @@ func TestDaysDifferenceWithIllegalParameterValue2(t *testing.T) {
 }
 
 func TestDaysDifference1(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 28, 15, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("daysdiff",
+	value, _ := functions.Execute("daysdiff",
 		DateTimeValue(
 			time.Date(2022, 8, 24, 15, 8, 00, 0, time.UTC),
 		),
 		DateTimeValue(
-			now(),
+			functions.timeContext.now(),
 		),
 	)
 	expected := 4.00
@@ -1072,18 +1067,14 @@ func TestDaysDifference1(t *testing.T) {
 }
 
 func TestDaysDifference2(t *testing.T) {
-	nowFunc = func() time.Time {
-		return time.Date(2022, 8, 24, 18, 8, 00, 0, time.UTC)
-	}
-	// after finish with the test, reset the time implementation
-	defer resetClock()
+	functions := functionsAt(time.Date(2022, 8, 24, 18, 8, 00, 0, time.UTC))
 
-	value, _ := NewFunctions().Execute("daysdiff",
+	value, _ := functions.Execute("daysdiff",
 		DateTimeValue(
 			time.Date(2022, 8, 24, 15, 8, 00, 0, time.UTC),
 		),
 		DateTimeValue(
-			now(),
+			functions.timeContext.now(),
 		),
 	)
 
@@ -1094,14 +1085,15 @@ func TestDaysDifference2(t *testing.T) {
 }
 
 func TestDaysDifference3(t *testing.T) {
-	value, _ := NewFunctions().Execute("daysdiff",
-		DateTimeValue(now()),
-		DateTimeValue(now()),
+	functions := NewFunctions()
+	value, _ := functions.Execute("daysdiff",
+		DateTimeValue(functions.timeContext.now()),
+		DateTimeValue(functions.timeContext.now()),
 	)
 
 	actualValue, _ := value.GetNumericAsFloat64()
-	if math.Round(actualValue) != 0 {
-		t.Fatalf("Expected days difference to be 0 but received %v, and round resulted in %v", actualValue, math.Round(actualValue))
+	if actualValue != 0 {
+		t.Fatalf("Expected days difference to be 0 but received %v", actualValue)
 	}
 }
 
@@ -1588,6 +1580,42 @@ func TestIfBlank3(t *testing.T) {
 	}
 }
 
+func TestIf1(t *testing.T) {
+	value, _ := NewFunctions().Execute("if", BooleanValue(true), StringValue("large"), StringValue("small"))
+	expected := "large"
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected if to be %v, received %v", expected, actualValue)
+	}
+}
+
+func TestIf2(t *testing.T) {
+	value, _ := NewFunctions().Execute("if", StringValue("false"), StringValue("large"), StringValue("small"))
+	expected := "small"
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected if to be %v, received %v", expected, actualValue)
+	}
+}
+
+func TestIfWithANonBooleanCondition(t *testing.T) {
+	_, err := NewFunctions().Execute("if", StringValue("large"), StringValue("large"), StringValue("small"))
+
+	if err == nil {
+		t.Fatalf("Expected an error given if with a non boolean condition")
+	}
+}
+
+func TestIfWithMissingParameters(t *testing.T) {
+	_, err := NewFunctions().Execute("if", BooleanValue(true), StringValue("large"))
+
+	if err == nil {
+		t.Fatalf("Expected an error given if with missing parameters")
+	}
+}
+
 func TestIfBlankWithMissingParameterValue(t *testing.T) {
 	_, err := NewFunctions().Execute("ifBlank")
 
@@ -1789,3 +1817,23 @@ func TestIsArchive3(t *testing.T) {
 		t.Fatalf("Expected an error while invoking isArchive without any parameter values")
 	}
 }
+
+func TestDirName(t *testing.T) {
+	value, _ := NewFunctions().Execute("dirname", StringValue("logs/archive/app.log"))
+	expected := "logs/archive"
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected dirname to be %v, received %v", expected, actualValue)
+	}
+}
+
+func TestDirNameWithoutADirectory(t *testing.T) {
+	value, _ := NewFunctions().Execute("dname", StringValue("app.log"))
+	expected := "."
+
+	actualValue := value.GetAsString()
+	if actualValue != expected {
+		t.Fatalf("Expected dirname to be %v, received %v", expected, actualValue)
+	}
+}
@@ -0,0 +1,112 @@
+package context
+
+import (
+	"sort"
+	"strings"
+)
+
+const maxSuggestions = 3
+
+func (attributes *AllAttributes) SuggestionsFor(term string) []string {
+	aliases := make([]string, 0, len(attributes.supportedAttributes))
+	for alias := range attributes.supportedAttributes {
+		aliases = append(aliases, alias)
+	}
+	return closestTo(term, aliases)
+}
+
+func (functions *AllFunctions) SuggestionsFor(term string) []string {
+	aliases := make([]string, 0, len(functions.supportedFunctions))
+	for alias := range functions.supportedFunctions {
+		aliases = append(aliases, alias)
+	}
+	return closestTo(term, aliases)
+}
+
+func (context *ParsingApplicationContext) SuggestionsFor(term string) []string {
+	if context.IsASupportedAttribute(term) || context.IsASupportedFunction(term) {
+		return nil
+	}
+	qualifier, attribute, ok := QualifiedAttribute(term)
+	if ok && context.sourceAliases[strings.ToLower(qualifier)] {
+		var suggestions []string
+		for _, suggestion := range context.allAttributes.SuggestionsFor(attribute) {
+			suggestions = append(suggestions, qualifier+"."+suggestion)
+		}
+		return suggestions
+	}
+	return closestTo(term, append(context.allAttributes.SuggestionsFor(term), context.allFunctions.SuggestionsFor(term)...))
+}
+
+/*
+distance:    the number of insertions, deletions, substitutions and adjacent transpositions between the two terms
+suggestions: the candidates at the smallest distance, provided it is at most 1 for terms up to 4 characters and 2 otherwise
+*/
+func closestTo(term string, candidates []string) []string {
+	term = strings.ToLower(term)
+	if len(term) < 3 {
+		return nil
+	}
+	maxDistance := 2
+	if len(term) <= 4 {
+		maxDistance = 1
+	}
+	var suggestions []string
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		distance := distanceBetween(term, candidate)
+		if distance > maxDistance {
+			continue
+		}
+		if distance < maxDistance {
+			maxDistance = distance
+			suggestions = nil
+		}
+		suggestions = append(suggestions, candidate)
+	}
+	sort.Strings(suggestions)
+	if len(suggestions) > maxSuggestions {
+		return suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+func distanceBetween(one string, other string) int {
+	first, second := []rune(one), []rune(other)
+	distances := make([][]int, len(first)+1)
+	for row := range distances {
+		distances[row] = make([]int, len(second)+1)
+		distances[row][0] = row
+	}
+	for column := range distances[0] {
+		distances[0][column] = column
+	}
+	for row := 1; row <= len(first); row++ {
+		for column := 1; column <= len(second); column++ {
+			cost := 1
+			if first[row-1] == second[column-1] {
+				cost = 0
+			}
+			distance := minOf(distances[row-1][column]+1, distances[row][column-1]+1, distances[row-1][column-1]+cost)
+			if row > 1 && column > 1 && first[row-1] == second[column-2] && first[row-2] == second[column-1] {
+				distance = minOf(distance, distances[row-2][column-2]+1)
+			}
+			distances[row][column] = distance
+		}
+	}
+	return distances[len(first)][len(second)]
+}
+
+func minOf(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+//go:build unit
+// +build unit
+
+package context
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggestionsForAMisspelledAttribute(t *testing.T) {
+	context := NewContext(NewFunctions(), NewAttributes())
+	suggestions := context.SuggestionsFor("nmae")
+
+	expected := []string{"name"}
+	if !reflect.DeepEqual(expected, suggestions) {
+		t.Fatalf("Expected suggestions to be %v, received %v", expected, suggestions)
+	}
+}
+
+func TestSuggestionsForAMisspelledFunction(t *testing.T) {
+	context := NewContext(NewFunctions(), NewAttributes())
+	suggestions := context.SuggestionsFor("concta")
+
+	expected := []string{"concat"}
+	if !reflect.DeepEqual(expected, suggestions) {
+		t.Fatalf("Expected suggestions to be %v, received %v", expected, suggestions)
+	}
+}
+
+func TestSuggestionsForAMisspelledQualifiedAttribute(t *testing.T) {
+	context := NewContext(NewFunctions(), NewAttributes()).WithSourceAliases([]string{"a"})
+	suggestions := context.SuggestionsFor("a.nmae")
+
+	expected := []string{"a.name"}
+	if !reflect.DeepEqual(expected, suggestions) {
+		t.Fatalf("Expected suggestions to be %v, received %v", expected, suggestions)
+	}
+}
+
+func TestNoSuggestionsForASupportedAttribute(t *testing.T) {
+	context := NewContext(NewFunctions(), NewAttributes())
+	suggestions := context.SuggestionsFor("name")
+
+	if len(suggestions) != 0 {
+		t.Fatalf("Expected no suggestions for a supported attribute, received %v", suggestions)
+	}
+}
+
+func TestNoSuggestionsForAnUnrelatedTerm(t *testing.T) {
+	context := NewContext(NewFunctions(), NewAttributes())
+	suggestions := context.SuggestionsFor("resources")
+
+	if len(suggestions) != 0 {
+		t.Fatalf("Expected no suggestions for an unrelated term, received %v", suggestions)
+	}
+}
+
+func TestDistanceCountsATranspositionAsOneEdit(t *testing.T) {
+	distance := distanceBetween("nmae", "name")
+
+	if distance != 1 {
+		t.Fatalf("Expected distance to be %v, received %v", 1, distance)
+	}
+}
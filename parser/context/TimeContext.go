@@ -0,0 +1,22 @@
+package context
+
+import "time"
+
+type TimeContext struct {
+	formats  DateTimeFormats
+	location *time.Location
+	clock    func() time.Time
+	instant  time.Time
+}
+
+func newTimeContext() *TimeContext {
+	return &TimeContext{formats: formatDefinitions.copy(), location: time.UTC, clock: time.Now}
+}
+
+// now is the instant the query started at, the clock is read once so that now(), age and the relative time literals agree across all the files.
+func (timeContext *TimeContext) now() time.Time {
+	if timeContext.instant.IsZero() {
+		timeContext.instant = timeContext.clock()
+	}
+	return timeContext.instant.In(timeContext.location)
+}
@@ -0,0 +1,138 @@
+package context
+
+import (
+	"fmt"
+	"goselect/parser/error/messages"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	relativeDateTimeRegexp = regexp.MustCompile("^(?i)([-+]?[0-9]+)\\s+([a-z]+)\\s+ago$")
+	startOfRegexp          = regexp.MustCompile("^(?i)start\\s+of\\s+([a-z]+)$")
+	durationLiteralRegexp  = regexp.MustCompile("^(?i)-?(?:[0-9]+[wdhms])+$")
+	durationPartRegexp     = regexp.MustCompile("(?i)([0-9]+)([wdhms])")
+)
+
+var durationsByUnit = map[string]time.Duration{
+	"w": 7 * 24 * time.Hour,
+	"d": 24 * time.Hour,
+	"h": time.Hour,
+	"m": time.Minute,
+	"s": time.Second,
+}
+
+var durationUnits = []string{DateTimeUnitSecond, DateTimeUnitMinute, DateTimeUnitHour, DateTimeUnitDay}
+
+var durationsByDateTimeUnit = map[string]time.Duration{
+	DateTimeUnitSecond: time.Second,
+	DateTimeUnitMinute: time.Minute,
+	DateTimeUnitHour:   time.Hour,
+	DateTimeUnitDay:    24 * time.Hour,
+}
+
+/*
+literal: an unquoted duration or relative time is a duration or a date/time value, a quoted one remains a string
+*/
+func timeLiteralOf(str string, now time.Time) (Value, bool) {
+	if duration, ok := durationOf(str); ok {
+		return DurationValue(duration), true
+	}
+	if aTime, ok := relativeDateTimeOf(str, now); ok {
+		return DateTimeValue(aTime), true
+	}
+	return EmptyValue, false
+}
+
+func IsATimeLiteral(str string) bool {
+	_, ok := timeLiteralOf(str, time.Now())
+	return ok
+}
+
+/*
+relative:  <number> <unit> ago, like 7 days ago or 2 hours ago
+start of:  start of <unit>, like start of month or start of week
+named:     today, yesterday and tomorrow, the start of the day
+*/
+func relativeDateTimeOf(str string, now time.Time) (time.Time, bool) {
+	switch strings.ToLower(str) {
+	case "today":
+		return truncateDateTime(now, DateTimeUnitDay), true
+	case "yesterday":
+		return truncateDateTime(now, DateTimeUnitDay).AddDate(0, 0, -1), true
+	case "tomorrow":
+		return truncateDateTime(now, DateTimeUnitDay).AddDate(0, 0, 1), true
+	}
+	if groups := relativeDateTimeRegexp.FindStringSubmatch(str); groups != nil {
+		amount, err := strconv.Atoi(groups[1])
+		unit, unitErr := dateTimeUnitOf(StringValue(groups[2]))
+		if err != nil || unitErr != nil {
+			return time.Time{}, false
+		}
+		return addToDateTime(now, -amount, unit), true
+	}
+	if groups := startOfRegexp.FindStringSubmatch(str); groups != nil {
+		unit, err := dateTimeUnitOf(StringValue(groups[1]))
+		if err != nil {
+			return time.Time{}, false
+		}
+		return truncateDateTime(now, unit), true
+	}
+	return time.Time{}, false
+}
+
+/*
+duration: a number followed by w, d, h, m or s, like 2h, 30m or 3d4h
+*/
+func durationOf(str string) (time.Duration, bool) {
+	if !durationLiteralRegexp.MatchString(str) {
+		return 0, false
+	}
+	var duration time.Duration
+	for _, groups := range durationPartRegexp.FindAllStringSubmatch(str, -1) {
+		amount, err := strconv.ParseInt(groups[1], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		duration = duration + time.Duration(amount)*durationsByUnit[strings.ToLower(groups[2])]
+	}
+	if strings.HasPrefix(str, "-") {
+		return -duration, true
+	}
+	return duration, true
+}
+
+func durationPrecisionOf(value Value) (time.Duration, error) {
+	unit, err := dateTimeUnitOf(value)
+	if precision, ok := durationsByDateTimeUnit[unit]; err == nil && ok {
+		return precision, nil
+	}
+	return 0, fmt.Errorf(messages.ErrorMessageIncorrectDateTimeUnit, strings.Join(durationUnits, ", "), value.GetAsString())
+}
+
+func formatDuration(duration time.Duration, precision time.Duration, separator string) string {
+	sign := ""
+	if duration < 0 {
+		sign, duration = "-", -duration
+	}
+	duration = duration.Truncate(precision)
+
+	var parts []string
+	smallestUnit := "s"
+	for _, unit := range []string{"d", "h", "m", "s"} {
+		if durationsByUnit[unit] < precision {
+			break
+		}
+		smallestUnit = unit
+		if amount := duration / durationsByUnit[unit]; amount > 0 {
+			parts = append(parts, strconv.FormatInt(int64(amount), 10)+unit)
+			duration = duration - amount*durationsByUnit[unit]
+		}
+	}
+	if len(parts) == 0 {
+		return "0" + smallestUnit
+	}
+	return sign + strings.Join(parts, separator)
+}
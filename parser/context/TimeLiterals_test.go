@@ -0,0 +1,104 @@
+//go:build unit
+// +build unit
+
+package context
+
+import (
+	"testing"
+	"time"
+)
+
+var aWednesday = time.Date(2022, 10, 19, 15, 30, 45, 0, time.UTC)
+
+func TestRelativeDateTimeWithDaysAgo(t *testing.T) {
+	aTime, ok := relativeDateTimeOf("7 days ago", aWednesday)
+	expected := time.Date(2022, 10, 12, 15, 30, 45, 0, time.UTC)
+
+	if !ok || !aTime.Equal(expected) {
+		t.Fatalf("Expected relative date/time to be %v, received %v", expected, aTime)
+	}
+}
+
+func TestRelativeDateTimeWithAMonthAgo(t *testing.T) {
+	aTime, ok := relativeDateTimeOf("1 Month  ago", time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC))
+	expected := time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	if !ok || !aTime.Equal(expected) {
+		t.Fatalf("Expected relative date/time to be %v, received %v", expected, aTime)
+	}
+}
+
+func TestRelativeDateTimeWithStartOfWeek(t *testing.T) {
+	aTime, ok := relativeDateTimeOf("start of week", aWednesday)
+	expected := time.Date(2022, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	if !ok || !aTime.Equal(expected) {
+		t.Fatalf("Expected relative date/time to be %v, received %v", expected, aTime)
+	}
+}
+
+func TestRelativeDateTimeWithYesterday(t *testing.T) {
+	aTime, ok := relativeDateTimeOf("Yesterday", aWednesday)
+	expected := time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	if !ok || !aTime.Equal(expected) {
+		t.Fatalf("Expected relative date/time to be %v, received %v", expected, aTime)
+	}
+}
+
+func TestRelativeDateTimeWithAnUnsupportedUnit(t *testing.T) {
+	if _, ok := relativeDateTimeOf("2 fortnights ago", aWednesday); ok {
+		t.Fatalf("Expected 2 fortnights ago to not be a relative date/time")
+	}
+}
+
+func TestDurationOf(t *testing.T) {
+	duration, ok := durationOf("3d4h30m")
+	expected := 3*24*time.Hour + 4*time.Hour + 30*time.Minute
+
+	if !ok || duration != expected {
+		t.Fatalf("Expected duration to be %v, received %v", expected, duration)
+	}
+}
+
+func TestDurationOfWeeks(t *testing.T) {
+	duration, ok := durationOf("-2W")
+	expected := -14 * 24 * time.Hour
+
+	if !ok || duration != expected {
+		t.Fatalf("Expected duration to be %v, received %v", expected, duration)
+	}
+}
+
+func TestDurationOfAnUnsupportedLiteral(t *testing.T) {
+	if _, ok := durationOf("2mb"); ok {
+		t.Fatalf("Expected 2mb to not be a duration")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	formatted := formatDuration(3*24*time.Hour+4*time.Hour+5*time.Second, time.Second, " ")
+	expected := "3d 4h 5s"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted duration to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatDurationWithAPrecision(t *testing.T) {
+	formatted := formatDuration(-(3*24*time.Hour + 4*time.Hour + 12*time.Minute), time.Hour, " ")
+	expected := "-3d 4h"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted duration to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatAZeroDuration(t *testing.T) {
+	formatted := formatDuration(40*time.Minute, time.Hour, " ")
+	expected := "0h"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted duration to be %v, received %v", expected, formatted)
+	}
+}
@@ -0,0 +1,48 @@
+package context
+
+import (
+	"fmt"
+	"goselect/parser/error/messages"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeZoneOffsetRegexp = regexp.MustCompile("^([-+])([0-9]{2}):([0-9]{2})$")
+
+/*
+zone:    a name from the IANA time zone database like Europe/Berlin, UTC, Local for the system time zone or an offset like +05:30
+applies: now(), the date/time attributes, the relative time literals and the parsed date/times
+*/
+func (timeContext *TimeContext) setTimeZone(zone string) error {
+	aLocation, err := timeZoneOf(zone)
+	if err != nil {
+		return err
+	}
+	timeContext.location = aLocation
+	return nil
+}
+
+func timeZoneOf(zone string) (*time.Location, error) {
+	if groups := timeZoneOffsetRegexp.FindStringSubmatch(zone); groups != nil {
+		hours, _ := strconv.Atoi(groups[2])
+		minutes, _ := strconv.Atoi(groups[3])
+		offset := hours*60*60 + minutes*60
+		if groups[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(zone, offset), nil
+	}
+	if strings.EqualFold(zone, "local") {
+		return time.Local, nil
+	}
+	if strings.EqualFold(zone, "utc") {
+		return time.UTC, nil
+	}
+	aLocation, err := time.LoadLocation(zone)
+	if err != nil || len(zone) == 0 {
+		return nil, fmt.Errorf(messages.ErrorMessageUnsupportedTimeZone, zone)
+	}
+	return aLocation, nil
+}
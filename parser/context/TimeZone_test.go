@@ -0,0 +1,130 @@
+//go:build unit
+// +build unit
+
+package context
+
+import (
+	"goselect/parser/tokenizer"
+	"testing"
+	"time"
+)
+
+func TestSetTimeZone(t *testing.T) {
+	timeContext := newTimeContext()
+	if err := timeContext.setTimeZone("Europe/Berlin"); err != nil {
+		t.Fatalf("Expected no error while setting the time zone Europe/Berlin, received %v", err)
+	}
+
+	if timeContext.location.String() != "Europe/Berlin" {
+		t.Fatalf("Expected time zone to be %v, received %v", "Europe/Berlin", timeContext.location)
+	}
+}
+
+func TestSetTimeZoneWithAnOffset(t *testing.T) {
+	timeContext := newTimeContext()
+	_ = timeContext.setTimeZone("-03:30")
+	_, offset := time.Date(2022, 10, 18, 0, 0, 0, 0, timeContext.location).Zone()
+
+	if offset != -(3*60*60 + 30*60) {
+		t.Fatalf("Expected the offset of the time zone to be %v seconds, received %v", -(3*60*60 + 30*60), offset)
+	}
+}
+
+func TestSetTimeZoneToLocal(t *testing.T) {
+	timeContext := newTimeContext()
+	_ = timeContext.setTimeZone("local")
+
+	if timeContext.location != time.Local {
+		t.Fatalf("Expected time zone to be the local time zone, received %v", timeContext.location)
+	}
+}
+
+func TestSetAnUnsupportedTimeZone(t *testing.T) {
+	timeContext := newTimeContext()
+	if err := timeContext.setTimeZone("Mars/Olympus"); err == nil {
+		t.Fatalf("Expected an error while setting an unsupported time zone")
+	}
+	if timeContext.location != time.UTC {
+		t.Fatalf("Expected time zone to remain UTC after an unsupported time zone, received %v", timeContext.location)
+	}
+}
+
+func TestSetTimeZoneIsScopedToItsContext(t *testing.T) {
+	context := NewContext(NewFunctions(), NewAttributes())
+	_ = context.SetTimeZone("Europe/Berlin")
+
+	if other := NewContext(NewFunctions(), NewAttributes()); other.TimeZone() != time.UTC {
+		t.Fatalf("Expected the time zone of another context to remain UTC, received %v", other.TimeZone())
+	}
+}
+
+func TestNowInATimeZone(t *testing.T) {
+	functions := functionsAt(time.Date(2022, 8, 22, 23, 30, 0, 0, time.UTC))
+	_ = functions.timeContext.setTimeZone("Europe/Berlin")
+
+	value, _ := functions.Execute("cdate")
+	expected := "2022-August-23"
+
+	if value.GetAsString() != expected {
+		t.Fatalf("Expected date to be %v, received %v", expected, value.GetAsString())
+	}
+}
+
+func TestRelativeDateTimeInATimeZone(t *testing.T) {
+	context := NewContext(functionsAt(time.Date(2022, 8, 22, 23, 30, 0, 0, time.UTC)), NewAttributes())
+	_ = context.SetTimeZone("Asia/Kolkata")
+
+	value, _ := context.ToValue(tokenizer.NewToken(tokenizer.RawString, "today"))
+	expected := time.Date(2022, 8, 23, 0, 0, 0, 0, context.TimeZone())
+
+	if actualValue, err := value.GetDateTime(); err != nil || !actualValue.Equal(expected) {
+		t.Fatalf("Expected today to be %v, received %v", expected, value)
+	}
+}
+
+func TestParseInATimeZone(t *testing.T) {
+	functions := NewFunctions()
+	_ = functions.timeContext.setTimeZone("Europe/Berlin")
+	value, _ := functions.Execute("parsedatetime", StringValue("2022-10-18T10:00:00"), StringValue("ts"))
+	expected := "2022-10-18 10:00:00 +0200 CEST"
+
+	if value.GetAsString() != expected {
+		t.Fatalf("Expected parsing of date/time to return %v, received %v", expected, value.GetAsString())
+	}
+}
+
+func TestCastToDateTimeInATimeZone(t *testing.T) {
+	functions := NewFunctions()
+	_ = functions.timeContext.setTimeZone("Europe/Berlin")
+	value, _ := functions.Execute("cast", StringValue("2022-10-18"), StringValue("datetime"))
+	expected := "2022-10-18 00:00:00 +0200 CEST"
+
+	if value.GetAsString() != expected {
+		t.Fatalf("Expected cast to date/time to return %v, received %v", expected, value.GetAsString())
+	}
+}
+
+func TestAtTimeZone(t *testing.T) {
+	value, _ := NewFunctions().Execute("attimezone", DateTimeValue(time.Date(2022, 10, 3, 11, 48, 5, 0, time.UTC)), StringValue("Europe/Berlin"))
+	expected := "2022-10-03 13:48:05 +0200 CEST"
+
+	if value.GetAsString() != expected {
+		t.Fatalf("Expected attimezone to be %v, received %v", expected, value.GetAsString())
+	}
+}
+
+func TestAtTimeZoneWithAnOffset(t *testing.T) {
+	value, _ := NewFunctions().Execute("attz", DateTimeValue(time.Date(2022, 10, 3, 20, 0, 0, 0, time.UTC)), StringValue("+05:30"))
+
+	if aTime, _ := value.GetDateTime(); aTime.Day() != 4 || aTime.Hour() != 1 || aTime.Minute() != 30 {
+		t.Fatalf("Expected attimezone to be 2022-10-04 01:30, received %v", value)
+	}
+}
+
+func TestAtTimeZoneWithAnUnsupportedTimeZone(t *testing.T) {
+	_, err := NewFunctions().Execute("attimezone", DateTimeValue(time.Now()), StringValue("Mars/Olympus"))
+
+	if err == nil {
+		t.Fatalf("Expected an error while executing attimezone with an unsupported time zone")
+	}
+}
@@ -26,10 +26,18 @@ const (
 	ValueTypeFloat64   = 7
 	ValueTypeUndefined = 8
 	ValueTypeUint64    = 9
+	ValueTypeNull      = 10
+	ValueTypeDuration  = 11
+)
+
+const (
+	nullAsString = "NULL"
+	nullAsKey    = "\x00NULL"
 )
 
 var (
 	EmptyValue        = emptyValue()
+	NullValue         = Value{valueType: ValueTypeNull}
 	zeroUint32Value   = Uint32Value(0)
 	oneUint32Value    = Uint32Value(1)
 	trueBooleanValue  = BooleanValue(true)
@@ -37,15 +45,16 @@ var (
 )
 
 type Value struct {
-	valueType    valueType
-	stringValue  string
-	intValue     int
-	int64Value   int64
-	booleanValue bool
-	uint32Value  uint32
-	float64Value float64
-	timeValue    time.Time
-	uint64Value  uint64
+	valueType     valueType
+	stringValue   string
+	intValue      int
+	int64Value    int64
+	booleanValue  bool
+	uint32Value   uint32
+	float64Value  float64
+	timeValue     time.Time
+	uint64Value   uint64
+	durationValue time.Duration
 }
 
 func StringValue(value string) Value {
@@ -104,6 +113,17 @@ func Uint64Value(value uint64) Value {
 	}
 }
 
+func DurationValue(duration time.Duration) Value {
+	return Value{
+		durationValue: duration,
+		valueType:     ValueTypeDuration,
+	}
+}
+
+func (value Value) IsNull() bool {
+	return value.valueType == ValueTypeNull
+}
+
 func (value Value) GetInt() (int, error) {
 	if value.valueType != ValueTypeInt {
 		return -1, fmt.Errorf(messages.ErrorMessageIncorrectValueType, "int", value.GetAsString())
@@ -118,6 +138,25 @@ func (value Value) GetDateTime() (time.Time, error) {
 	return value.timeValue, nil
 }
 
+func (value Value) GetDuration() (time.Duration, error) {
+	if value.valueType != ValueTypeDuration {
+		return 0, fmt.Errorf(messages.ErrorMessageIncorrectValueType, "duration", value.GetAsString())
+	}
+	return value.durationValue, nil
+}
+
+func (value Value) isANumber() bool {
+	switch value.valueType {
+	case ValueTypeInt, ValueTypeInt64, ValueTypeUint32, ValueTypeUint64, ValueTypeFloat64:
+		return true
+	}
+	return false
+}
+
+func (value Value) isADuration() bool {
+	return value.valueType == ValueTypeDuration
+}
+
 func (value Value) GetBoolean() (bool, error) {
 	if value.valueType == ValueTypeString {
 		v, _ := stringToBoolean(value.stringValue)
@@ -129,6 +168,9 @@ func (value Value) GetBoolean() (bool, error) {
 	if value.valueType == ValueTypeBoolean {
 		return value.booleanValue, nil
 	}
+	if value.valueType == ValueTypeNull {
+		return false, nil
+	}
 	return false, fmt.Errorf(messages.ErrorMessageIncorrectValueType, "boolean", value.GetAsString())
 }
 
@@ -161,11 +203,45 @@ func (value Value) GetAsString() string {
 		return value.timeValue.String()
 	case ValueTypeUint64:
 		return strconv.FormatUint(value.uint64Value, 10)
+	case ValueTypeDuration:
+		return formatDuration(value.durationValue, time.Second, " ")
+	case ValueTypeNull:
+		return nullAsString
 	}
 	return ""
 }
 
+// GetAsKey returns the key used to bucket values before CompareTo decides the equality,
+// numbers of all the types share the key of their float64 form so that 5 and 5.0 land in the same bucket.
+func (value Value) GetAsKey() string {
+	if value.valueType == ValueTypeNull {
+		return nullAsKey
+	}
+	if value.isANumber() {
+		number, _ := value.GetNumericAsFloat64()
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return value.GetAsString()
+}
+
+func (value Value) GetAsLiteral() string {
+	switch value.valueType {
+	case ValueTypeFloat64:
+		return strconv.FormatFloat(value.float64Value, 'f', -1, 64)
+	case ValueTypeBoolean:
+		return strconv.FormatBool(value.booleanValue)
+	case ValueTypeDuration:
+		return formatDuration(value.durationValue, time.Second, "")
+	case ValueTypeNull:
+		return "null"
+	}
+	return value.GetAsString()
+}
+
 func (value Value) CompareTo(other Value) int {
+	if value.IsNull() || other.IsNull() {
+		return compareNulls(value, other)
+	}
 	receiver, arg := value, other
 	if value.valueType != other.valueType {
 		if rec, ar, err := value.attemptCommonType(other); err != nil {
@@ -247,10 +323,29 @@ func (value Value) CompareTo(other Value) int {
 			return CompareToLessThan
 		}
 		return CompareToGreaterThan
+	case ValueTypeDuration:
+		first, second := receiver.durationValue, arg.durationValue
+		if first == second {
+			return CompareToEqual
+		}
+		if first < second {
+			return CompareToLessThan
+		}
+		return CompareToGreaterThan
 	}
 	return CompareToNotPossible
 }
 
+func compareNulls(value Value, other Value) int {
+	switch {
+	case value.IsNull() && other.IsNull():
+		return CompareToEqual
+	case value.IsNull():
+		return CompareToLessThan
+	}
+	return CompareToGreaterThan
+}
+
 func emptyValue() Value {
 	return Value{valueType: ValueTypeUndefined}
 }
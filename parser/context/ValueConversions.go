@@ -2,12 +2,18 @@ package context
 
 import (
 	"fmt"
+	"github.com/dustin/go-humanize"
 	"goselect/parser/error/messages"
 	"goselect/parser/tokenizer"
+	"math"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
+var sizeLiteralRegexp, _ = regexp.Compile("^(?i)[0-9]+(?:\\.[0-9]+)?(?:b|kb|mb|gb|tb|pb|kib|mib|gib|tib|pib)$")
+
 type TypePair struct {
 	aType valueType
 	bType valueType
@@ -15,6 +21,44 @@ type TypePair struct {
 
 type toCommonTypeValueFunction = func(aValue Value, bValue Value) (Value, Value, error)
 
+type castFunction = func(value Value) (Value, error)
+
+const (
+	CastTypeInt      = "int"
+	CastTypeFloat    = "float"
+	CastTypeString   = "string"
+	CastTypeBool     = "bool"
+	CastTypeDateTime = "datetime"
+	CastTypeSize     = "size"
+)
+
+var castTypes = []string{CastTypeInt, CastTypeFloat, CastTypeString, CastTypeBool, CastTypeDateTime, CastTypeSize}
+
+// SupportedCastTypes returns the types a value can be converted to with cast(expression as type).
+func SupportedCastTypes() []string {
+	return castTypes
+}
+
+// IsASupportedCastType returns true if the type (case-insensitive) is one of SupportedCastTypes.
+func IsASupportedCastType(castType string) bool {
+	for _, supported := range castTypes {
+		if strings.EqualFold(supported, castType) {
+			return true
+		}
+	}
+	return false
+}
+
+var castFunctions = map[string]castFunction{
+	CastTypeInt:    castToInt,
+	CastTypeFloat:  castToFloat,
+	CastTypeString: castToString,
+	CastTypeBool:   castToBool,
+	CastTypeSize:   castToSize,
+}
+
+var castDateTimeFormatIds = []string{"tsfull", "ts", "dt"}
+
 var toTargetConversions = map[TypePair]toCommonTypeValueFunction{
 	TypePair{aType: ValueTypeInt, bType: ValueTypeUint64}: func(aValue Value, bValue Value) (Value, Value, error) {
 		return Uint64Value(uint64(aValue.intValue)), bValue, nil
@@ -79,6 +123,20 @@ var toTargetConversions = map[TypePair]toCommonTypeValueFunction{
 	TypePair{aType: ValueTypeInt64, bType: ValueTypeUint32}: func(aValue Value, bValue Value) (Value, Value, error) {
 		return Float64Value(float64(aValue.int64Value)), Float64Value(float64(bValue.uint32Value)), nil
 	},
+	TypePair{aType: ValueTypeDuration, bType: ValueTypeString}: func(aValue Value, bValue Value) (Value, Value, error) {
+		v, ok := durationOf(bValue.stringValue)
+		if !ok {
+			return aValue, bValue, fmt.Errorf(messages.ErrorMessageIncorrectValueType, "duration", bValue.stringValue)
+		}
+		return aValue, DurationValue(v), nil
+	},
+	TypePair{aType: ValueTypeString, bType: ValueTypeDuration}: func(aValue Value, bValue Value) (Value, Value, error) {
+		v, ok := durationOf(aValue.stringValue)
+		if !ok {
+			return aValue, bValue, fmt.Errorf(messages.ErrorMessageIncorrectValueType, "duration", aValue.stringValue)
+		}
+		return DurationValue(v), bValue, nil
+	},
 	TypePair{aType: ValueTypeString, bType: ValueTypeBoolean}: func(aValue Value, bValue Value) (Value, Value, error) {
 		v, _ := stringToBoolean(aValue.stringValue)
 		if v == EmptyValue {
@@ -165,7 +223,15 @@ var toTargetConversions = map[TypePair]toCommonTypeValueFunction{
 	},
 }
 
+// ToValue types a token relative to the current time in UTC, ParsingApplicationContext.ToValue uses the time zone of its context.
 func ToValue(token tokenizer.Token) (Value, error) {
+	return toValue(token, newTimeContext().now())
+}
+
+func toValue(token tokenizer.Token, now time.Time) (Value, error) {
+	if token.IsANull() {
+		return NullValue, nil
+	}
 	switch token.TokenType {
 	case tokenizer.Numeric:
 		return stringToInt64(token.TokenValue)
@@ -174,14 +240,24 @@ func ToValue(token tokenizer.Token) (Value, error) {
 	case tokenizer.Boolean:
 		value, _ := stringToBoolean(token.TokenValue)
 		if value == EmptyValue {
-			return StringValue(token.TokenValue), nil
+			return stringToValue(token.TokenValue, now, token.IsQuoted())
 		}
 		return value, nil
 	default:
-		return StringValue(token.TokenValue), nil
+		return stringToValue(token.TokenValue, now, token.IsQuoted())
 	}
 }
 
+func stringToValue(str string, now time.Time, quoted bool) (Value, error) {
+	if sizeLiteralRegexp.MatchString(str) {
+		return stringToSize(str)
+	}
+	if value, ok := timeLiteralOf(str, now.Round(0)); ok && !quoted {
+		return value, nil
+	}
+	return StringValue(str), nil
+}
+
 func getCommonType(value Value, other Value, typePair TypePair) (Value, Value, error) {
 	fn, ok := toTargetConversions[typePair]
 	if !ok {
@@ -219,6 +295,14 @@ func stringToFloat64(str string) (Value, error) {
 	return Float64Value(v), nil
 }
 
+func stringToSize(str string) (Value, error) {
+	v, err := humanize.ParseBytes(str)
+	if err != nil {
+		return EmptyValue, err
+	}
+	return Uint64Value(v), nil
+}
+
 func stringToBoolean(str string) (Value, error) {
 	lowerCased := strings.ToLower(str)
 	if lowerCased == "true" || lowerCased == "y" {
@@ -229,3 +313,127 @@ func stringToBoolean(str string) (Value, error) {
 	}
 	return EmptyValue, nil
 }
+
+func castToInt(value Value) (Value, error) {
+	switch value.valueType {
+	case ValueTypeInt:
+		return Int64Value(int64(value.intValue)), nil
+	case ValueTypeInt64:
+		return value, nil
+	case ValueTypeUint32:
+		return Int64Value(int64(value.uint32Value)), nil
+	case ValueTypeUint64:
+		if value.uint64Value <= math.MaxInt64 {
+			return Int64Value(int64(value.uint64Value)), nil
+		}
+	case ValueTypeFloat64:
+		if value.float64Value >= math.MinInt64 && value.float64Value <= math.MaxInt64 {
+			return Int64Value(int64(value.float64Value)), nil
+		}
+	case ValueTypeBoolean:
+		if value.booleanValue {
+			return Int64Value(1), nil
+		}
+		return Int64Value(0), nil
+	case ValueTypeDateTime:
+		return Int64Value(value.timeValue.Unix()), nil
+	case ValueTypeDuration:
+		return Int64Value(int64(value.durationValue / time.Second)), nil
+	case ValueTypeString:
+		if v, err := stringToInt64(strings.TrimSpace(value.stringValue)); err == nil {
+			return v, nil
+		}
+	}
+	return EmptyValue, fmt.Errorf(messages.ErrorMessageCannotCast, value.GetAsString(), CastTypeInt)
+}
+
+func castToFloat(value Value) (Value, error) {
+	switch value.valueType {
+	case ValueTypeBoolean:
+		if value.booleanValue {
+			return Float64Value(1), nil
+		}
+		return Float64Value(0), nil
+	case ValueTypeDateTime:
+		return Float64Value(float64(value.timeValue.Unix())), nil
+	case ValueTypeDuration:
+		return Float64Value(value.durationValue.Seconds()), nil
+	case ValueTypeString:
+		if v, err := stringToFloat64(strings.TrimSpace(value.stringValue)); err == nil {
+			return v, nil
+		}
+	default:
+		if v, err := toFloat64(value); err == nil {
+			return v, nil
+		}
+	}
+	return EmptyValue, fmt.Errorf(messages.ErrorMessageCannotCast, value.GetAsString(), CastTypeFloat)
+}
+
+func castToString(value Value) (Value, error) {
+	if value.valueType == ValueTypeString {
+		return value, nil
+	}
+	return StringValue(value.GetAsLiteral()), nil
+}
+
+func castToBool(value Value) (Value, error) {
+	switch value.valueType {
+	case ValueTypeBoolean:
+		return value, nil
+	case ValueTypeString:
+		if v, _ := stringToBoolean(strings.TrimSpace(value.stringValue)); v != EmptyValue {
+			return v, nil
+		}
+	default:
+		if v, err := toFloat64(value); err == nil {
+			return booleanValueUsing(v.float64Value != 0), nil
+		}
+	}
+	return EmptyValue, fmt.Errorf(messages.ErrorMessageCannotCast, value.GetAsString(), CastTypeBool)
+}
+
+func castToDateTime(value Value, location *time.Location) (Value, error) {
+	switch value.valueType {
+	case ValueTypeDateTime:
+		return value, nil
+	case ValueTypeString:
+		for _, formatId := range castDateTimeFormatIds {
+			if parsed, err := formatDefinitions.parse(strings.TrimSpace(value.stringValue), formatId, location); err == nil {
+				return DateTimeValue(parsed), nil
+			}
+		}
+	case ValueTypeInt, ValueTypeInt64, ValueTypeUint32, ValueTypeUint64:
+		seconds, _ := castToInt(value)
+		if seconds.valueType == ValueTypeInt64 {
+			return DateTimeValue(time.Unix(seconds.int64Value, 0).In(location)), nil
+		}
+	}
+	return EmptyValue, fmt.Errorf(messages.ErrorMessageCannotCast, value.GetAsString(), CastTypeDateTime)
+}
+
+func castToSize(value Value) (Value, error) {
+	switch value.valueType {
+	case ValueTypeUint64:
+		return value, nil
+	case ValueTypeUint32:
+		return Uint64Value(uint64(value.uint32Value)), nil
+	case ValueTypeInt:
+		if value.intValue >= 0 {
+			return Uint64Value(uint64(value.intValue)), nil
+		}
+	case ValueTypeInt64:
+		if value.int64Value >= 0 {
+			return Uint64Value(uint64(value.int64Value)), nil
+		}
+	case ValueTypeFloat64:
+		if value.float64Value >= 0 && value.float64Value <= math.MaxUint64 {
+			return Uint64Value(uint64(value.float64Value)), nil
+		}
+	case ValueTypeString:
+		if v, err := stringToSize(strings.TrimSpace(value.stringValue)); err == nil {
+			return v, nil
+		}
+	}
+	return EmptyValue, fmt.Errorf(messages.ErrorMessageCannotCast, value.GetAsString(), CastTypeSize)
+}
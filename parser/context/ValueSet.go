@@ -0,0 +1,34 @@
+package context
+
+type ValueSet struct {
+	valuesByKey map[string][]Value
+}
+
+func NewValueSet() *ValueSet {
+	return &ValueSet{valuesByKey: make(map[string][]Value)}
+}
+
+func (valueSet *ValueSet) Add(value Value) {
+	if valueSet.Contains(value) {
+		return
+	}
+	key := value.GetAsKey()
+	valueSet.valuesByKey[key] = append(valueSet.valuesByKey[key], value)
+}
+
+func (valueSet *ValueSet) Contains(value Value) bool {
+	for _, existing := range valueSet.valuesByKey[value.GetAsKey()] {
+		if existing.CompareTo(value) == CompareToEqual {
+			return true
+		}
+	}
+	return false
+}
+
+func (valueSet *ValueSet) Count() int {
+	count := 0
+	for _, values := range valueSet.valuesByKey {
+		count = count + len(values)
+	}
+	return count
+}
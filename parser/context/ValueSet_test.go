@@ -0,0 +1,62 @@
+//go:build unit
+// +build unit
+
+package context
+
+import "testing"
+
+func TestValueSetContainsAnAddedValue(t *testing.T) {
+	valueSet := NewValueSet()
+	valueSet.Add(StringValue("sample.log"))
+
+	if !valueSet.Contains(StringValue("sample.log")) {
+		t.Fatalf("Expected value set to contain %v", "sample.log")
+	}
+}
+
+func TestValueSetDoesNotContainAValue(t *testing.T) {
+	valueSet := NewValueSet()
+	valueSet.Add(StringValue("sample.log"))
+
+	if valueSet.Contains(StringValue("sample.txt")) {
+		t.Fatalf("Expected value set to not contain %v", "sample.txt")
+	}
+}
+
+func TestValueSetContainsAValueOfACompatibleType(t *testing.T) {
+	valueSet := NewValueSet()
+	valueSet.Add(Int64Value(58))
+
+	if !valueSet.Contains(IntValue(58)) {
+		t.Fatalf("Expected value set to contain %v", 58)
+	}
+}
+
+func TestValueSetIgnoresDuplicates(t *testing.T) {
+	valueSet := NewValueSet()
+	valueSet.Add(StringValue("sample.log"))
+	valueSet.Add(StringValue("sample.log"))
+
+	if valueSet.Count() != 1 {
+		t.Fatalf("Expected value set count to be %v, received %v", 1, valueSet.Count())
+	}
+}
+
+func TestValueSetContainsAnIntegerAddedAsAFloat(t *testing.T) {
+	valueSet := NewValueSet()
+	valueSet.Add(Float64Value(58))
+
+	if !valueSet.Contains(Int64Value(58)) {
+		t.Fatalf("Expected value set to contain %v", 58)
+	}
+}
+
+func TestValueSetIgnoresDuplicatesOfDifferentNumericTypes(t *testing.T) {
+	valueSet := NewValueSet()
+	valueSet.Add(Int64Value(58))
+	valueSet.Add(Float64Value(58))
+
+	if valueSet.Count() != 1 {
+		t.Fatalf("Expected value set count to be %v, received %v", 1, valueSet.Count())
+	}
+}
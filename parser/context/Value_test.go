@@ -553,7 +553,7 @@ func TestGetBoolean(t *testing.T) {
 
 func TestTokenToInt64Value(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.Numeric, "12")
-	value, _ := ToValue(token)
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if value.CompareTo(Int64Value(12)) != CompareToEqual {
 		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "12", Int64Value(12), value)
@@ -562,7 +562,7 @@ func TestTokenToInt64Value(t *testing.T) {
 
 func TestTokenToInt64ValueWithError(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.Numeric, "non-numeric")
-	_, err := ToValue(token)
+	_, err := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if err == nil {
 		t.Fatalf("Expected an error while converting %v to int64 but received none", "non-numeric")
@@ -571,7 +571,7 @@ func TestTokenToInt64ValueWithError(t *testing.T) {
 
 func TestTokenToFloat64Value(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.FloatingPoint, "12.78")
-	value, _ := ToValue(token)
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if value.CompareTo(Float64Value(12.78)) != CompareToEqual {
 		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "12.78", Float64Value(12.78), value)
@@ -580,7 +580,7 @@ func TestTokenToFloat64Value(t *testing.T) {
 
 func TestTokenToFloat64ValueWithError(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.FloatingPoint, "non-numeric")
-	_, err := ToValue(token)
+	_, err := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if err == nil {
 		t.Fatalf("Expected an error while converting %v to float64 but received none", "non-numeric")
@@ -589,7 +589,7 @@ func TestTokenToFloat64ValueWithError(t *testing.T) {
 
 func TestTokenToBooleanAsTrue1(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.Boolean, "true")
-	value, _ := ToValue(token)
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if value.CompareTo(booleanValueUsing(true)) != CompareToEqual {
 		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "true", booleanValueUsing(true), value)
@@ -598,7 +598,7 @@ func TestTokenToBooleanAsTrue1(t *testing.T) {
 
 func TestTokenToBooleanAsTrue2(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.Boolean, "y")
-	value, _ := ToValue(token)
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if value.CompareTo(booleanValueUsing(true)) != CompareToEqual {
 		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "y", booleanValueUsing(true), value)
@@ -607,7 +607,7 @@ func TestTokenToBooleanAsTrue2(t *testing.T) {
 
 func TestTokenToBooleanAsFalse1(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.Boolean, "false")
-	value, _ := ToValue(token)
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if value.CompareTo(booleanValueUsing(false)) != CompareToEqual {
 		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "false", booleanValueUsing(false), value)
@@ -616,7 +616,7 @@ func TestTokenToBooleanAsFalse1(t *testing.T) {
 
 func TestTokenToBooleanAsFalse2(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.Boolean, "n")
-	value, _ := ToValue(token)
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if value.CompareTo(booleanValueUsing(false)) != CompareToEqual {
 		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "n", booleanValueUsing(false), value)
@@ -625,7 +625,7 @@ func TestTokenToBooleanAsFalse2(t *testing.T) {
 
 func TestTokenToString1(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.Boolean, "non-boolean")
-	value, _ := ToValue(token)
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if value.CompareTo(StringValue("non-boolean")) != CompareToEqual {
 		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "non-boolean", StringValue("non-boolean"), value)
@@ -634,7 +634,7 @@ func TestTokenToString1(t *testing.T) {
 
 func TestTokenToString2(t *testing.T) {
 	token := tokenizer.NewToken(tokenizer.RawString, "string")
-	value, _ := ToValue(token)
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
 
 	if value.CompareTo(StringValue("string")) != CompareToEqual {
 		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "string", StringValue("string"), value)
@@ -837,3 +837,202 @@ func TestGetCommonTypeUint64Int64NotEqual(t *testing.T) {
 		t.Fatalf("Expected first and second values to not match but they did")
 	}
 }
+
+func TestTokenToSizeValue1(t *testing.T) {
+	token := tokenizer.NewToken(tokenizer.RawString, "1mb")
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
+
+	if value.CompareTo(Uint64Value(1000000)) != CompareToEqual {
+		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "1mb", Uint64Value(1000000), value)
+	}
+}
+
+func TestTokenToSizeValue2(t *testing.T) {
+	token := tokenizer.NewToken(tokenizer.RawString, "1.5KiB")
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
+
+	if value.CompareTo(Uint64Value(1536)) != CompareToEqual {
+		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "1.5KiB", Uint64Value(1536), value)
+	}
+}
+
+func TestTokenToStringThatLooksLikeASize(t *testing.T) {
+	token := tokenizer.NewToken(tokenizer.RawString, "1mb.log")
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
+
+	if value.CompareTo(StringValue("1mb.log")) != CompareToEqual {
+		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "1mb.log", StringValue("1mb.log"), value)
+	}
+}
+
+func TestGetAsLiteralForAFloat(t *testing.T) {
+	literal := Float64Value(1.25).GetAsLiteral()
+
+	if literal != "1.25" {
+		t.Fatalf("Expected literal to be %v, received %v", "1.25", literal)
+	}
+}
+
+func TestGetAsLiteralForABoolean(t *testing.T) {
+	literal := BooleanValue(true).GetAsLiteral()
+
+	if literal != "true" {
+		t.Fatalf("Expected literal to be %v, received %v", "true", literal)
+	}
+}
+
+func TestGetAsLiteralForAString(t *testing.T) {
+	literal := StringValue("a b").GetAsLiteral()
+
+	if literal != "a b" {
+		t.Fatalf("Expected literal to be %v, received %v", "a b", literal)
+	}
+}
+
+func TestNullValueAsString(t *testing.T) {
+	if NullValue.GetAsString() != "NULL" {
+		t.Fatalf("Expected null value as string to be %v, received %v", "NULL", NullValue.GetAsString())
+	}
+}
+
+func TestNullValueAsBoolean(t *testing.T) {
+	value, err := NullValue.GetBoolean()
+
+	if err != nil || value != false {
+		t.Fatalf("Expected null value as boolean to be %v, received %v with error %v", false, value, err)
+	}
+}
+
+func TestCompareNullNull(t *testing.T) {
+	if NullValue.CompareTo(NullValue) != CompareToEqual {
+		t.Fatalf("Expected null values to match but they did not")
+	}
+}
+
+func TestCompareNullString(t *testing.T) {
+	if NullValue.CompareTo(StringValue("")) != CompareToLessThan {
+		t.Fatalf("Expected null value to be less than a blank string but was not")
+	}
+	if StringValue("").CompareTo(NullValue) != CompareToGreaterThan {
+		t.Fatalf("Expected a blank string to be greater than null value but was not")
+	}
+}
+
+func TestNullValueHasAKeyDifferentFromTheString(t *testing.T) {
+	if NullValue.GetAsKey() == StringValue("NULL").GetAsKey() {
+		t.Fatalf("Expected the key of null value to be different from the key of the string NULL")
+	}
+}
+
+func TestNumericValuesOfDifferentTypesHaveTheSameKey(t *testing.T) {
+	if Float64Value(5).GetAsKey() != Int64Value(5).GetAsKey() {
+		t.Fatalf("Expected the key of float64 5 to be the key of int64 5, received %v and %v", Float64Value(5).GetAsKey(), Int64Value(5).GetAsKey())
+	}
+	if Uint32Value(5).GetAsKey() != IntValue(5).GetAsKey() {
+		t.Fatalf("Expected the key of uint32 5 to be the key of int 5, received %v and %v", Uint32Value(5).GetAsKey(), IntValue(5).GetAsKey())
+	}
+}
+
+func TestTokenToDurationValue(t *testing.T) {
+	token := tokenizer.NewToken(tokenizer.RawString, "2h")
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
+
+	if value.CompareTo(DurationValue(2*time.Hour)) != CompareToEqual {
+		t.Fatalf("Expected token %v to be converted to a value %v, but received %v", "2h", DurationValue(2*time.Hour), value)
+	}
+}
+
+func TestTokenToNullValue(t *testing.T) {
+	token := tokenizer.NewToken(tokenizer.RawString, "NULL")
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
+
+	if !value.IsNull() {
+		t.Fatalf("Expected token %v to be converted to a null value, but received %v", "NULL", value)
+	}
+}
+
+func TestTokenToRelativeDateTimeValue(t *testing.T) {
+	token := tokenizer.NewToken(tokenizer.RawString, "7 days ago")
+	context := NewContext(NewFunctions(), NewAttributes())
+	value, _ := context.ToValue(token)
+	aTime, err := value.GetDateTime()
+
+	if err != nil || !aTime.Equal(context.timeContext().now().AddDate(0, 0, -7)) {
+		t.Fatalf("Expected token %v to be converted to a date/time 7 days ago, but received %v", "7 days ago", value)
+	}
+}
+
+func TestBooleanLikeTokenToRelativeDateTimeValue(t *testing.T) {
+	token := tokenizer.NewToken(tokenizer.Boolean, "yesterday")
+	context := NewContext(NewFunctions(), NewAttributes())
+	value, _ := context.ToValue(token)
+	aTime, err := value.GetDateTime()
+
+	if err != nil || !aTime.Before(context.timeContext().now()) {
+		t.Fatalf("Expected token %v to be converted to a date/time, but received %v", "yesterday", value)
+	}
+}
+
+func TestCompareDurations(t *testing.T) {
+	if DurationValue(time.Hour).CompareTo(DurationValue(2*time.Hour)) != CompareToLessThan {
+		t.Fatalf("Expected 1h to be less than 2h")
+	}
+}
+
+func TestCompareADurationWithAString(t *testing.T) {
+	if DurationValue(26*time.Hour).CompareTo(StringValue("1d2h")) != CompareToEqual {
+		t.Fatalf("Expected 26 hours to be equal to 1d2h")
+	}
+}
+
+func TestCompareADurationWithSeconds(t *testing.T) {
+	if DurationValue(time.Minute).CompareTo(Int64Value(60)) != CompareToNotPossible {
+		t.Fatalf("Expected 1m to not be comparable with 60 seconds")
+	}
+}
+
+func TestTokenToRelativeDateTimeValueIsADateTime(t *testing.T) {
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(tokenizer.NewToken(tokenizer.RawString, "today"))
+
+	if _, err := value.GetDateTime(); err != nil || value.CompareTo(StringValue("today")) == CompareToEqual {
+		t.Fatalf("Expected the literal today to be a date/time, received %v", value)
+	}
+}
+
+func TestQuotedTokenToRelativeDateTimeValueIsAString(t *testing.T) {
+	token := tokenizer.NewTokenizer("'today'").Tokenize().Iterator().Next()
+	value, _ := NewContext(NewFunctions(), NewAttributes()).ToValue(token)
+
+	if value.CompareTo(StringValue("today")) != CompareToEqual {
+		t.Fatalf("Expected the quoted literal today to be the string today, received %v", value)
+	}
+}
+
+func TestCompareARelativeDateTimeLiteralWithADateTime(t *testing.T) {
+	context := NewContext(NewFunctions(), NewAttributes())
+	value, _ := context.ToValue(tokenizer.NewToken(tokenizer.RawString, "tomorrow"))
+
+	if value.CompareTo(DateTimeValue(context.timeContext().now())) != CompareToGreaterThan {
+		t.Fatalf("Expected the literal tomorrow to be greater than the current time")
+	}
+}
+
+func TestDurationAsString(t *testing.T) {
+	value := DurationValue(3*24*time.Hour + 4*time.Hour + 12*time.Minute)
+
+	if value.GetAsString() != "3d 4h 12m" || value.GetAsLiteral() != "3d4h12m" {
+		t.Fatalf("Expected duration as string and literal to be 3d 4h 12m and 3d4h12m, received %v and %v", value.GetAsString(), value.GetAsLiteral())
+	}
+}
+
+func TestExportedTokenToValue(t *testing.T) {
+	value, err := ToValue(tokenizer.NewToken(tokenizer.Numeric, "10"))
+
+	if err != nil || value.CompareTo(Int64Value(10)) != CompareToEqual {
+		t.Fatalf("Expected the token 10 to be the value 10, received %v, %v", value, err)
+	}
+	duration, _ := ToValue(tokenizer.NewToken(tokenizer.RawString, "2h"))
+	if aDuration, err := duration.GetDuration(); err != nil || aDuration != 2*time.Hour {
+		t.Fatalf("Expected the token 2h to be the duration 2h, received %v", duration)
+	}
+}
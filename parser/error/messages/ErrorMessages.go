@@ -1,37 +1,103 @@
 package messages
 
 const (
-	ErrorMessageEmptyQuery                                = "expected query to be non-empty"
-	ErrorMessageNonSelectQuery                            = "expected a select query statement"
-	ErrorMessageLimitValue                                = "expected a limit value"
-	ErrorMessageLimitValueInt                             = "expected limit to be a positive integer, received %v"
-	ErrorMessageMissingBy                                 = "expected 'by' after order"
-	ErrorMessageMissingCommaOrderBy                       = "expected a comma after 'order by' in attribute positions"
-	ErrorMessageMissingOrderByAttributes                  = "expected an attribute position after 'order by'. attribute positions start with 1"
-	ErrorMessageNonZeroPositivePositions                  = "expected non-zero & positive 'order by' positions"
-	ErrorMessageNonZeroPositivePositionsWithExistingError = "expected non-zero & positive 'order by' positions, %v"
-	ErrorMessageOrderByPositionOutOfRange                 = "expected 'order by' position to be between %v and %v, both inclusive"
-	ErrorMessageMissingSource                             = "expected a source path after 'from`"
-	ErrorMessageInaccessibleSource                        = "expected directory path %v to exist. please check the path, also ensure that it is accessible"
-	ErrorMessageSourceNotADirectory                       = "expected source path to be a directory"
-	ErrorMessageInvalidKeywordAfterFrom                   = "expected either where or order by or limit clause after the source directory"
-	ErrorMessageMissingCommaProjection                    = "expected a comma in the projection list after a supported attribute or a function. please check the spellings, supported attributes and supported functions as well"
-	ErrorMessageOpeningParenthesesProjection              = "expected an opening parentheses in the projection list after '%v'"
-	ErrorMessageInvalidProjection                         = "invalid projection list, please check the opening and closing parentheses for all the functions"
-	ErrorMessageExpectedExpressionInProjection            = "expected atleast one expression in the projection list. please check the supported attributes and functions"
-	ErrorMessageExpectedExpressionInWhere                 = "expected one expression in the where clause, or remove 'where' keyword"
-	ErrorMessageInvalidWhere                              = "invalid where clause, please check opening and closing parentheses for all the functions"
-	ErrorMessageInvalidWhereFunctionUsed                  = "invalid where clause, 'where' clause must be a single expression. please check all the functions supported in 'where' clause.\n'where' can be followed by either an 'order by' or a 'limit' clause"
-	ErrorMessageAggregateFunctionInsideWhere              = "invalid where clause, aggregate functions are not supported in the where clause"
-	ErrorMessageMissingParameterInScalarFunctions         = "expected %v parameter(s) in the function %v but did not receive the required parameter(s)"
-	ErrorMessageIncorrectValueType                        = "expected a %v value type but received %v"
-	ErrorMessageIncorrectEndIndexInSubstring              = "expected the end index to be greater than the from index in the function 'substr'"
-	ErrorMessageIllegalFromToIndexInSubstring             = "expected the from and to index to be positive integers"
-	ErrorMessageExpectedNumericArgument                   = "expected numeric type argument value but received %v"
-	ErrorMessageExpectedNonZeroInDivide                   = "expected a non zero denominator in divide operation"
-	ErrorMessageFunctionNamePrefixWithExistingError       = "[Function %v], %s"
-	ErrorMessageIncorrectExtractionKey                    = "expected either of %v to be passed to 'extract' as an extraction key"
-	ErrorMessageUnsupportedDateTimeFormat                 = "expected a supported date/time format id. Use CLI to check the supported date/time format ids"
-	ErrorMessageCannotConvertToBoolean                    = "expected conversion of %v to boolean, but failed"
-	ErrorMessageUndefinedConversionFunction               = "expected conversion of %v to %v, but such a conversion is not supported"
+	ErrorMessageDidYouMean                            = "did you mean %v?"
+	ErrorMessageMissingNamedParameter                 = "expected a value for the placeholder :%v"
+	ErrorMessageMissingPositionalParameter            = "expected a value for the placeholder ? at position %v"
+	ErrorMessageUnexpectedTokenInProjection           = "expected an attribute, a function or an expression in projections, received %v"
+	ErrorMessageTrailingCommaInProjection             = "expected an attribute, a function or an expression after the last comma in projections"
+	ErrorMessageOperatorWithoutSpaces                 = "expected spaces around the operators in %v"
+	ErrorMessageMissingCommaInFunction                = "expected a comma or a closing parenthesis after a parameter in the function %v, received %v"
+	ErrorMessageExpectedParameterInFunction           = "expected a parameter in the function %v, received %v"
+	ErrorMessageTooManyParametersInFunction           = "received too many parameters in the function %v, %v parameter(s) were given"
+	ErrorMessageUnexpectedTokenAtEnd                  = "expected the end of the query, received %v"
+	ErrorMessageUnsupportedAttributeInBuilder         = "expected a supported attribute, received %v"
+	ErrorMessageUnsupportedFunctionInBuilder          = "expected a supported function, received %v"
+	ErrorMessageUnsupportedValueInBuilder             = "expected a string, a number, a boolean or a time as a value, received %v"
+	ErrorMessageUnsupportedTermInBuilder              = "expected an attribute name or a term, received %v"
+	ErrorMessageEmptyQuery                            = "expected query to be non-empty"
+	ErrorMessageNonSelectQuery                        = "expected a select query statement"
+	ErrorMessageLimitValue                            = "expected a limit value"
+	ErrorMessageLimitValueInt                         = "expected limit to be a positive integer, received %v"
+	ErrorMessageOffsetValue                           = "expected an offset value after 'offset'"
+	ErrorMessageOffsetValueInt                        = "expected offset to be a positive integer, received %v"
+	ErrorMessageMissingBy                             = "expected 'by' after order"
+	ErrorMessageMissingCommaOrderBy                   = "expected a comma after an attribute in 'order by'"
+	ErrorMessageMissingOrderByAttributes              = "expected either an attribute position, an alias, an attribute or an expression after 'order by'. attribute positions start with 1"
+	ErrorMessageNonZeroPositivePositions              = "expected non-zero & positive 'order by' positions"
+	ErrorMessageOrderByPositionOutOfRange             = "expected 'order by' position to be between %v and %v, both inclusive"
+	ErrorMessageInvalidOrderByAttribute               = "expected either a position, an alias, an attribute or an expression in 'order by', received %v"
+	ErrorMessageInvalidOrderBy                        = "invalid order by clause, please check opening and closing parentheses for all the functions"
+	ErrorMessageMissingSource                         = "expected a source path after 'from`"
+	ErrorMessageInaccessibleSource                    = "expected directory path %v to exist. please check the path, also ensure that it is accessible"
+	ErrorMessageSourceNotADirectory                   = "expected source path to be a directory"
+	ErrorMessageInvalidGlobPattern                    = "expected a valid glob pattern in the source, received %v"
+	ErrorMessageExpectedAliasAfterAsInSource          = "expected an alias after 'as' in the source"
+	ErrorMessageMissingAliasInJoin                    = "expected an alias with 'as' for every source in a join"
+	ErrorMessageDuplicateAliasInJoin                  = "expected unique aliases for the sources in a join, %v is used more than once"
+	ErrorMessageMissingJoinAfterJoinType              = "expected 'join' after 'left' or 'inner'"
+	ErrorMessageMissingSourceInJoin                   = "expected a source path after 'join'"
+	ErrorMessageMissingOnInJoin                       = "expected 'on' followed by a condition after the joined source"
+	ErrorMessageInvalidJoinCondition                  = "invalid join condition, please check opening and closing parentheses for all the functions"
+	ErrorMessageInvalidJoinConditionFunctionUsed      = "invalid join condition, 'on' must be followed by a single expression. please check all the functions supported in 'where' clause"
+	ErrorMessageAggregateFunctionInsideJoin           = "invalid join condition, aggregate functions are not supported in the join condition"
+	ErrorMessageDepthValue                            = "expected a depth value after 'depth', for example depth 2 or depth 1 to 3"
+	ErrorMessageDepthValueInt                         = "expected depth to be a positive integer, received %v"
+	ErrorMessageInvalidDepthRange                     = "expected the minimum depth %v to be at most the maximum depth %v"
+	ErrorMessageInvalidKeywordAfterFrom               = "expected either depth or where or group by or having or order by or limit clause after the source directory"
+	ErrorMessageMissingCommaProjection                = "expected a comma in the projection list after a supported attribute or a function. please check the spellings, supported attributes and supported functions as well"
+	ErrorMessageOpeningParenthesesProjection          = "expected an opening parentheses in the projection list after '%v'"
+	ErrorMessageInvalidProjection                     = "invalid projection list, please check the opening and closing parentheses for all the functions"
+	ErrorMessageExpectedAliasAfterAs                  = "expected an alias after 'as' in the projection list"
+	ErrorMessageExpectedExpressionInProjection        = "expected atleast one expression in the projection list. please check the supported attributes and functions"
+	ErrorMessageExpectedExpressionInWhere             = "expected one expression in the where clause, or remove 'where' keyword"
+	ErrorMessageInvalidWhere                          = "invalid where clause, please check opening and closing parentheses for all the functions"
+	ErrorMessageInvalidWhereFunctionUsed              = "invalid where clause, 'where' clause must be a single expression. please check all the functions supported in 'where' clause.\n'where' can be followed by either a 'group by', a 'having', an 'order by' or a 'limit' clause"
+	ErrorMessageAggregateFunctionInsideWhere          = "invalid where clause, aggregate functions are not supported in the where clause"
+	ErrorMessageMissingByAfterGroup                   = "expected 'by' after group"
+	ErrorMessageMissingGroupByExpressions             = "expected an attribute or a function after 'group by'"
+	ErrorMessageMissingCommaGroupBy                   = "expected a comma after an attribute or a function in 'group by'"
+	ErrorMessageAggregateFunctionInsideGroupBy        = "invalid group by clause, aggregate functions are not supported in the group by clause"
+	ErrorMessageInvalidGroupBy                        = "invalid group by clause, please check opening and closing parentheses for all the functions"
+	ErrorMessageInvalidGroupByExpression              = "invalid group by clause, %v is neither a supported attribute nor a function"
+	ErrorMessageNonGroupedExpression                  = "expected %v to be an expression in 'group by' or to be inside an aggregate function"
+	ErrorMessageExpectedExpressionInHaving            = "expected one expression in the having clause, or remove 'having' keyword"
+	ErrorMessageInvalidHaving                         = "invalid having clause, please check opening and closing parentheses for all the functions"
+	ErrorMessageInvalidHavingFunctionUsed             = "invalid having clause, 'having' clause must be a single expression. please check all the functions supported in 'having' clause.\n'having' can be followed by either an 'order by' or a 'limit' clause"
+	ErrorMessageExpectedSelectAfterSetOperator        = "expected a select query after 'union', 'union all', 'except' or 'intersect'"
+	ErrorMessageOrderByOrLimitBeforeSetOperator       = "expected 'order by' and 'limit' only after the last select query in 'union', 'union all', 'except' or 'intersect'"
+	ErrorMessageIncompatibleProjectionsInSetOperation = "expected all the select queries in 'union', 'union all', 'except' or 'intersect' to have the same number of projections"
+	ErrorMessageOrderByNonProjectedInSetOperation     = "expected 'order by' to use positions, aliases or projected attributes with 'union', 'union all', 'except' or 'intersect'"
+	ErrorMessageOrderByNonProjectedWithDistinct       = "expected 'order by' to use positions, aliases or projected attributes with 'select distinct'"
+	ErrorMessageSubqueryNotSupported                  = "subqueries are supported only with 'in' inside the where clause"
+	ErrorMessageInvalidCaseExpression                 = "expected case when <condition> then <expression> [when <condition> then <expression>] [else <expression>] end"
+	ErrorMessageInvalidCastExpression                 = "expected cast(<expression> as <type>)"
+	ErrorMessageWindowFunctionNotSupported            = "window functions with 'over' are supported only as a projection, for example rownumber() over (order by size) as rn"
+	ErrorMessageWindowAliasInsideHaving               = "window function %v can not be used in the having clause, the window functions are evaluated after having"
+	ErrorMessageMissingOverForWindowFunction          = "expected 'over' after the window function %v, for example %v() over (order by size)"
+	ErrorMessageInvalidWindowFunction                 = "expected an aggregate or a window function before 'over', received %v"
+	ErrorMessageInvalidWindow                         = "expected over ([partition by <expression>, ...] [order by <expression> [asc | desc], ...])"
+	ErrorMessageSubqueryWithMultipleProjections       = "expected the subquery inside 'in' to have exactly one projection"
+	ErrorMessageMissingParameterInScalarFunctions     = "expected %v parameter(s) in the function %v but did not receive the required parameter(s)"
+	ErrorMessageIncorrectValueType                    = "expected a %v value type but received %v"
+	ErrorMessageIncorrectEndIndexInSubstring          = "expected the end index to be greater than the from index in the function 'substr'"
+	ErrorMessageIllegalFromToIndexInSubstring         = "expected the from and to index to be positive integers"
+	ErrorMessageExpectedNumericArgument               = "expected numeric type argument value but received %v"
+	ErrorMessageExpectedNonZeroInDivide               = "expected a non zero denominator in divide operation"
+	ErrorMessageFunctionNamePrefixWithExistingError   = "[Function %v], %s"
+	ErrorMessageIncorrectExtractionKey                = "expected either of %v to be passed to 'extract' as an extraction key"
+	ErrorMessageIncorrectRegexGroup                   = "expected the group %v to be an index between 0 and %v or the name of a group in the regular expression %v"
+	ErrorMessageUnsupportedDateTimeFormat             = "expected a supported date/time format id. Use CLI to check the supported date/time format ids"
+	ErrorMessageUnsupportedDateTimeDirective          = "expected the date/time pattern %v to use the directives %v, received %v"
+	ErrorMessageInvalidDateTimeFormatId               = "expected a date/time format id made of letters, digits and _ that does not start with a digit, received %v"
+	ErrorMessageDateTimeFormatIdAlreadyDefined        = "expected a new date/time format id, %v is already defined with a different format"
+	ErrorMessageDateTimeFormatNotParseable            = "expected the date/time pattern %v to have no text that looks like a date/time layout (1, 2, Jan, Mon, PM ..) for parsing, it can still be used in formatdatetime"
+	ErrorMessageNumberComparedWithDuration            = "expected a duration to be compared with a duration like 2h or age, received %v and %v"
+	ErrorMessageIncorrectDateTimeUnit                 = "expected one of %v as the date/time unit, received %v"
+	ErrorMessageExpectedIntegerAmount                 = "expected an integer amount, received %v"
+	ErrorMessageUnsupportedTimeZone                   = "expected a time zone like Europe/Berlin, UTC, Local or an offset like +05:30, received %v"
+	ErrorMessageCannotConvertToBoolean                = "expected conversion of %v to boolean, but failed"
+	ErrorMessageUndefinedConversionFunction           = "expected conversion of %v to %v, but such a conversion is not supported"
+	ErrorMessageCannotCast                            = "expected conversion of %v to %v, but failed"
+	ErrorMessageUnsupportedCastType                   = "expected one of %v as the type to convert to, received %v"
 )
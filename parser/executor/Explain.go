@@ -0,0 +1,266 @@
+package executor
+
+import (
+	"fmt"
+	"goselect/parser/expression"
+	"goselect/parser/projection"
+	"goselect/parser/source"
+	"strings"
+)
+
+type Plan struct {
+	Label    string
+	Children []*Plan
+}
+
+func newPlan(label string, children ...*Plan) *Plan {
+	return &Plan{Label: label, Children: children}
+}
+
+func (plan *Plan) add(children ...*Plan) *Plan {
+	plan.Children = append(plan.Children, children...)
+	return plan
+}
+
+/*
+plan:      projections, source, where, group by, having, set operations, order by, limit and the execution
+names:     attributes and functions are shown by their canonical names, an alias used in the query is shown as "written as"
+execution: the shortcuts that the executor takes for the query, like limit short-circuiting the traversal
+*/
+func (selectQueryExecutor *SelectQueryExecutor) Explain() *Plan {
+	query := selectQueryExecutor.query
+	plan := newPlan("select", selectQueryExecutor.explainProjections(), selectQueryExecutor.explainSource())
+	if query.Where != nil && query.Where.Expression() != nil {
+		plan.add(newPlan("where", selectQueryExecutor.explainExpression(query.Where.Expression())))
+	}
+	if query.IsGroupByDefined() {
+		groupBy := newPlan("group by")
+		for _, anExpression := range query.GroupBy.Expressions().Expressions {
+			groupBy.add(selectQueryExecutor.explainExpression(anExpression))
+		}
+		plan.add(groupBy)
+	}
+	if query.IsHavingDefined() {
+		plan.add(newPlan("having", selectQueryExecutor.explainExpression(query.Having.Expression())))
+	}
+	for _, setOperation := range query.SetOperations {
+		plan.add(newPlan(
+			setOperation.Operator.String(),
+			NewSelectQueryExecutor(setOperation.Query, selectQueryExecutor.context, selectQueryExecutor.options).Explain(),
+		))
+	}
+	if query.IsOrderDefined() {
+		plan.add(selectQueryExecutor.explainOrder())
+	}
+	if query.IsLimitDefined() {
+		plan.add(newPlan(fmt.Sprintf("limit %v offset %v", query.Limit.Limit, query.Limit.Offset)))
+	}
+	return plan.add(selectQueryExecutor.explainExecution())
+}
+
+func (selectQueryExecutor *SelectQueryExecutor) explainProjections() *Plan {
+	projections := selectQueryExecutor.query.Projections
+	plan := newPlan("projections")
+	if projections.IsDistinct() {
+		plan.Label = "projections distinct"
+	}
+	for index, anExpression := range projections.Expressions().Expressions {
+		label := fmt.Sprintf("column %v", index+1)
+		if alias := projections.AliasAt(index); len(alias) > 0 {
+			label = label + " as " + alias
+		}
+		if index >= projections.Count() {
+			label = fmt.Sprintf("hidden column %v, used only by order by", index+1)
+			if position, ok := windowPositionOf(projections.Windows(), index+1); ok {
+				label = fmt.Sprintf("hidden column %v, used only by the window function in column %v", index+1, position)
+			}
+		}
+		plan.add(newPlan(label, selectQueryExecutor.explainExpression(anExpression)))
+	}
+	return plan
+}
+
+func (selectQueryExecutor *SelectQueryExecutor) explainSource() *Plan {
+	fileSource := selectQueryExecutor.query.Source
+	plan := newPlan("source")
+	if len(fileSource.Alias) > 0 {
+		plan.Label = "source as " + fileSource.Alias
+	}
+	for index, directory := range fileSource.Directories {
+		if glob := fileSource.GlobAt(index); glob != nil {
+			plan.add(newPlan(
+				"directory "+directory,
+				newPlan("glob "+glob.Pattern+", only the directories that can match the glob are read"),
+			))
+			continue
+		}
+		plan.add(newPlan("directory " + directory))
+	}
+	joins := newJoins(fileSource.Alias, selectQueryExecutor.context.AllFunctions())
+	for index, join := range fileSource.Joins {
+		_ = joins.add(join, nil)
+		strategy := "nested loop, the condition is evaluated for every pair of files"
+		if keys := len(joins.hashJoins[index].rightKeys); keys > 0 {
+			strategy = fmt.Sprintf("hash join on %v equality key(s), the condition is evaluated for the matching files", keys)
+		}
+		joinType := "join"
+		if join.Type == source.JoinTypeLeft {
+			joinType = "left join"
+		}
+		plan.add(newPlan(
+			fmt.Sprintf("%v %v as %v", joinType, join.Directory, join.Alias),
+			newPlan(strategy),
+			newPlan("on", selectQueryExecutor.explainExpression(join.On)),
+		))
+	}
+	if fileSource.IsDepthDefined() {
+		depth := fmt.Sprintf("depth up to %v", fileSource.Depth.Max)
+		if fileSource.Depth.Min > 0 {
+			depth = fmt.Sprintf("depth from %v to %v", fileSource.Depth.Min, fileSource.Depth.Max)
+		}
+		plan.add(newPlan(depth))
+	}
+	return plan
+}
+
+func (selectQueryExecutor *SelectQueryExecutor) explainOrder() *Plan {
+	query := selectQueryExecutor.query
+	attributes := query.Projections.Expressions().DisplayableAttributes()
+	plan := newPlan("order by")
+	for index, attribute := range query.Order.Attributes {
+		direction := "descending"
+		if query.Order.IsAscendingAt(index) {
+			direction = "ascending"
+		}
+		plan.add(newPlan(fmt.Sprintf(
+			"column %v (%v) %v",
+			attribute.ProjectionPosition,
+			attributes[attribute.ProjectionPosition-1],
+			direction,
+		)))
+	}
+	return plan
+}
+
+func (selectQueryExecutor *SelectQueryExecutor) explainExpression(anExpression *expression.Expression) *Plan {
+	if attribute := anExpression.Attribute(); len(attribute) > 0 {
+		return newPlan("attribute " + writtenAs(selectQueryExecutor.context.AllAttributes().CanonicalNameOf(attribute), attribute))
+	}
+	functionName := anExpression.FunctionName()
+	if len(functionName) == 0 {
+		return newPlan("value " + anExpression.DisplayableValue())
+	}
+	kind := "scalar"
+	if anExpression.IsAnAggregateFunction() {
+		kind = "aggregate"
+	}
+	if anExpression.IsAWindowFunction() {
+		kind = "window"
+	}
+	canonicalName := selectQueryExecutor.context.AllFunctions().CanonicalNameOf(functionName)
+	plan := newPlan(kind + " function " + writtenAs(canonicalName, functionName))
+	for _, arg := range anExpression.FunctionArgs() {
+		plan.add(selectQueryExecutor.explainExpression(arg))
+	}
+	if window := anExpression.Window(); window != nil {
+		plan.add(selectQueryExecutor.explainWindow(window))
+	}
+	if subquery := anExpression.FunctionSubquery(); subquery != nil {
+		for _, parsedSubquery := range selectQueryExecutor.query.Subqueries {
+			if parsedSubquery.Values == subquery.Values() {
+				plan.add(newPlan("subquery", NewSelectQueryExecutor(parsedSubquery.Query, selectQueryExecutor.context, selectQueryExecutor.options).Explain()))
+			}
+		}
+	}
+	return plan
+}
+
+func (selectQueryExecutor *SelectQueryExecutor) explainWindow(window *expression.Window) *Plan {
+	plan := newPlan("over")
+	if len(window.PartitionBy()) == 0 {
+		plan.add(newPlan("a single partition of all the rows"))
+	} else {
+		partitionBy := newPlan("partition by")
+		for _, anExpression := range window.PartitionBy() {
+			partitionBy.add(selectQueryExecutor.explainExpression(anExpression))
+		}
+		plan.add(partitionBy)
+	}
+	if len(window.OrderBy()) > 0 {
+		orderBy := newPlan("order by")
+		for index, anExpression := range window.OrderBy() {
+			direction := newPlan("descending", selectQueryExecutor.explainExpression(anExpression))
+			if window.IsAscendingAt(index) {
+				direction.Label = "ascending"
+			}
+			orderBy.add(direction)
+		}
+		plan.add(orderBy)
+	}
+	return plan
+}
+
+func (selectQueryExecutor *SelectQueryExecutor) explainExecution() *Plan {
+	query := selectQueryExecutor.query
+	plan := newPlan("execution")
+	switch {
+	case query.IsCombined():
+		plan.add(newPlan("every query of the set operations is executed in full, order by and limit apply to the combined rows"))
+	case selectQueryExecutor.hasOnlyAggregates():
+		plan.add(newPlan("only aggregate functions without group by, the traversal produces a single row (limit 1)"))
+	case query.IsLimitDefined() && selectQueryExecutor.canStopTraversalEarly():
+		plan.add(newPlan(fmt.Sprintf("limit short-circuits the traversal once %v rows after an offset of %v are collected", query.Limit.Limit, query.Limit.Offset)))
+	case query.IsLimitDefined():
+		plan.add(newPlan("limit applies after the traversal, order by, group by or aggregate functions need every file"))
+	default:
+		plan.add(newPlan("every file is traversed"))
+	}
+	if selectQueryExecutor.isDistinct() {
+		plan.add(newPlan("distinct rows are kept by their projected values"))
+	}
+	if query.Projections.HasWindows() {
+		plan.add(newPlan("window functions are evaluated over their ordered partitions after the traversal"))
+	}
+	if len(query.Subqueries) > 0 {
+		plan.add(newPlan("every subquery is executed once before the traversal"))
+	}
+	if query.Source.IsJoined() {
+		plan.add(newPlan("the joined directories are traversed in full before the traversal of the source"))
+	}
+	if selectQueryExecutor.options.IsNestedTraversalEnabled() {
+		plan.add(newPlan("nested directories are traversed"))
+	} else {
+		plan.add(newPlan("nested directories are not traversed"))
+	}
+	minDepth, maxDepth := selectQueryExecutor.depthLimits()
+	if maxDepth > 0 {
+		plan.add(newPlan(fmt.Sprintf("directories past depth %v are not read", maxDepth)))
+	}
+	if minDepth > 1 {
+		plan.add(newPlan(fmt.Sprintf("files at a depth less than %v are traversed but not chosen", minDepth)))
+	}
+	if directories := selectQueryExecutor.options.DirectoriesIgnoredInTraversal(); len(directories) > 0 {
+		plan.add(newPlan("directories skipped in the traversal: " + strings.Join(directories, ", ")))
+	}
+	return plan
+}
+
+func windowPositionOf(windows []*projection.WindowColumn, position int) (int, bool) {
+	for _, window := range windows {
+		for _, positions := range [][]int{window.ArgPositions, window.PartitionPositions, window.OrderPositions} {
+			for _, aPosition := range positions {
+				if aPosition == position {
+					return window.Position, true
+				}
+			}
+		}
+	}
+	return -1, false
+}
+
+func writtenAs(canonicalName string, name string) string {
+	if len(canonicalName) == 0 || strings.EqualFold(canonicalName, name) {
+		return name
+	}
+	return canonicalName + " (written as " + name + ")"
+}
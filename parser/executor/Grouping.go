@@ -0,0 +1,101 @@
+package executor
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/expression"
+	"goselect/parser/groupby"
+	"goselect/parser/having"
+	"goselect/parser/projection"
+)
+
+type Grouping struct {
+	groupBy     *groupby.GroupBy
+	projections *projection.Projections
+	having      *having.Having
+	functions   *context.AllFunctions
+	groupsByKey map[string]*group
+	groups      []*group
+}
+
+type group struct {
+	projections    *projection.Projections
+	having         *having.Having
+	fileAttributes *context.FileAttributes
+	values         []context.Value
+	fullyEvaluated []bool
+	expressions    []*expression.Expression
+}
+
+func newGrouping(
+	groupBy *groupby.GroupBy,
+	projections *projection.Projections,
+	having *having.Having,
+	functions *context.AllFunctions,
+) *Grouping {
+	return &Grouping{
+		groupBy:     groupBy,
+		projections: projections,
+		having:      having,
+		functions:   functions,
+		groupsByKey: make(map[string]*group),
+	}
+}
+
+func (grouping *Grouping) add(fileAttributes *context.FileAttributes) error {
+	key, err := grouping.keyFor(fileAttributes)
+	if err != nil {
+		return err
+	}
+	aGroup, exists := grouping.groupsByKey[key]
+	if !exists {
+		aGroup = grouping.newGroup(fileAttributes)
+		grouping.groupsByKey[key] = aGroup
+		grouping.groups = append(grouping.groups, aGroup)
+	}
+	values, fullyEvaluated, expressions, err := aGroup.projections.EvaluateWith(fileAttributes, grouping.functions)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		aGroup.values, aGroup.fullyEvaluated, aGroup.expressions = values, fullyEvaluated, expressions
+	}
+	if aGroup.having != nil {
+		return aGroup.having.EvaluateWith(fileAttributes, grouping.functions)
+	}
+	return nil
+}
+
+func (grouping *Grouping) addRowsTo(rows *EvaluatingRows) error {
+	for _, aGroup := range grouping.groups {
+		if aGroup.having != nil {
+			passesHaving, err := aGroup.having.FullyEvaluate(aGroup.fileAttributes, grouping.functions)
+			if err != nil {
+				return err
+			}
+			if !passesHaving {
+				continue
+			}
+		}
+		rows.addRow(aGroup.values, aGroup.fullyEvaluated, aGroup.expressions, aGroup.fileAttributes)
+	}
+	return nil
+}
+
+func (grouping *Grouping) keyFor(fileAttributes *context.FileAttributes) (string, error) {
+	if grouping.groupBy == nil {
+		return "", nil
+	}
+	_, key, err := grouping.groupBy.EvaluateWith(fileAttributes, grouping.functions)
+	return key, err
+}
+
+func (grouping *Grouping) newGroup(fileAttributes *context.FileAttributes) *group {
+	aGroup := &group{
+		projections:    grouping.projections.Clone(grouping.functions),
+		fileAttributes: fileAttributes,
+	}
+	if grouping.having != nil {
+		aGroup.having = grouping.having.Clone(grouping.functions)
+	}
+	return aGroup
+}
@@ -0,0 +1,167 @@
+package executor
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/expression"
+	"goselect/parser/source"
+	"strings"
+)
+
+type joins struct {
+	alias     string
+	aliases   map[string]bool
+	hashJoins []*hashJoin
+	functions *context.AllFunctions
+}
+
+type hashJoin struct {
+	join      *source.Join
+	rows      []*context.FileAttributes
+	leftKeys  []*expression.Expression
+	rightKeys []*expression.Expression
+	rowsByKey map[string][]*context.FileAttributes
+}
+
+func newJoins(alias string, functions *context.AllFunctions) *joins {
+	return &joins{
+		alias:     alias,
+		aliases:   map[string]bool{strings.ToLower(alias): true},
+		functions: functions,
+	}
+}
+
+func (joins *joins) add(join *source.Join, rows []*context.FileAttributes) error {
+	hashJoin := &hashJoin{join: join, rows: rows}
+	for _, conjunct := range joins.conjunctsOf(join.On) {
+		if joins.functions.CanonicalNameOf(conjunct.FunctionName()) != context.FunctionNameEqual || len(conjunct.FunctionArgs()) != 2 {
+			continue
+		}
+		left, right := conjunct.FunctionArgs()[0], conjunct.FunctionArgs()[1]
+		if joins.isOnlyOn(left, join.Alias) && joins.isPreceding(right) {
+			left, right = right, left
+		}
+		if joins.isPreceding(left) && joins.isOnlyOn(right, join.Alias) {
+			hashJoin.leftKeys = append(hashJoin.leftKeys, left)
+			hashJoin.rightKeys = append(hashJoin.rightKeys, right)
+		}
+	}
+	if len(hashJoin.rightKeys) > 0 {
+		hashJoin.rowsByKey = make(map[string][]*context.FileAttributes)
+		for _, row := range rows {
+			key, err := joins.keyOf(hashJoin.rightKeys, row.Qualified(join.Alias))
+			if err != nil {
+				return err
+			}
+			hashJoin.rowsByKey[key] = append(hashJoin.rowsByKey[key], row)
+		}
+	}
+	joins.hashJoins = append(joins.hashJoins, hashJoin)
+	joins.aliases[strings.ToLower(join.Alias)] = true
+	return nil
+}
+
+func (joins *joins) each(fileAttributes *context.FileAttributes, visit func(*context.FileAttributes) error) error {
+	if len(joins.alias) == 0 {
+		return visit(fileAttributes)
+	}
+	return joins.joinFrom(0, fileAttributes.Qualified(joins.alias), visit)
+}
+
+func (joins *joins) joinFrom(index int, left *context.FileAttributes, visit func(*context.FileAttributes) error) error {
+	if index == len(joins.hashJoins) {
+		return visit(left)
+	}
+	hashJoin := joins.hashJoins[index]
+	candidates, err := joins.candidatesFor(hashJoin, left)
+	if err != nil {
+		return err
+	}
+	isMatched := false
+	for _, right := range candidates {
+		joined := left.JoinWith(hashJoin.join.Alias, right)
+		value, err, _ := hashJoin.join.On.Evaluate(joined, joins.functions)
+		if err != nil {
+			return err
+		}
+		matches, err := value.GetBoolean()
+		if err != nil {
+			return err
+		}
+		if matches {
+			isMatched = true
+			if err := joins.joinFrom(index+1, joined, visit); err != nil {
+				return err
+			}
+		}
+	}
+	if !isMatched && hashJoin.join.IsLeft() {
+		return joins.joinFrom(index+1, left.JoinWith(hashJoin.join.Alias, nil), visit)
+	}
+	return nil
+}
+
+func (joins *joins) candidatesFor(hashJoin *hashJoin, left *context.FileAttributes) ([]*context.FileAttributes, error) {
+	if len(hashJoin.leftKeys) == 0 {
+		return hashJoin.rows, nil
+	}
+	key, err := joins.keyOf(hashJoin.leftKeys, left)
+	if err != nil {
+		return nil, err
+	}
+	return hashJoin.rowsByKey[key], nil
+}
+
+func (joins *joins) keyOf(keys []*expression.Expression, fileAttributes *context.FileAttributes) (string, error) {
+	var key strings.Builder
+	for _, anExpression := range keys {
+		value, err, _ := anExpression.Evaluate(fileAttributes, joins.functions)
+		if err != nil {
+			return "", err
+		}
+		key.WriteString(value.GetAsKey())
+		key.WriteString(distinctKeySeparator)
+	}
+	return key.String(), nil
+}
+
+func (joins *joins) conjunctsOf(anExpression *expression.Expression) []*expression.Expression {
+	if joins.functions.CanonicalNameOf(anExpression.FunctionName()) != context.FunctionNameAnd {
+		return []*expression.Expression{anExpression}
+	}
+	var conjuncts []*expression.Expression
+	for _, arg := range anExpression.FunctionArgs() {
+		conjuncts = append(conjuncts, joins.conjunctsOf(arg)...)
+	}
+	return conjuncts
+}
+
+func (joins *joins) isPreceding(anExpression *expression.Expression) bool {
+	for _, qualifier := range joins.qualifiersOf(anExpression) {
+		if !joins.aliases[qualifier] {
+			return false
+		}
+	}
+	return true
+}
+
+func (joins *joins) isOnlyOn(anExpression *expression.Expression, alias string) bool {
+	qualifiers := joins.qualifiersOf(anExpression)
+	for _, qualifier := range qualifiers {
+		if qualifier != strings.ToLower(alias) {
+			return false
+		}
+	}
+	return len(qualifiers) > 0
+}
+
+func (joins *joins) qualifiersOf(anExpression *expression.Expression) []string {
+	var qualifiers []string
+	for _, attribute := range anExpression.Attributes() {
+		if qualifier, _, ok := context.QualifiedAttribute(attribute); ok {
+			qualifiers = append(qualifiers, strings.ToLower(qualifier))
+		} else {
+			qualifiers = append(qualifiers, strings.ToLower(joins.alias))
+		}
+	}
+	return qualifiers
+}
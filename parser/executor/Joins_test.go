@@ -0,0 +1,59 @@
+//go:build unit
+// +build unit
+
+package executor
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/expression"
+	"goselect/parser/source"
+	"goselect/parser/tokenizer"
+	"testing"
+)
+
+func joinOn(condition string) *source.Join {
+	iterator := tokenizer.NewTokenizer(condition).Tokenize().Iterator()
+	ctx := context.NewContext(context.NewFunctions(), context.NewAttributes()).WithSourceAliases([]string{"a", "b"})
+	on, _ := expression.NewParser(iterator, ctx, expression.ParsingRules{}).ParseFrom(iterator.Next())
+	return &source.Join{Alias: "b", On: on}
+}
+
+func TestJoinWithHashKeysFromEquality(t *testing.T) {
+	joins := newJoins("a", context.NewFunctions())
+	_ = joins.add(joinOn("b.name = lower(a.name) and gt(a.size, 1)"), nil)
+
+	hashJoin := joins.hashJoins[0]
+	if len(hashJoin.leftKeys) != 1 || len(hashJoin.rightKeys) != 1 {
+		t.Fatalf("Expected one hash key on each side, received %v and %v", len(hashJoin.leftKeys), len(hashJoin.rightKeys))
+	}
+	if hashJoin.leftKeys[0].Attributes()[0] != "a.name" || hashJoin.rightKeys[0].Attributes()[0] != "b.name" {
+		t.Fatalf("Expected the hash keys to be a.name and b.name, received %v and %v", hashJoin.leftKeys[0].Attributes(), hashJoin.rightKeys[0].Attributes())
+	}
+}
+
+func TestJoinWithHashKeysFromEqualityWithUnqualifiedAttributes(t *testing.T) {
+	joins := newJoins("a", context.NewFunctions())
+	_ = joins.add(joinOn("equal(name, b.name)"), nil)
+
+	if len(joins.hashJoins[0].leftKeys) != 1 {
+		t.Fatalf("Expected one hash key, received %v", len(joins.hashJoins[0].leftKeys))
+	}
+}
+
+func TestJoinWithoutHashKeysGivenNoEquality(t *testing.T) {
+	joins := newJoins("a", context.NewFunctions())
+	_ = joins.add(joinOn("gt(a.size, b.size)"), nil)
+
+	if len(joins.hashJoins[0].leftKeys) != 0 {
+		t.Fatalf("Expected no hash keys, received %v", len(joins.hashJoins[0].leftKeys))
+	}
+}
+
+func TestJoinWithoutHashKeysGivenAnEqualityOnTheSameSource(t *testing.T) {
+	joins := newJoins("a", context.NewFunctions())
+	_ = joins.add(joinOn("eq(b.name, b.basename) or eq(a.name, b.name)"), nil)
+
+	if len(joins.hashJoins[0].leftKeys) != 0 {
+		t.Fatalf("Expected no hash keys, received %v", len(joins.hashJoins[0].leftKeys))
+	}
+}
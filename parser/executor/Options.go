@@ -1,12 +1,15 @@
 package executor
 
 import (
+	"sort"
 	"strings"
 )
 
 type Options struct {
 	traverseNestedDirectories    bool
 	directoriesToIgnoreTraversal map[string]bool
+	minDepth                     uint32
+	maxDepth                     uint32
 }
 
 func NewDefaultOptions() *Options {
@@ -32,6 +35,37 @@ func (options *Options) DirectoriesToIgnoreTraversal(names []string) *Options {
 	return options
 }
 
+func (options *Options) MinDepth(depth uint32) *Options {
+	options.minDepth = depth
+	return options
+}
+
+func (options *Options) MaxDepth(depth uint32) *Options {
+	options.maxDepth = depth
+	return options
+}
+
+func (options Options) IsNestedTraversalEnabled() bool {
+	return options.traverseNestedDirectories
+}
+
+func (options Options) DirectoriesIgnoredInTraversal() []string {
+	var directories []string
+	for directory := range options.directoriesToIgnoreTraversal {
+		directories = append(directories, directory)
+	}
+	sort.Strings(directories)
+	return directories
+}
+
 func (options Options) IsDirectoryTraversalIgnored(name string) bool {
 	return options.directoriesToIgnoreTraversal[strings.ToLower(name)]
 }
+
+func (options Options) MinTraversalDepth() uint32 {
+	return options.minDepth
+}
+
+func (options Options) MaxTraversalDepth() uint32 {
+	return options.maxDepth
+}
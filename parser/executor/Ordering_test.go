@@ -7,6 +7,7 @@ import (
 	"goselect/parser/context"
 	"goselect/parser/expression"
 	"goselect/parser/order"
+	"goselect/parser/projection"
 	"goselect/parser/tokenizer"
 	"testing"
 )
@@ -17,12 +18,12 @@ func TestAscendingOrderWithASingleColumn(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
 
-	anOrder, _ := order.NewOrder(tokens.Iterator(), 1)
+	anOrder, _ := order.NewOrder(tokens.Iterator(), projectionsWithCount(1), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 
 	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(newContext.AllFunctions(), 2)
-	rows.addRow([]context.Value{context.StringValue("fileA")}, []bool{true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("fileB")}, []bool{true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("fileA")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("fileB")}, []bool{true}, []*expression.Expression{}, nil)
 
 	expected := [][]context.Value{
 		{context.StringValue("fileA")},
@@ -43,13 +44,13 @@ func TestAscendingOrderWith2Columns(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 
-	anOrder, _ := order.NewOrder(tokens.Iterator(), 2)
+	anOrder, _ := order.NewOrder(tokens.Iterator(), projectionsWithCount(2), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 
 	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(newContext.AllFunctions(), 3)
-	rows.addRow([]context.Value{context.StringValue("fileB"), context.IntValue(10)}, []bool{true, true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(20)}, []bool{true, true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(30)}, []bool{true, true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("fileB"), context.IntValue(10)}, []bool{true, true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(20)}, []bool{true, true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(30)}, []bool{true, true}, []*expression.Expression{}, nil)
 
 	expected := [][]context.Value{
 		{context.StringValue("fileA"), context.IntValue(20)},
@@ -70,12 +71,12 @@ func TestDescendingOrderWithASingleColumn(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "desc"))
 
-	anOrder, _ := order.NewOrder(tokens.Iterator(), 1)
+	anOrder, _ := order.NewOrder(tokens.Iterator(), projectionsWithCount(1), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 
 	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(newContext.AllFunctions(), 2)
-	rows.addRow([]context.Value{context.StringValue("fileA")}, []bool{true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("fileB")}, []bool{true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("fileA")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("fileB")}, []bool{true}, []*expression.Expression{}, nil)
 
 	expected := [][]context.Value{
 		{context.StringValue("fileB")},
@@ -98,13 +99,13 @@ func TestDescendingOrderWith2Columns(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "desc"))
 
-	anOrder, _ := order.NewOrder(tokens.Iterator(), 2)
+	anOrder, _ := order.NewOrder(tokens.Iterator(), projectionsWithCount(2), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 
 	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(newContext.AllFunctions(), 3)
-	rows.addRow([]context.Value{context.StringValue("fileB"), context.IntValue(10)}, []bool{true, true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(20)}, []bool{true, true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(30)}, []bool{true, true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("fileB"), context.IntValue(10)}, []bool{true, true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(20)}, []bool{true, true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(30)}, []bool{true, true}, []*expression.Expression{}, nil)
 
 	expected := [][]context.Value{
 		{context.StringValue("fileB"), context.IntValue(10)},
@@ -128,13 +129,13 @@ func TestMixOrderWith2Columns(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "desc"))
 
-	anOrder, _ := order.NewOrder(tokens.Iterator(), 2)
+	anOrder, _ := order.NewOrder(tokens.Iterator(), projectionsWithCount(2), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 
 	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(newContext.AllFunctions(), 3)
-	rows.addRow([]context.Value{context.StringValue("fileB"), context.IntValue(10)}, []bool{true, true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(20)}, []bool{true, true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(30)}, []bool{true, true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("fileB"), context.IntValue(10)}, []bool{true, true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(20)}, []bool{true, true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("fileA"), context.IntValue(30)}, []bool{true, true}, []*expression.Expression{}, nil)
 
 	expected := [][]context.Value{
 		{context.StringValue("fileA"), context.IntValue(30)},
@@ -147,3 +148,15 @@ func TestMixOrderWith2Columns(t *testing.T) {
 
 	AssertMatch(t, expected, rows)
 }
+
+func projectionsWithCount(count int) *projection.Projections {
+	tokens := tokenizer.NewEmptyTokens()
+	for index := 0; index < count; index++ {
+		if index > 0 {
+			tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+		}
+		tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	}
+	projections, _ := projection.NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	return projections
+}
@@ -3,17 +3,24 @@ package executor
 import (
 	"goselect/parser/context"
 	"goselect/parser/expression"
+	"strings"
 )
 
+const distinctKeySeparator = "\x1f"
+
 type EvaluatingRows struct {
-	rows      []*EvaluatingRow
-	functions *context.AllFunctions
-	limit     uint32
+	rows               []*EvaluatingRow
+	functions          *context.AllFunctions
+	limit              uint32
+	offset             uint32
+	distinctAttributes int
+	distinctKeys       map[string]bool
 }
 
 type RowsIterator struct {
 	currentIndex uint32
 	limit        uint32
+	offset       uint32
 	rows         []*EvaluatingRow
 }
 
@@ -21,24 +28,79 @@ type EvaluatingRow struct {
 	attributeValues []context.Value
 	fullyEvaluated  []bool
 	expressions     []*expression.Expression
+	fileAttributes  *context.FileAttributes
 	functions       *context.AllFunctions
 }
 
 func emptyRows(functions *context.AllFunctions, limit uint32) *EvaluatingRows {
-	return &EvaluatingRows{functions: functions, limit: limit}
+	return emptyRowsWithOffset(functions, limit, 0)
+}
+
+func emptyRowsWithOffset(functions *context.AllFunctions, limit uint32, offset uint32) *EvaluatingRows {
+	return &EvaluatingRows{functions: functions, limit: limit, offset: offset}
 }
 
-func (rows *EvaluatingRows) addRow(attributeValues []context.Value, fullyEvaluated []bool, expressions []*expression.Expression) *EvaluatingRow {
+func (rows *EvaluatingRows) distinctOn(attributeCount int) {
+	rows.distinctAttributes = attributeCount
+	rows.distinctKeys = make(map[string]bool)
+}
+
+func (rows *EvaluatingRows) addRow(
+	attributeValues []context.Value,
+	fullyEvaluated []bool,
+	expressions []*expression.Expression,
+	fileAttributes *context.FileAttributes,
+) *EvaluatingRow {
 	row := &EvaluatingRow{
 		attributeValues: attributeValues,
 		fullyEvaluated:  fullyEvaluated,
 		expressions:     expressions,
+		fileAttributes:  fileAttributes,
 		functions:       rows.functions,
 	}
+	if rows.distinctAttributes > 0 {
+		key := row.keyOf(rows.distinctAttributes)
+		if rows.distinctKeys[key] {
+			return row
+		}
+		rows.distinctKeys[key] = true
+	}
 	rows.rows = append(rows.rows, row)
 	return row
 }
 
+func (rows *EvaluatingRows) retainDistinct(attributeCount int) {
+	allRows := rows.rows
+	rows.rows = nil
+	rows.distinctOn(attributeCount)
+	for _, row := range allRows {
+		key := row.keyOf(attributeCount)
+		if !rows.distinctKeys[key] {
+			rows.distinctKeys[key] = true
+			rows.rows = append(rows.rows, row)
+		}
+	}
+}
+
+func (rows *EvaluatingRows) retainFirstRow() {
+	if len(rows.rows) > 1 {
+		rows.rows = rows.rows[:1]
+	}
+}
+
+func (rows *EvaluatingRows) retainAttributes(count int) {
+	for _, row := range rows.rows {
+		row.AllAttributes()
+		if count < len(row.attributeValues) {
+			row.attributeValues = row.attributeValues[:count]
+			row.fullyEvaluated = row.fullyEvaluated[:count]
+		}
+		if count < len(row.expressions) {
+			row.expressions = row.expressions[:count]
+		}
+	}
+}
+
 func (rows EvaluatingRows) Count() uint32 {
 	minOf := func(a, b uint32) uint32 {
 		if a < b {
@@ -46,27 +108,30 @@ func (rows EvaluatingRows) Count() uint32 {
 		}
 		return b
 	}
-	return minOf(uint32(len(rows.rows)), rows.limit)
+	if uint32(len(rows.rows)) <= rows.offset {
+		return 0
+	}
+	return minOf(uint32(len(rows.rows))-rows.offset, rows.limit)
 }
 
 func (rows *EvaluatingRows) RowIterator() *RowsIterator {
-	return &RowsIterator{currentIndex: 0, limit: rows.limit, rows: rows.rows}
+	return &RowsIterator{currentIndex: 0, limit: rows.limit, offset: rows.offset, rows: rows.rows}
 }
 
 func (rows EvaluatingRows) AtIndex(index int) *EvaluatingRow {
-	if index < len(rows.rows) {
-		return rows.rows[index]
+	if index+int(rows.offset) < len(rows.rows) {
+		return rows.rows[index+int(rows.offset)]
 	}
 	return &EvaluatingRow{}
 }
 
 func (rowsIterator *RowsIterator) HasNext() bool {
-	return rowsIterator.currentIndex < uint32(len(rowsIterator.rows)) &&
+	return rowsIterator.currentIndex+rowsIterator.offset < uint32(len(rowsIterator.rows)) &&
 		rowsIterator.currentIndex+1 <= rowsIterator.limit
 }
 
 func (rowsIterator *RowsIterator) Next() *EvaluatingRow {
-	row := rowsIterator.rows[rowsIterator.currentIndex]
+	row := rowsIterator.rows[rowsIterator.currentIndex+rowsIterator.offset]
 	rowsIterator.currentIndex = rowsIterator.currentIndex + 1
 	return row
 }
@@ -77,7 +142,7 @@ func (row *EvaluatingRow) AllAttributes() []context.Value {
 		if row.fullyEvaluated[index] {
 			values = append(values, attributeValue)
 		} else {
-			value, err := row.expressions[index].FullyEvaluate(row.functions)
+			value, err := row.expressions[index].FullyEvaluateWith(row.fileAttributes, row.functions)
 			if err != nil {
 				value = context.StringValue(err.Error())
 			}
@@ -89,6 +154,18 @@ func (row *EvaluatingRow) AllAttributes() []context.Value {
 	return values
 }
 
+func (row *EvaluatingRow) keyOf(attributeCount int) string {
+	var key strings.Builder
+	for index, attribute := range row.AllAttributes() {
+		if index >= attributeCount {
+			break
+		}
+		key.WriteString(attribute.GetAsKey())
+		key.WriteString(distinctKeySeparator)
+	}
+	return key.String()
+}
+
 func (row EvaluatingRow) TotalAttributes() int {
 	return len(row.attributeValues)
 }
@@ -14,7 +14,7 @@ import (
 func TestEvaluatingRowAllAttributesThatAreFullyEvaluated(t *testing.T) {
 	_ = context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(context.NewFunctions(), 1)
-	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{}, nil)
 
 	attributes := rows.AtIndex(0).AllAttributes()
 	expected := []context.Value{context.StringValue("someValue")}
@@ -27,7 +27,7 @@ func TestEvaluatingRowAllAttributesThatAreFullyEvaluated(t *testing.T) {
 func TestEvaluatingRowAtAnIndexGreaterThanTotalNumberOfRows(t *testing.T) {
 	_ = context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(context.NewFunctions(), 1)
-	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{}, nil)
 
 	row := rows.AtIndex(1)
 	if len(row.attributeValues) != 0 {
@@ -38,7 +38,7 @@ func TestEvaluatingRowAtAnIndexGreaterThanTotalNumberOfRows(t *testing.T) {
 func TestEvaluatingRowCount(t *testing.T) {
 	_ = context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(context.NewFunctions(), 1)
-	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{}, nil)
 
 	count := rows.Count()
 	expected := uint32(1)
@@ -51,7 +51,7 @@ func TestEvaluatingRowCount(t *testing.T) {
 func TestEvaluatingRowIterator(t *testing.T) {
 	_ = context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(context.NewFunctions(), 1)
-	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{}, nil)
 
 	attributes := rows.RowIterator().Next().AllAttributes()
 	expected := []context.Value{context.StringValue("someValue")}
@@ -64,7 +64,7 @@ func TestEvaluatingRowIterator(t *testing.T) {
 func TestEvaluatingRowIteratorHasNextWithAnAvailableRow(t *testing.T) {
 	_ = context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(context.NewFunctions(), 1)
-	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{}, nil)
 
 	hasNext := rows.RowIterator().HasNext()
 	if hasNext != true {
@@ -75,9 +75,9 @@ func TestEvaluatingRowIteratorHasNextWithAnAvailableRow(t *testing.T) {
 func TestEvaluatingRowIteratorHasNextWithLimit(t *testing.T) {
 	_ = context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(context.NewFunctions(), 2)
-	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{})
-	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{}, nil)
 
 	iterator := rows.RowIterator()
 
@@ -112,7 +112,7 @@ func TestEvaluatingRowIteratorHasNextWithNoAvailableRows(t *testing.T) {
 func TestEvaluatingRowTotalAttributes(t *testing.T) {
 	_ = context.NewContext(context.NewFunctions(), context.NewAttributes())
 	rows := emptyRows(context.NewFunctions(), 1)
-	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{})
+	rows.addRow([]context.Value{context.StringValue("someValue")}, []bool{true}, []*expression.Expression{}, nil)
 
 	totalAttributes := rows.RowIterator().Next().TotalAttributes()
 	if totalAttributes != 1 {
@@ -135,6 +135,7 @@ func TestEvaluatingRowFullyEvaluationOfAnAttribute(t *testing.T) {
 				true,
 			)),
 		},
+		nil,
 	)
 
 	values := rows.RowIterator().Next().AllAttributes()
@@ -150,3 +151,92 @@ func TestEvaluatingRowFullyEvaluationOfAnAttribute(t *testing.T) {
 		)
 	}
 }
+
+func TestEvaluatingRowsRetainAttributes(t *testing.T) {
+	rows := emptyRows(context.NewFunctions(), 1)
+	rows.addRow(
+		[]context.Value{context.StringValue("someValue"), context.StringValue("hidden")},
+		[]bool{true, true},
+		[]*expression.Expression{},
+		nil,
+	)
+	rows.retainAttributes(1)
+
+	attributes := rows.AtIndex(0).AllAttributes()
+	expected := []context.Value{context.StringValue("someValue")}
+
+	if !reflect.DeepEqual(expected, attributes) {
+		t.Fatalf("Expected attributes to be %v, received %v", expected, attributes)
+	}
+}
+
+func TestEvaluatingRowCountWithOffset(t *testing.T) {
+	rows := emptyRowsWithOffset(context.NewFunctions(), 2, 2)
+	rows.addRow([]context.Value{context.StringValue("first")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("second")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("third")}, []bool{true}, []*expression.Expression{}, nil)
+
+	if rows.Count() != 1 {
+		t.Fatalf("Expected count to be %v, received %v", 1, rows.Count())
+	}
+}
+
+func TestEvaluatingRowCountWithOffsetBeyondTotalRows(t *testing.T) {
+	rows := emptyRowsWithOffset(context.NewFunctions(), 2, 5)
+	rows.addRow([]context.Value{context.StringValue("first")}, []bool{true}, []*expression.Expression{}, nil)
+
+	if rows.Count() != 0 {
+		t.Fatalf("Expected count to be %v, received %v", 0, rows.Count())
+	}
+	if rows.RowIterator().HasNext() {
+		t.Fatalf("Expected no rows using row iterator but hasNext returned true")
+	}
+}
+
+func TestEvaluatingRowIteratorWithOffset(t *testing.T) {
+	rows := emptyRowsWithOffset(context.NewFunctions(), 1, 1)
+	rows.addRow([]context.Value{context.StringValue("first")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("second")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("third")}, []bool{true}, []*expression.Expression{}, nil)
+
+	iterator := rows.RowIterator()
+	attributes := iterator.Next().AllAttributes()
+	expected := []context.Value{context.StringValue("second")}
+
+	if !reflect.DeepEqual(expected, attributes) {
+		t.Fatalf("Expected attributes to be %v, received %v", expected, attributes)
+	}
+	if iterator.HasNext() {
+		t.Fatalf("Expected no row given limit is reached but hasNext returned true")
+	}
+	if !reflect.DeepEqual(expected, rows.AtIndex(0).AllAttributes()) {
+		t.Fatalf("Expected attributes at index 0 to be %v, received %v", expected, rows.AtIndex(0).AllAttributes())
+	}
+}
+
+func TestEvaluatingRowsWithDistinctAttributes(t *testing.T) {
+	rows := emptyRows(context.NewFunctions(), 10)
+	rows.distinctOn(1)
+	rows.addRow([]context.Value{context.StringValue(".log"), context.StringValue("a")}, []bool{true, true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue(".txt"), context.StringValue("b")}, []bool{true, true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue(".log"), context.StringValue("c")}, []bool{true, true}, []*expression.Expression{}, nil)
+
+	if rows.Count() != 2 {
+		t.Fatalf("Expected count to be %v, received %v", 2, rows.Count())
+	}
+	expected := []context.Value{context.StringValue(".log"), context.StringValue("a")}
+	if !reflect.DeepEqual(expected, rows.AtIndex(0).AllAttributes()) {
+		t.Fatalf("Expected attributes to be %v, received %v", expected, rows.AtIndex(0).AllAttributes())
+	}
+}
+
+func TestEvaluatingRowsRetainFirstRowWithOffset(t *testing.T) {
+	rows := emptyRowsWithOffset(context.NewFunctions(), 1, 1)
+	rows.addRow([]context.Value{context.StringValue("first")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.addRow([]context.Value{context.StringValue("second")}, []bool{true}, []*expression.Expression{}, nil)
+	rows.retainFirstRow()
+
+	if rows.Count() != 0 {
+		t.Fatalf("Expected count to be %v, received %v", 0, rows.Count())
+	}
+}
@@ -3,90 +3,254 @@ package executor
 import (
 	"goselect/parser"
 	"goselect/parser/context"
+	"goselect/parser/source"
 	"io/fs"
 	"math"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
 const pathSeparator = string(os.PathSeparator)
 
 type SelectQueryExecutor struct {
-	options *Options
-	query   *parser.SelectQuery
-	context *context.ParsingApplicationContext
+	options  *Options
+	query    *parser.SelectQuery
+	context  *context.ParsingApplicationContext
+	grouping *Grouping
 }
 
 func NewSelectQueryExecutor(query *parser.SelectQuery, context *context.ParsingApplicationContext, options *Options) *SelectQueryExecutor {
+	var grouping *Grouping
+	if query.IsGroupingDefined() {
+		grouping = newGrouping(query.GroupBy, query.Projections, query.Having, context.AllFunctions())
+	}
 	return &SelectQueryExecutor{
-		query:   query,
-		context: context,
-		options: options,
+		query:    query,
+		context:  context,
+		options:  options,
+		grouping: grouping,
 	}
 }
 
 func (selectQueryExecutor *SelectQueryExecutor) Execute() (*EvaluatingRows, error) {
-	source := selectQueryExecutor.query.Source
-
+	if selectQueryExecutor.query.IsCombined() {
+		return selectQueryExecutor.executeCombined()
+	}
 	var limit uint32 = math.MaxInt32
-	if selectQueryExecutor.query.Projections.HasAllAggregates() {
+	var offset uint32 = 0
+	if selectQueryExecutor.query.IsLimitDefined() {
+		offset = selectQueryExecutor.query.Limit.Offset
+	}
+	if selectQueryExecutor.hasOnlyAggregates() {
 		limit = 1
 	} else {
 		if selectQueryExecutor.query.IsLimitDefined() {
 			limit = selectQueryExecutor.query.Limit.Limit
 		}
 	}
-	rows, err := selectQueryExecutor.executeFrom(source.Directory, limit)
+	rows, err := selectQueryExecutor.executeSource(limit, offset)
 	if err != nil {
 		return nil, err
 	}
+	if selectQueryExecutor.hasOnlyAggregates() {
+		rows.retainFirstRow()
+	}
 	newOrdering(selectQueryExecutor.query.Order).doOrder(rows)
+	if selectQueryExecutor.query.Projections.HiddenCount() > 0 {
+		rows.retainAttributes(selectQueryExecutor.query.Projections.Count())
+	}
 	return rows, nil
 }
 
-func (selectQueryExecutor SelectQueryExecutor) executeFrom(directory string, maxLimit uint32) (*EvaluatingRows, error) {
-	rows := emptyRows(selectQueryExecutor.context.AllFunctions(), maxLimit)
-	if err := selectQueryExecutor.execute(directory, maxLimit, rows); err != nil {
+func (selectQueryExecutor *SelectQueryExecutor) executeCombined() (*EvaluatingRows, error) {
+	rows, err := selectQueryExecutor.executeSourceWithoutLimit()
+	if err != nil {
 		return nil, err
 	}
+	for _, setOperation := range selectQueryExecutor.query.SetOperations {
+		otherRows, err := NewSelectQueryExecutor(
+			setOperation.Query,
+			selectQueryExecutor.context,
+			selectQueryExecutor.options,
+		).executeSourceWithoutLimit()
+		if err != nil {
+			return nil, err
+		}
+		rows = combine(rows, otherRows, setOperation.Operator)
+	}
+	if selectQueryExecutor.query.IsLimitDefined() {
+		rows.limit = selectQueryExecutor.query.Limit.Limit
+		rows.offset = selectQueryExecutor.query.Limit.Offset
+	}
+	newOrdering(selectQueryExecutor.query.Order).doOrder(rows)
 	return rows, nil
 }
 
-func (selectQueryExecutor SelectQueryExecutor) execute(directory string, maxLimit uint32, rows *EvaluatingRows) error {
-	entries, err := os.ReadDir(directory)
+func (selectQueryExecutor SelectQueryExecutor) executeSourceWithoutLimit() (*EvaluatingRows, error) {
+	var limit uint32 = math.MaxInt32
+	if selectQueryExecutor.hasOnlyAggregates() {
+		limit = 1
+	}
+	rows, err := selectQueryExecutor.executeSource(limit, 0)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	for _, entry := range entries {
-		file, err := entry.Info()
+	if selectQueryExecutor.query.Projections.HiddenCount() > 0 {
+		rows.retainAttributes(selectQueryExecutor.query.Projections.Count())
+	}
+	return rows, nil
+}
+
+func (selectQueryExecutor SelectQueryExecutor) executeSource(limit uint32, offset uint32) (*EvaluatingRows, error) {
+	if err := selectQueryExecutor.executeSubqueries(); err != nil {
+		return nil, err
+	}
+	rows, err := selectQueryExecutor.executeFrom(selectQueryExecutor.query.Source, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if selectQueryExecutor.query.IsGroupingDefined() {
+		if err := selectQueryExecutor.grouping.addRowsTo(rows); err != nil {
+			return nil, err
+		}
+	}
+	if selectQueryExecutor.query.Projections.HasWindows() {
+		if err := newWindows(selectQueryExecutor.query.Projections, selectQueryExecutor.context.AllFunctions()).evaluate(rows); err != nil {
+			return nil, err
+		}
+	}
+	if selectQueryExecutor.isDistinct() && !selectQueryExecutor.isDistinctWhileAdding() {
+		rows.retainDistinct(selectQueryExecutor.query.Projections.Count())
+	}
+	return rows, nil
+}
+
+func (selectQueryExecutor SelectQueryExecutor) executeSubqueries() error {
+	for _, subquery := range selectQueryExecutor.query.Subqueries {
+		rows, err := NewSelectQueryExecutor(subquery.Query, selectQueryExecutor.context, selectQueryExecutor.options).Execute()
 		if err != nil {
 			return err
 		}
-		if selectQueryExecutor.shouldTraverseDirectory(file) {
-			newPath := selectQueryExecutor.childDirectoryName(directory, entry)
-			if err := selectQueryExecutor.execute(newPath, maxLimit, rows); err != nil {
-				return err
-			}
+		for iterator := rows.RowIterator(); iterator.HasNext(); {
+			subquery.Values.Add(iterator.Next().AllAttributes()[0])
 		}
+	}
+	return nil
+}
+
+func (selectQueryExecutor SelectQueryExecutor) executeFrom(fileSource *source.Source, maxLimit uint32, offset uint32) (*EvaluatingRows, error) {
+	rows := emptyRowsWithOffset(selectQueryExecutor.context.AllFunctions(), maxLimit, offset)
+	if selectQueryExecutor.isDistinctWhileAdding() {
+		rows.distinctOn(selectQueryExecutor.query.Projections.Count())
+	}
+	joins, err := selectQueryExecutor.executeJoins()
+	if err != nil {
+		return nil, err
+	}
+	for index, directory := range fileSource.Directories {
 		if selectQueryExecutor.haveCollectedEnough(rows, maxLimit) {
+			break
+		}
+		if err := selectQueryExecutor.execute(directory, fileSource.GlobAt(index), maxLimit, rows, joins); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+func (selectQueryExecutor SelectQueryExecutor) executeJoins() (*joins, error) {
+	joins := newJoins(selectQueryExecutor.query.Source.Alias, selectQueryExecutor.context.AllFunctions())
+	for _, join := range selectQueryExecutor.query.Source.Joins {
+		var joinedRows []*context.FileAttributes
+		collect := func(fileAttributes *context.FileAttributes) error {
+			joinedRows = append(joinedRows, fileAttributes)
+			return nil
+		}
+		if err := selectQueryExecutor.traverse(join.Directory, join.Directory, 1, nil, func() bool { return false }, collect); err != nil {
+			return nil, err
+		}
+		if err := joins.add(join, joinedRows); err != nil {
+			return nil, err
+		}
+	}
+	return joins, nil
+}
+
+func (selectQueryExecutor SelectQueryExecutor) execute(root string, glob *source.Glob, maxLimit uint32, rows *EvaluatingRows, joins *joins) error {
+	haveCollectedEnough := func() bool {
+		return selectQueryExecutor.haveCollectedEnough(rows, maxLimit)
+	}
+	addRowIfChosen := func(fileAttributes *context.FileAttributes) error {
+		if haveCollectedEnough() {
 			return nil
 		}
-		fileAttributes := context.ToFileAttributes(directory, file, selectQueryExecutor.context)
 		shouldChoose, err := selectQueryExecutor.shouldChoose(fileAttributes)
 		if err != nil {
 			return err
 		}
 		if shouldChoose {
-			values, fullyEvaluated, expressions, err := selectQueryExecutor.query.Projections.EvaluateWith(
-				fileAttributes,
-				selectQueryExecutor.context.AllFunctions(),
-			)
-			if err != nil {
+			return selectQueryExecutor.addRow(fileAttributes, rows)
+		}
+		return nil
+	}
+	return selectQueryExecutor.traverse(root, root, 1, glob, haveCollectedEnough, func(fileAttributes *context.FileAttributes) error {
+		return joins.each(fileAttributes, addRowIfChosen)
+	})
+}
+
+func (selectQueryExecutor SelectQueryExecutor) traverse(
+	root string,
+	directory string,
+	depth uint32,
+	glob *source.Glob,
+	haveCollectedEnough func() bool,
+	visit func(*context.FileAttributes) error,
+) error {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return err
+	}
+	minDepth, maxDepth := selectQueryExecutor.depthLimits()
+	for _, entry := range entries {
+		file, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		newPath := selectQueryExecutor.childDirectoryName(directory, entry)
+		pathFromRoot, _ := filepath.Rel(root, newPath)
+		if selectQueryExecutor.shouldTraverseDirectory(file) &&
+			(maxDepth == 0 || depth < maxDepth) &&
+			(glob == nil || glob.CanMatchInside(pathFromRoot)) {
+			if err := selectQueryExecutor.traverse(root, newPath, depth+1, glob, haveCollectedEnough, visit); err != nil {
 				return err
 			}
-			rows.addRow(values, fullyEvaluated, expressions)
 		}
+		if haveCollectedEnough() {
+			return nil
+		}
+		if depth < minDepth || (glob != nil && !glob.Matches(pathFromRoot)) {
+			continue
+		}
+		if err := visit(context.ToFileAttributesFromRoot(root, directory, file, selectQueryExecutor.context)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (selectQueryExecutor SelectQueryExecutor) addRow(fileAttributes *context.FileAttributes, rows *EvaluatingRows) error {
+	if selectQueryExecutor.query.IsGroupingDefined() {
+		return selectQueryExecutor.grouping.add(fileAttributes)
+	}
+	values, fullyEvaluated, expressions, err := selectQueryExecutor.query.Projections.EvaluateWith(
+		fileAttributes,
+		selectQueryExecutor.context.AllFunctions(),
+	)
+	if err != nil {
+		return err
 	}
+	rows.addRow(values, fullyEvaluated, expressions, fileAttributes)
 	return nil
 }
 
@@ -97,6 +261,13 @@ func (selectQueryExecutor SelectQueryExecutor) shouldTraverseDirectory(file fs.F
 
 }
 
+func (selectQueryExecutor SelectQueryExecutor) depthLimits() (uint32, uint32) {
+	if fileSource := selectQueryExecutor.query.Source; fileSource != nil && fileSource.IsDepthDefined() {
+		return fileSource.Depth.Min, fileSource.Depth.Max
+	}
+	return selectQueryExecutor.options.minDepth, selectQueryExecutor.options.maxDepth
+}
+
 func (selectQueryExecutor SelectQueryExecutor) childDirectoryName(directory string, entry os.DirEntry) string {
 	newPath := directory + pathSeparator + entry.Name()
 	if strings.HasSuffix(directory, pathSeparator) {
@@ -106,11 +277,31 @@ func (selectQueryExecutor SelectQueryExecutor) childDirectoryName(directory stri
 }
 
 func (selectQueryExecutor SelectQueryExecutor) haveCollectedEnough(rows *EvaluatingRows, maxLimit uint32) bool {
-	return rows.Count() >= maxLimit &&
-		!selectQueryExecutor.query.IsOrderDefined() &&
+	return rows.Count() >= maxLimit && selectQueryExecutor.canStopTraversalEarly()
+}
+
+func (selectQueryExecutor SelectQueryExecutor) canStopTraversalEarly() bool {
+	return !selectQueryExecutor.query.IsOrderDefined() &&
+		!selectQueryExecutor.query.IsGroupingDefined() &&
+		!selectQueryExecutor.query.Projections.HasWindows() &&
 		selectQueryExecutor.query.Projections.AggregationCount() == 0
 }
 
+func (selectQueryExecutor SelectQueryExecutor) hasOnlyAggregates() bool {
+	return selectQueryExecutor.query.Projections.HasAllAggregates() && !selectQueryExecutor.query.IsGroupingDefined()
+}
+
+func (selectQueryExecutor SelectQueryExecutor) isDistinct() bool {
+	return selectQueryExecutor.query.Projections.IsDistinct()
+}
+
+func (selectQueryExecutor SelectQueryExecutor) isDistinctWhileAdding() bool {
+	projections := selectQueryExecutor.query.Projections
+	return selectQueryExecutor.isDistinct() &&
+		!projections.HasWindows() &&
+		(selectQueryExecutor.query.IsGroupingDefined() || projections.AggregationCount() == 0)
+}
+
 func (selectQueryExecutor SelectQueryExecutor) shouldChoose(fileAttributes *context.FileAttributes) (bool, error) {
 	if passesWhere, err := selectQueryExecutor.query.Where.EvaluateWith(fileAttributes, selectQueryExecutor.context.AllFunctions()); err != nil {
 		return false, err
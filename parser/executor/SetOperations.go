@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"math"
+)
+
+type rowSet struct {
+	rowsByKey map[string][]*EvaluatingRow
+}
+
+func combine(left *EvaluatingRows, right *EvaluatingRows, operator parser.SetOperator) *EvaluatingRows {
+	combined := emptyRows(left.functions, math.MaxInt32)
+	if operator == parser.SetOperatorUnionAll {
+		combined.addAllFrom(left)
+		combined.addAllFrom(right)
+		return combined
+	}
+
+	rightRows := newRowSet()
+	if operator != parser.SetOperatorUnion {
+		rightRows.addAllFrom(right)
+	}
+	added := newRowSet()
+	addIfAbsent := func(row *EvaluatingRow) {
+		if !added.contains(row) {
+			added.add(row)
+			combined.rows = append(combined.rows, row)
+		}
+	}
+	for iterator := left.RowIterator(); iterator.HasNext(); {
+		row := iterator.Next()
+		switch {
+		case operator == parser.SetOperatorUnion:
+			addIfAbsent(row)
+		case operator == parser.SetOperatorExcept && !rightRows.contains(row):
+			addIfAbsent(row)
+		case operator == parser.SetOperatorIntersect && rightRows.contains(row):
+			addIfAbsent(row)
+		}
+	}
+	if operator == parser.SetOperatorUnion {
+		for iterator := right.RowIterator(); iterator.HasNext(); {
+			addIfAbsent(iterator.Next())
+		}
+	}
+	return combined
+}
+
+func (rows *EvaluatingRows) addAllFrom(other *EvaluatingRows) {
+	for iterator := other.RowIterator(); iterator.HasNext(); {
+		rows.rows = append(rows.rows, iterator.Next())
+	}
+}
+
+func newRowSet() *rowSet {
+	return &rowSet{rowsByKey: make(map[string][]*EvaluatingRow)}
+}
+
+func (set *rowSet) addAllFrom(rows *EvaluatingRows) {
+	for iterator := rows.RowIterator(); iterator.HasNext(); {
+		set.add(iterator.Next())
+	}
+}
+
+func (set *rowSet) add(row *EvaluatingRow) {
+	key := set.keyOf(row)
+	set.rowsByKey[key] = append(set.rowsByKey[key], row)
+}
+
+func (set *rowSet) contains(row *EvaluatingRow) bool {
+	for _, other := range set.rowsByKey[set.keyOf(row)] {
+		if areEqual(row.AllAttributes(), other.AllAttributes()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (set *rowSet) keyOf(row *EvaluatingRow) string {
+	return row.keyOf(row.TotalAttributes())
+}
+
+func areEqual(first []context.Value, second []context.Value) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for index, value := range first {
+		if value.CompareTo(second[index]) != context.CompareToEqual {
+			return false
+		}
+	}
+	return true
+}
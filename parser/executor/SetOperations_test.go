@@ -0,0 +1,99 @@
+//go:build unit
+// +build unit
+
+package executor
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/expression"
+	"testing"
+)
+
+func TestCombineWithUnion(t *testing.T) {
+	left, right := rowsOf("fileA", "fileB", "fileA"), rowsOf("fileB", "fileC")
+	expected := [][]context.Value{
+		{context.StringValue("fileA")},
+		{context.StringValue("fileB")},
+		{context.StringValue("fileC")},
+	}
+	AssertMatch(t, expected, combine(left, right, parser.SetOperatorUnion))
+}
+
+func TestCombineWithUnionAll(t *testing.T) {
+	left, right := rowsOf("fileA", "fileB"), rowsOf("fileB")
+	expected := [][]context.Value{
+		{context.StringValue("fileA")},
+		{context.StringValue("fileB")},
+		{context.StringValue("fileB")},
+	}
+	AssertMatch(t, expected, combine(left, right, parser.SetOperatorUnionAll))
+}
+
+func TestCombineWithExcept(t *testing.T) {
+	left, right := rowsOf("fileA", "fileB", "fileA", "fileC"), rowsOf("fileB")
+	expected := [][]context.Value{
+		{context.StringValue("fileA")},
+		{context.StringValue("fileC")},
+	}
+	AssertMatch(t, expected, combine(left, right, parser.SetOperatorExcept))
+}
+
+func TestCombineWithIntersect(t *testing.T) {
+	left, right := rowsOf("fileA", "fileB", "fileB"), rowsOf("fileB", "fileC")
+	expected := [][]context.Value{
+		{context.StringValue("fileB")},
+	}
+	AssertMatch(t, expected, combine(left, right, parser.SetOperatorIntersect))
+}
+
+func TestCombineRespectsTheLimitOfTheRows(t *testing.T) {
+	left, right := rowsOf("fileA", "fileB"), rowsOf("fileC")
+	left.limit = 1
+	expected := [][]context.Value{
+		{context.StringValue("fileA")},
+		{context.StringValue("fileC")},
+	}
+	AssertMatch(t, expected, combine(left, right, parser.SetOperatorUnionAll))
+}
+
+func TestCombineWithUnionOfDifferentNumericTypes(t *testing.T) {
+	left, right := numericRowsOf(context.Int64Value(58), context.Int64Value(71)), numericRowsOf(context.Float64Value(58))
+	expected := [][]context.Value{
+		{context.Int64Value(58)},
+		{context.Int64Value(71)},
+	}
+	AssertMatch(t, expected, combine(left, right, parser.SetOperatorUnion))
+}
+
+func TestCombineWithIntersectOfDifferentNumericTypes(t *testing.T) {
+	left, right := numericRowsOf(context.Int64Value(58), context.Int64Value(71)), numericRowsOf(context.Float64Value(71))
+	expected := [][]context.Value{
+		{context.Int64Value(71)},
+	}
+	AssertMatch(t, expected, combine(left, right, parser.SetOperatorIntersect))
+}
+
+func TestCombineWithExceptOfDifferentNumericTypes(t *testing.T) {
+	left, right := numericRowsOf(context.Int64Value(58), context.Int64Value(71)), numericRowsOf(context.Uint32Value(71))
+	expected := [][]context.Value{
+		{context.Int64Value(58)},
+	}
+	AssertMatch(t, expected, combine(left, right, parser.SetOperatorExcept))
+}
+
+func rowsOf(values ...string) *EvaluatingRows {
+	rows := emptyRows(context.NewFunctions(), 10)
+	for _, value := range values {
+		rows.addRow([]context.Value{context.StringValue(value)}, []bool{true}, []*expression.Expression{}, nil)
+	}
+	return rows
+}
+
+func numericRowsOf(values ...context.Value) *EvaluatingRows {
+	rows := emptyRows(context.NewFunctions(), 10)
+	for _, value := range values {
+		rows.addRow([]context.Value{value}, []bool{true}, []*expression.Expression{}, nil)
+	}
+	return rows
+}
@@ -0,0 +1,126 @@
+package executor
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/order"
+	"goselect/parser/projection"
+	"math"
+	"strings"
+)
+
+type windows struct {
+	columns   []*projection.WindowColumn
+	functions *context.AllFunctions
+}
+
+func newWindows(projections *projection.Projections, functions *context.AllFunctions) *windows {
+	return &windows{columns: projections.Windows(), functions: functions}
+}
+
+/*
+partitions: the rows are partitioned by the values of partition by, a window without partition by has a single partition
+order:      every partition is ordered by the order by of the window, like the order by of the query
+ranking:    rownumber, rank and denserank get a value for every row, rank and denserank receive the order by values
+aggregate:  an aggregate gets a running value, the rows with equal order by values (peers) share the value
+whole:      an aggregate over a window without order by gets the value of the whole partition
+*/
+func (windows *windows) evaluate(rows *EvaluatingRows) error {
+	for _, column := range windows.columns {
+		for _, partition := range windows.partitionsOf(rows, column) {
+			newOrdering(windows.orderOf(column)).doOrder(partition)
+			if err := windows.evaluateOver(partition.rows, column); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (windows *windows) partitionsOf(rows *EvaluatingRows, column *projection.WindowColumn) []*EvaluatingRows {
+	var partitions []*EvaluatingRows
+	partitionsByKey := make(map[string]*EvaluatingRows)
+	for _, row := range rows.rows {
+		key := keyAt(row.AllAttributes(), column.PartitionPositions)
+		partition, ok := partitionsByKey[key]
+		if !ok {
+			partition = emptyRows(windows.functions, math.MaxInt32)
+			partitionsByKey[key] = partition
+			partitions = append(partitions, partition)
+		}
+		partition.rows = append(partition.rows, row)
+	}
+	return partitions
+}
+
+func (windows *windows) orderOf(column *projection.WindowColumn) *order.Order {
+	if len(column.OrderPositions) == 0 {
+		return nil
+	}
+	var attributes []order.AttributeRef
+	var directions []bool
+	for index, position := range column.OrderPositions {
+		attributes = append(attributes, order.AttributeRef{ProjectionPosition: position})
+		directions = append(directions, column.Expression.Window().IsAscendingAt(index))
+	}
+	anOrder, _ := order.NewOrderWith(attributes, directions)
+	return anOrder
+}
+
+func (windows *windows) evaluateOver(rows []*EvaluatingRow, column *projection.WindowColumn) error {
+	functionName := column.Expression.FunctionName()
+	state := windows.functions.InitialState(functionName)
+	isARankingFunction := windows.functions.IsAWindowFunction(functionName)
+
+	var err error
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for !isARankingFunction && end < len(rows) && areEqualAt(rows[start], rows[end], column.OrderPositions) {
+			end = end + 1
+		}
+		var args []context.Value
+		for _, row := range rows[start:end] {
+			if isARankingFunction {
+				args = valuesAt(row.AllAttributes(), column.OrderPositions)
+			} else {
+				args = valuesAt(row.AllAttributes(), column.ArgPositions)
+			}
+			if state, err = windows.functions.ExecuteAggregate(functionName, state, args...); err != nil {
+				return err
+			}
+		}
+		value, err := windows.functions.FinalValue(functionName, state, args)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows[start:end] {
+			row.attributeValues[column.Position-1] = value
+			row.fullyEvaluated[column.Position-1] = true
+		}
+		start = end
+	}
+	return nil
+}
+
+func areEqualAt(first *EvaluatingRow, second *EvaluatingRow, positions []int) bool {
+	if len(positions) == 0 {
+		return true
+	}
+	return areEqual(valuesAt(first.AllAttributes(), positions), valuesAt(second.AllAttributes(), positions))
+}
+
+func valuesAt(values []context.Value, positions []int) []context.Value {
+	var result []context.Value
+	for _, position := range positions {
+		result = append(result, values[position-1])
+	}
+	return result
+}
+
+func keyAt(values []context.Value, positions []int) string {
+	var key strings.Builder
+	for _, value := range valuesAt(values, positions) {
+		key.WriteString(value.GetAsKey())
+		key.WriteString(distinctKeySeparator)
+	}
+	return key.String()
+}
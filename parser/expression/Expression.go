@@ -1,7 +1,11 @@
 package expression
 
 import (
+	"errors"
+	"fmt"
 	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"strings"
 )
 
 type Expressions struct {
@@ -19,6 +23,7 @@ const (
 type Expression struct {
 	eType     expressionType
 	value     context.Value
+	literal   string
 	attribute string
 	function  *FunctionInstance
 }
@@ -28,6 +33,14 @@ type FunctionInstance struct {
 	args        []*Expression
 	state       *context.FunctionState
 	isAggregate bool
+	subquery    *Subquery
+	window      *Window
+}
+
+type Window struct {
+	partitionBy []*Expression
+	orderBy     []*Expression
+	ascending   []bool
 }
 
 func FunctionInstanceWith(name string, args []*Expression, state *context.FunctionState, isAggregate bool) *FunctionInstance {
@@ -60,6 +73,39 @@ func WithValue(value context.Value) *Expression {
 	}
 }
 
+func WithLiteralValue(value context.Value, literal string) *Expression {
+	return &Expression{
+		eType:   TypeValue,
+		value:   value,
+		literal: literal,
+	}
+}
+
+func NewWindow(partitionBy []*Expression, orderBy []*Expression, ascending []bool) *Window {
+	return &Window{partitionBy: partitionBy, orderBy: orderBy, ascending: ascending}
+}
+
+func NewWindowFunction(
+	name string,
+	args []*Expression,
+	window *Window,
+	ctx *context.ParsingApplicationContext,
+) (*Expression, error) {
+	if !ctx.IsAnAggregateFunction(name) && !ctx.IsAWindowFunction(name) {
+		return nil, fmt.Errorf(messages.ErrorMessageInvalidWindowFunction, name)
+	}
+	for _, expressions := range [][]*Expression{args, window.partitionBy, window.orderBy} {
+		for _, anExpression := range expressions {
+			if anExpression.HasAWindow() {
+				return nil, errors.New(messages.ErrorMessageWindowFunctionNotSupported)
+			}
+		}
+	}
+	functionInstance := FunctionInstanceWith(name, args, nil, false)
+	functionInstance.window = window
+	return WithFunctionInstance(functionInstance), nil
+}
+
 func WithAttributes(attributes []string) []*Expression {
 	var expressions = make([]*Expression, len(attributes))
 	for index, attribute := range attributes {
@@ -68,6 +114,33 @@ func WithAttributes(attributes []string) []*Expression {
 	return expressions
 }
 
+func (expressions Expressions) Clone(functions *context.AllFunctions) Expressions {
+	var clone func(expression *Expression) *Expression
+	clone = func(expression *Expression) *Expression {
+		if !expression.isAFunction() {
+			return &Expression{eType: expression.eType, value: expression.value, literal: expression.literal, attribute: expression.attribute}
+		}
+		var args []*Expression
+		for _, arg := range expression.function.args {
+			args = append(args, clone(arg))
+		}
+		var state *context.FunctionState
+		if expression.function.isAggregate {
+			state = functions.InitialState(expression.function.name)
+		}
+		functionInstance := FunctionInstanceWith(expression.function.name, args, state, expression.function.isAggregate)
+		functionInstance.subquery = expression.function.subquery
+		functionInstance.window = expression.function.window
+		return WithFunctionInstance(functionInstance)
+	}
+
+	var expressionsClone []*Expression
+	for _, expression := range expressions.Expressions {
+		expressionsClone = append(expressionsClone, clone(expression))
+	}
+	return Expressions{Expressions: expressionsClone}
+}
+
 func (expressions Expressions) Count() int {
 	return len(expressions.Expressions)
 }
@@ -86,17 +159,23 @@ func (expressions Expressions) DisplayableAttributes() []string {
 			if expression.eType == TypeAttribute {
 				return expression.attribute
 			}
-			return expression.value.GetAsString()
+			return expression.DisplayableValue()
 		}
 		var result = expression.function.name + "("
 		for _, arg := range expression.function.args {
 			result = result + functionAsString(arg) + ","
 		}
-		if len(expression.function.args) > 0 {
+		if expression.function.subquery != nil {
+			result = result + expression.function.subquery.display() + ","
+		}
+		if len(expression.function.args) > 0 || expression.function.subquery != nil {
 			result = result[0:len(result)-1] + ")"
 		} else {
 			result = result + ")"
 		}
+		if expression.function.window != nil {
+			result = result + " over(" + expression.function.window.display(functionAsString) + ")"
+		}
 		return result
 	}
 
@@ -105,7 +184,7 @@ func (expressions Expressions) DisplayableAttributes() []string {
 		if expression.eType == TypeAttribute {
 			attributes = append(attributes, expression.attribute)
 		} else if expression.eType == TypeValue {
-			attributes = append(attributes, expression.value.GetAsString())
+			attributes = append(attributes, expression.DisplayableValue())
 		} else {
 			attributes = append(attributes, functionAsString(expression))
 		}
@@ -161,6 +240,9 @@ func (expression Expression) Evaluate(
 	if !expression.isAFunction() {
 		return expression.getNonFunctionValue(fileAttributes), nil, false
 	}
+	if expression.IsAWindowFunction() {
+		return context.EmptyValue, nil, false
+	}
 
 	var values []context.Value
 	isAtleastOneExpressionAnAggregateFunction := false
@@ -175,6 +257,9 @@ func (expression Expression) Evaluate(
 		values = append(values, v)
 	}
 
+	if expression.function.subquery != nil && !isAtleastOneExpressionAnAggregateFunction {
+		return expression.function.subquery.contains(values), nil, false
+	}
 	isAnAggregateFunction := functions.IsAnAggregateFunction(expression.function.name)
 	if isAnAggregateFunction && !isAtleastOneExpressionAnAggregateFunction {
 		state, err := functions.ExecuteAggregate(expression.function.name, expression.function.state, values...)
@@ -192,28 +277,41 @@ func (expression Expression) Evaluate(
 }
 
 func (expression *Expression) FullyEvaluate(functions *context.AllFunctions) (context.Value, error) {
+	return expression.FullyEvaluateWith(nil, functions)
+}
+
+func (expression *Expression) FullyEvaluateWith(
+	fileAttributes *context.FileAttributes,
+	functions *context.AllFunctions,
+) (context.Value, error) {
+
 	var execute func(expression *Expression) (context.Value, error)
 	execute = func(expression *Expression) (context.Value, error) {
-		if !expression.isAFunction() {
+		if !expression.isAFunction() || expression.IsAWindowFunction() {
 			return context.EmptyValue, nil
 		}
+		isAnAggregateFunction := functions.IsAnAggregateFunction(expression.function.name)
 		var values []context.Value
 		for _, arg := range expression.function.args {
-			if arg.isAFunction() && functions.IsAnAggregateFunction(arg.function.name) {
+			switch {
+			case arg.isAFunction() && (functions.IsAnAggregateFunction(arg.function.name) || !isAnAggregateFunction):
 				v, err := execute(arg)
 				if err != nil {
 					return context.EmptyValue, err
 				}
 				values = append(values, v)
-			} else {
-				if arg.eType == TypeValue {
-					values = append(values, arg.value)
-				}
+			case arg.eType == TypeValue:
+				values = append(values, arg.value)
+			case arg.eType == TypeAttribute && fileAttributes != nil && !isAnAggregateFunction:
+				values = append(values, fileAttributes.Get(arg.attribute))
 			}
 		}
-		if functions.IsAnAggregateFunction(expression.function.name) {
+		if isAnAggregateFunction {
 			return functions.FinalValue(expression.function.name, expression.function.state, values)
 		}
+		if expression.function.subquery != nil {
+			return expression.function.subquery.contains(values), nil
+		}
 		return functions.Execute(expression.function.name, values...)
 	}
 	return execute(expression)
@@ -223,7 +321,7 @@ func (expression Expression) HasAnAggregate() bool {
 	isAnyArgumentAnAggregate := func(fn *FunctionInstance) bool {
 		if fn != nil {
 			for _, arg := range fn.args {
-				if arg.isAFunction() && arg.function.isAggregate {
+				if arg.HasAnAggregate() {
 					return true
 				}
 			}
@@ -231,9 +329,148 @@ func (expression Expression) HasAnAggregate() bool {
 		}
 		return false
 	}
+	if expression.IsAWindowFunction() {
+		return false
+	}
 	return (expression.isAFunction() && expression.function.isAggregate) || isAnyArgumentAnAggregate(expression.function)
 }
 
+func (expression Expression) HasAWindow() bool {
+	if !expression.isAFunction() {
+		return false
+	}
+	if expression.IsAWindowFunction() {
+		return true
+	}
+	for _, arg := range expression.function.args {
+		if arg.HasAWindow() {
+			return true
+		}
+	}
+	return false
+}
+
+func (expression Expression) Subqueries() []*Subquery {
+	if !expression.isAFunction() {
+		return nil
+	}
+	var subqueries []*Subquery
+	if expression.function.subquery != nil {
+		subqueries = append(subqueries, expression.function.subquery)
+	}
+	for _, arg := range expression.function.args {
+		subqueries = append(subqueries, arg.Subqueries()...)
+	}
+	return subqueries
+}
+
+func (expression Expression) Attributes() []string {
+	if expression.eType == TypeAttribute {
+		return []string{expression.attribute}
+	}
+	if !expression.isAFunction() {
+		return nil
+	}
+	var attributes []string
+	for _, arg := range expression.function.args {
+		attributes = append(attributes, arg.Attributes()...)
+	}
+	return attributes
+}
+
+func (expression Expression) FunctionArgs() []*Expression {
+	if expression.isAFunction() {
+		return expression.function.args
+	}
+	return nil
+}
+
+func (expression Expression) Attribute() string {
+	if expression.eType == TypeAttribute {
+		return expression.attribute
+	}
+	return ""
+}
+
+func (expression Expression) Value() context.Value {
+	return expression.value
+}
+
+func (expression Expression) Literal() string {
+	return expression.literal
+}
+
+func (expression Expression) DisplayableValue() string {
+	if len(expression.literal) > 0 {
+		return expression.literal
+	}
+	return expression.value.GetAsString()
+}
+
+func (expression Expression) IsAnAggregateFunction() bool {
+	return expression.isAFunction() && expression.function.isAggregate
+}
+
+func (expression Expression) IsAWindowFunction() bool {
+	return expression.isAFunction() && expression.function.window != nil
+}
+
+func (expression Expression) Window() *Window {
+	if expression.isAFunction() {
+		return expression.function.window
+	}
+	return nil
+}
+
+func (window Window) PartitionBy() []*Expression {
+	return window.partitionBy
+}
+
+func (window Window) OrderBy() []*Expression {
+	return window.orderBy
+}
+
+func (window Window) IsAscendingAt(index int) bool {
+	return window.ascending[index]
+}
+
+func (window Window) display(functionAsString func(expression *Expression) string) string {
+	var clauses []string
+	if len(window.partitionBy) > 0 {
+		var partitionBy []string
+		for _, anExpression := range window.partitionBy {
+			partitionBy = append(partitionBy, functionAsString(anExpression))
+		}
+		clauses = append(clauses, "partition by "+strings.Join(partitionBy, ","))
+	}
+	if len(window.orderBy) > 0 {
+		var orderBy []string
+		for index, anExpression := range window.orderBy {
+			if window.ascending[index] {
+				orderBy = append(orderBy, functionAsString(anExpression))
+			} else {
+				orderBy = append(orderBy, functionAsString(anExpression)+" desc")
+			}
+		}
+		clauses = append(clauses, "order by "+strings.Join(orderBy, ","))
+	}
+	return strings.Join(clauses, " ")
+}
+
+func (expression Expression) FunctionSubquery() *Subquery {
+	if expression.isAFunction() {
+		return expression.function.subquery
+	}
+	return nil
+}
+
+func (expression Expression) FunctionName() string {
+	if expression.isAFunction() {
+		return expression.function.name
+	}
+	return ""
+}
+
 func (expression Expression) isAFunction() bool {
 	return expression.function != nil
 }
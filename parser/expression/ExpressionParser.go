@@ -0,0 +1,539 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/tokenizer"
+	"strconv"
+	"strings"
+)
+
+const (
+	precedenceLowest     = 0
+	precedenceOr         = 1
+	precedenceAnd        = 2
+	precedenceNot        = 3
+	precedenceComparison = 4
+	precedenceAdditive   = 5
+	precedenceMultiplier = 6
+	precedenceUnary      = 7
+)
+
+type infixOperator struct {
+	functionName string
+	precedence   int
+}
+
+var infixOperators = map[string]infixOperator{
+	"or":   {functionName: "or", precedence: precedenceOr},
+	"and":  {functionName: "and", precedence: precedenceAnd},
+	"=":    {functionName: "eq", precedence: precedenceComparison},
+	"==":   {functionName: "eq", precedence: precedenceComparison},
+	"!=":   {functionName: "ne", precedence: precedenceComparison},
+	"<>":   {functionName: "ne", precedence: precedenceComparison},
+	"<":    {functionName: "lt", precedence: precedenceComparison},
+	"<=":   {functionName: "le", precedence: precedenceComparison},
+	">":    {functionName: "gt", precedence: precedenceComparison},
+	">=":   {functionName: "ge", precedence: precedenceComparison},
+	"like": {functionName: "like", precedence: precedenceComparison},
+	"in":   {functionName: "in", precedence: precedenceComparison},
+	"+":    {functionName: "add", precedence: precedenceAdditive},
+	"-":    {functionName: "sub", precedence: precedenceAdditive},
+	"*":    {functionName: "mul", precedence: precedenceMultiplier},
+	"/":    {functionName: "div", precedence: precedenceMultiplier},
+}
+
+var keywords = []string{"from", "where", "group", "having", "order", "limit", "when", "then", "else", "end"}
+
+type ParsingRules struct {
+	AllowAggregates       bool
+	AllowSubqueries       bool
+	AllowWindows          bool
+	AggregateErrorMessage string
+	InvalidErrorMessage   string
+	ResolveAlias          func(alias string) (*Expression, bool, error)
+}
+
+type Parser struct {
+	tokenIterator *tokenizer.TokenIterator
+	context       *context.ParsingApplicationContext
+	rules         ParsingRules
+}
+
+func NewParser(
+	tokenIterator *tokenizer.TokenIterator,
+	context *context.ParsingApplicationContext,
+	rules ParsingRules,
+) *Parser {
+	return &Parser{tokenIterator: tokenIterator, context: context, rules: rules}
+}
+
+func IsAnInfixOperator(token tokenizer.Token) bool {
+	if token.IsAnOperator() {
+		return true
+	}
+	casedToken := strings.ToLower(token.TokenValue)
+	return casedToken == "and" || casedToken == "or" || casedToken == "like" || casedToken == "in"
+}
+
+func (parser *Parser) IsAnExpressionStart(token tokenizer.Token) bool {
+	return token.IsBound() ||
+		token.IsQuoted() ||
+		parser.context.IsASupportedAttribute(token.TokenValue) ||
+		parser.context.IsASupportedFunction(token.TokenValue) ||
+		token.Equals("(") ||
+		token.Equals("case") ||
+		(parser.tokenIterator.HasNext() && IsAnInfixOperator(parser.tokenIterator.Peek()))
+}
+
+/*
+expression: operand [infix-operator operand]*, lowered onto the existing functions
+operand:    attribute | function(expression, ...) | (expression) | not operand | - operand | alias | value
+alias:      an unquoted value resolved by ParsingRules.ResolveAlias, having resolves the projection aliases
+membership: expression in (expression, ...) | expression in (select ...), the subquery must project a single attribute
+case:       case when expression then expression [when expression then expression]* [else expression] end, lowered onto nested if
+cast:       cast(expression as type), lowered onto cast(expression, type), the type is never an attribute and is checked while parsing
+strict:     the parameters of a function must be separated by commas and must not exceed the parameters the function takes
+precedence: or < and < not < =, ==, !=, <>, <, <=, >, >=, like, in < +, - < *, /
+example:    size > 1mb and (ext = .log or name like err.*) becomes and(gt(size,1mb),or(eq(ext,.log),like(name,err.*)))
+*/
+func (parser *Parser) ParseFrom(token tokenizer.Token) (*Expression, error) {
+	return parser.expression(token, precedenceLowest)
+}
+
+func (parser *Parser) expression(token tokenizer.Token, precedence int) (*Expression, error) {
+	left, err := parser.operand(token)
+	if err != nil {
+		return nil, err
+	}
+	for parser.tokenIterator.HasNext() {
+		operator, ok := parser.infixOperatorAt(parser.tokenIterator.Peek())
+		if !ok || operator.precedence <= precedence {
+			break
+		}
+		parser.tokenIterator.Next()
+		if !parser.tokenIterator.HasNext() {
+			return nil, errors.New(parser.rules.InvalidErrorMessage)
+		}
+		if operator.functionName == "in" {
+			if left, err = parser.membership(left); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		right, err := parser.expression(parser.tokenIterator.Next(), operator.precedence)
+		if err != nil {
+			return nil, err
+		}
+		left = WithFunctionInstance(FunctionInstanceWith(operator.functionName, []*Expression{left, right}, nil, false))
+	}
+	return left, nil
+}
+
+func (parser *Parser) operand(token tokenizer.Token) (*Expression, error) {
+	switch {
+	case token.IsBound() || token.IsQuoted():
+		return parser.valueOf(token), nil
+	case token.Equals("("):
+		return parser.parenthesized()
+	case token.Equals("case"):
+		return parser.caseWhen()
+	case token.Equals("not") && !parser.isNextToken("("):
+		return parser.prefix("not", precedenceNot)
+	case token.Equals("-") && token.IsAnOperator() && parser.isAnOperandNext():
+		return parser.negation()
+	case parser.context.IsASupportedFunction(token.TokenValue) && parser.isNextToken("("):
+		return parser.function(token)
+	case parser.context.IsASupportedAttribute(token.TokenValue):
+		return WithAttribute(token.TokenValue), nil
+	case parser.context.IsASupportedFunction(token.TokenValue):
+		if !parser.tokenIterator.HasNext() {
+			return nil, errors.New(parser.rules.InvalidErrorMessage)
+		}
+		return nil, fmt.Errorf(messages.ErrorMessageOpeningParenthesesProjection, token.TokenValue)
+	case isAKeyword(token) || token.Equals(")") || token.Equals(","):
+		return nil, errors.New(parser.rules.InvalidErrorMessage)
+	default:
+		if parser.rules.ResolveAlias != nil {
+			if anExpression, ok, err := parser.rules.ResolveAlias(token.TokenValue); ok || err != nil {
+				return anExpression, err
+			}
+		}
+		if parser.IsGluedToAnArithmeticOperator(token) {
+			return nil, fmt.Errorf(messages.ErrorMessageOperatorWithoutSpaces, token.TokenValue)
+		}
+		return parser.valueOf(token), nil
+	}
+}
+
+/*
+IsGluedToAnArithmeticOperator: -size, size/1024 or 1+2 where the arithmetic operator is not separated by spaces.
+The tokenizer can not split +, -, * and / because they are a part of paths, dates and patterns like ./a/b, 2022-01-31 and *.go,
+so a token is treated as glued only if its parts are attributes or numbers, and atleast one of them is an attribute or the operator is + or *
+*/
+func (parser *Parser) IsGluedToAnArithmeticOperator(token tokenizer.Token) bool {
+	if token.IsQuoted() || token.IsBound() || token.IsAnOperator() || token.IsANumber() {
+		return false
+	}
+	isAnArithmeticOperator := func(ch rune) bool {
+		return ch == '+' || ch == '-' || ch == '*' || ch == '/'
+	}
+	parts := strings.FieldsFunc(token.TokenValue, isAnArithmeticOperator)
+	if len(parts) == 0 || len(parts) == 1 && parts[0] == token.TokenValue {
+		return false
+	}
+	hasAnAttribute := false
+	for _, part := range parts {
+		switch {
+		case parser.context.IsASupportedAttribute(part):
+			hasAnAttribute = true
+		case !isANumber(part):
+			return false
+		}
+	}
+	return hasAnAttribute || strings.ContainsAny(token.TokenValue, "+*")
+}
+
+func isANumber(str string) bool {
+	_, err := strconv.ParseFloat(str, 64)
+	return err == nil
+}
+
+func (parser *Parser) valueOf(token tokenizer.Token) *Expression {
+	value, err := parser.context.ToValue(token)
+	if err != nil {
+		value = context.StringValue(token.TokenValue)
+	}
+	if !token.IsQuoted() && !token.IsBound() && context.IsATimeLiteral(token.TokenValue) {
+		return WithLiteralValue(value, token.TokenValue)
+	}
+	return WithValue(value)
+}
+
+func (parser *Parser) parenthesized() (*Expression, error) {
+	if !parser.tokenIterator.HasNext() {
+		return nil, errors.New(parser.rules.InvalidErrorMessage)
+	}
+	expression, err := parser.expression(parser.tokenIterator.Next(), precedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+	if !parser.isNextToken(")") {
+		return nil, errors.New(parser.rules.InvalidErrorMessage)
+	}
+	parser.tokenIterator.Next()
+	return expression, nil
+}
+
+func (parser *Parser) prefix(functionName string, precedence int) (*Expression, error) {
+	if !parser.tokenIterator.HasNext() {
+		return nil, errors.New(parser.rules.InvalidErrorMessage)
+	}
+	operand, err := parser.expression(parser.tokenIterator.Next(), precedence)
+	if err != nil {
+		return nil, err
+	}
+	return WithFunctionInstance(FunctionInstanceWith(functionName, []*Expression{operand}, nil, false)), nil
+}
+
+func (parser *Parser) negation() (*Expression, error) {
+	operand, err := parser.expression(parser.tokenIterator.Next(), precedenceUnary)
+	if err != nil {
+		return nil, err
+	}
+	return WithFunctionInstance(
+		FunctionInstanceWith("sub", []*Expression{WithValue(context.Int64Value(0)), operand}, nil, false),
+	), nil
+}
+
+func (parser *Parser) function(functionNameToken tokenizer.Token) (*Expression, error) {
+	isAggregate := parser.context.IsAnAggregateFunction(functionNameToken.TokenValue)
+	if isAggregate && !parser.rules.AllowAggregates {
+		return nil, errors.New(parser.rules.AggregateErrorMessage)
+	}
+	parser.tokenIterator.Next()
+
+	if parser.context.AllFunctions().CanonicalNameOf(functionNameToken.TokenValue) == context.FunctionNameCast {
+		return parser.cast(functionNameToken)
+	}
+	strict := parser.context.IsInStrictMode()
+	var functionArgs []*Expression
+	var expectComma bool
+	for parser.tokenIterator.HasNext() {
+		token := parser.tokenIterator.Next()
+		switch {
+		case token.Equals(")"):
+			if strict && len(functionArgs) > 0 && !expectComma {
+				return nil, fmt.Errorf(messages.ErrorMessageExpectedParameterInFunction, functionNameToken.TokenValue, token.TokenValue)
+			}
+			if strict && !parser.context.AllFunctions().AcceptsParameters(functionNameToken.TokenValue, len(functionArgs)) {
+				return nil, fmt.Errorf(messages.ErrorMessageTooManyParametersInFunction, functionNameToken.TokenValue, len(functionArgs))
+			}
+			if parser.isNextToken("over") {
+				return parser.window(functionNameToken, functionArgs)
+			}
+			if parser.context.IsAWindowFunction(functionNameToken.TokenValue) {
+				return nil, fmt.Errorf(messages.ErrorMessageMissingOverForWindowFunction, functionNameToken.TokenValue, functionNameToken.TokenValue)
+			}
+			var state *context.FunctionState
+			if isAggregate {
+				state = parser.context.InitialState(functionNameToken.TokenValue)
+			}
+			return WithFunctionInstance(
+				FunctionInstanceWith(functionNameToken.TokenValue, functionArgs, state, isAggregate),
+			), nil
+		case token.Equals(","):
+			if strict && !expectComma {
+				return nil, fmt.Errorf(messages.ErrorMessageExpectedParameterInFunction, functionNameToken.TokenValue, token.TokenValue)
+			}
+			expectComma = false
+		case strict && expectComma:
+			return nil, fmt.Errorf(messages.ErrorMessageMissingCommaInFunction, functionNameToken.TokenValue, token.TokenValue)
+		default:
+			arg, err := parser.expression(token, precedenceLowest)
+			if err != nil {
+				return nil, err
+			}
+			functionArgs = append(functionArgs, arg)
+			expectComma = true
+		}
+	}
+	return nil, errors.New(parser.rules.InvalidErrorMessage)
+}
+
+func (parser *Parser) cast(functionNameToken tokenizer.Token) (*Expression, error) {
+	if !parser.isAnOperandNext() {
+		return nil, errors.New(messages.ErrorMessageInvalidCastExpression)
+	}
+	operand, err := parser.expression(parser.tokenIterator.Next(), precedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+	if !parser.isNextToken("as") {
+		return nil, errors.New(messages.ErrorMessageInvalidCastExpression)
+	}
+	parser.tokenIterator.Next()
+	if !parser.isAnOperandNext() {
+		return nil, errors.New(messages.ErrorMessageInvalidCastExpression)
+	}
+	castType := parser.tokenIterator.Next().TokenValue
+	if !context.IsASupportedCastType(castType) {
+		return nil, fmt.Errorf(messages.ErrorMessageUnsupportedCastType, strings.Join(context.SupportedCastTypes(), ", "), castType)
+	}
+	if !parser.isNextToken(")") {
+		return nil, errors.New(messages.ErrorMessageInvalidCastExpression)
+	}
+	parser.tokenIterator.Next()
+	return WithFunctionInstance(
+		FunctionInstanceWith(functionNameToken.TokenValue, []*Expression{operand, WithValue(context.StringValue(castType))}, nil, false),
+	), nil
+}
+
+/*
+window:  function(args) over ([partition by expression, ...] [order by expression [asc | desc], ...])
+example: rownumber() over (partition by dirname(path) order by size desc), sum(size) over (order by mtime)
+*/
+func (parser *Parser) window(functionNameToken tokenizer.Token, functionArgs []*Expression) (*Expression, error) {
+	if !parser.rules.AllowWindows {
+		return nil, errors.New(messages.ErrorMessageWindowFunctionNotSupported)
+	}
+	parser.tokenIterator.Next()
+	if !parser.isNextToken("(") {
+		return nil, errors.New(messages.ErrorMessageInvalidWindow)
+	}
+	parser.tokenIterator.Next()
+
+	var partitionBy, orderBy []*Expression
+	var ascending []bool
+	var err error
+	if parser.isNextToken("partition") {
+		parser.tokenIterator.Next()
+		if partitionBy, _, err = parser.windowClause(false); err != nil {
+			return nil, err
+		}
+	}
+	if parser.isNextToken("order") {
+		parser.tokenIterator.Next()
+		if orderBy, ascending, err = parser.windowClause(true); err != nil {
+			return nil, err
+		}
+	}
+	if !parser.isNextToken(")") {
+		return nil, errors.New(messages.ErrorMessageInvalidWindow)
+	}
+	parser.tokenIterator.Next()
+	return NewWindowFunction(functionNameToken.TokenValue, functionArgs, NewWindow(partitionBy, orderBy, ascending), parser.context)
+}
+
+func (parser *Parser) windowClause(withDirections bool) ([]*Expression, []bool, error) {
+	if !parser.isNextToken("by") {
+		return nil, nil, errors.New(messages.ErrorMessageInvalidWindow)
+	}
+	parser.tokenIterator.Next()
+
+	var expressions []*Expression
+	var ascending []bool
+	for {
+		if !parser.isAnOperandNext() {
+			return nil, nil, errors.New(messages.ErrorMessageInvalidWindow)
+		}
+		anExpression, err := parser.expression(parser.tokenIterator.Next(), precedenceLowest)
+		if err != nil {
+			return nil, nil, err
+		}
+		expressions = append(expressions, anExpression)
+		if withDirections {
+			if parser.isNextToken("desc") {
+				parser.tokenIterator.Next()
+				ascending = append(ascending, false)
+			} else {
+				if parser.isNextToken("asc") {
+					parser.tokenIterator.Next()
+				}
+				ascending = append(ascending, true)
+			}
+		}
+		if !parser.isNextToken(",") {
+			return expressions, ascending, nil
+		}
+		parser.tokenIterator.Next()
+	}
+}
+
+func (parser *Parser) caseWhen() (*Expression, error) {
+	var conditions, results []*Expression
+	for parser.isNextToken("when") {
+		parser.tokenIterator.Next()
+		condition, err := parser.caseOperand("then")
+		if err != nil {
+			return nil, err
+		}
+		result, err := parser.caseOperand("")
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+		results = append(results, result)
+	}
+	if len(conditions) == 0 {
+		return nil, errors.New(messages.ErrorMessageInvalidCaseExpression)
+	}
+	otherwise := WithValue(context.StringValue(""))
+	if parser.isNextToken("else") {
+		parser.tokenIterator.Next()
+		var err error
+		if otherwise, err = parser.caseOperand(""); err != nil {
+			return nil, err
+		}
+	}
+	if !parser.isNextToken("end") {
+		return nil, errors.New(messages.ErrorMessageInvalidCaseExpression)
+	}
+	parser.tokenIterator.Next()
+	for index := len(conditions) - 1; index >= 0; index-- {
+		otherwise = WithFunctionInstance(
+			FunctionInstanceWith("if", []*Expression{conditions[index], results[index], otherwise}, nil, false),
+		)
+	}
+	return otherwise, nil
+}
+
+func (parser *Parser) caseOperand(followedBy string) (*Expression, error) {
+	if !parser.isAnOperandNext() {
+		return nil, errors.New(messages.ErrorMessageInvalidCaseExpression)
+	}
+	operand, err := parser.expression(parser.tokenIterator.Next(), precedenceLowest)
+	if err != nil {
+		return nil, err
+	}
+	if len(followedBy) > 0 {
+		if !parser.isNextToken(followedBy) {
+			return nil, errors.New(messages.ErrorMessageInvalidCaseExpression)
+		}
+		parser.tokenIterator.Next()
+	}
+	return operand, nil
+}
+
+func (parser *Parser) membership(left *Expression) (*Expression, error) {
+	if !parser.tokenIterator.Next().Equals("(") {
+		return nil, errors.New(parser.rules.InvalidErrorMessage)
+	}
+	if parser.isNextToken("select") {
+		return parser.subquery(left)
+	}
+	args := []*Expression{left}
+	for parser.tokenIterator.HasNext() {
+		token := parser.tokenIterator.Next()
+		switch {
+		case token.Equals(")"):
+			if len(args) == 1 {
+				return nil, errors.New(parser.rules.InvalidErrorMessage)
+			}
+			return WithFunctionInstance(FunctionInstanceWith("in", args, nil, false)), nil
+		case token.Equals(","):
+		default:
+			arg, err := parser.expression(token, precedenceLowest)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+		}
+	}
+	return nil, errors.New(parser.rules.InvalidErrorMessage)
+}
+
+func (parser *Parser) subquery(left *Expression) (*Expression, error) {
+	if !parser.rules.AllowSubqueries {
+		return nil, errors.New(messages.ErrorMessageSubqueryNotSupported)
+	}
+	tokens := tokenizer.NewEmptyTokens()
+	var tokenValues []string
+	depth := 1
+	for parser.tokenIterator.HasNext() {
+		token := parser.tokenIterator.Next()
+		if token.Equals("(") {
+			depth = depth + 1
+		} else if token.Equals(")") {
+			depth = depth - 1
+		}
+		if depth == 0 {
+			functionInstance := FunctionInstanceWith("in", []*Expression{left}, nil, false)
+			functionInstance.subquery = newSubquery(tokens, tokenValues)
+			return WithFunctionInstance(functionInstance), nil
+		}
+		tokens.Add(token)
+		tokenValues = append(tokenValues, token.TokenValue)
+	}
+	return nil, errors.New(parser.rules.InvalidErrorMessage)
+}
+
+func (parser *Parser) infixOperatorAt(token tokenizer.Token) (infixOperator, bool) {
+	if !IsAnInfixOperator(token) {
+		return infixOperator{}, false
+	}
+	operator, ok := infixOperators[strings.ToLower(token.TokenValue)]
+	return operator, ok
+}
+
+func (parser *Parser) isNextToken(value string) bool {
+	return parser.tokenIterator.HasNext() && parser.tokenIterator.Peek().Equals(value)
+}
+
+func (parser *Parser) isAnOperandNext() bool {
+	return parser.tokenIterator.HasNext() &&
+		!parser.tokenIterator.Peek().Equals(",") &&
+		!parser.tokenIterator.Peek().Equals(")") &&
+		!isAKeyword(parser.tokenIterator.Peek())
+}
+
+func isAKeyword(token tokenizer.Token) bool {
+	for _, keyword := range keywords {
+		if token.Equals(keyword) {
+			return true
+		}
+	}
+	return false
+}
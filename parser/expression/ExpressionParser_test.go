@@ -0,0 +1,443 @@
+//go:build unit
+// +build unit
+
+package expression
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/tokenizer"
+	"strings"
+	"testing"
+)
+
+var testParsingRules = ParsingRules{
+	AllowAggregates:       false,
+	AggregateErrorMessage: "aggregate",
+	InvalidErrorMessage:   "invalid",
+}
+
+func parse(expression string, rules ParsingRules) (*Expression, error) {
+	iterator := tokenizer.NewTokenizer(expression).Tokenize().Iterator()
+	parser := NewParser(iterator, context.NewContext(context.NewFunctions(), context.NewAttributes()), rules)
+	return parser.ParseFrom(iterator.Next())
+}
+
+func display(expression *Expression) string {
+	return Expressions{Expressions: []*Expression{expression}}.DisplayableAttributes()[0]
+}
+
+func TestParsesAnAttribute(t *testing.T) {
+	expression, _ := parse("name", testParsingRules)
+	expected := "name"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesAPrefixFunction(t *testing.T) {
+	expression, _ := parse("gt(size, 10)", testParsingRules)
+	expected := "gt(size,10)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesAComparison(t *testing.T) {
+	expression, _ := parse("size > 10", testParsingRules)
+	expected := "gt(size,10)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesAllTheComparisonOperators(t *testing.T) {
+	expected := map[string]string{
+		"size = 10":  "eq(size,10)",
+		"size == 10": "eq(size,10)",
+		"size != 10": "ne(size,10)",
+		"size <> 10": "ne(size,10)",
+		"size < 10":  "lt(size,10)",
+		"size <= 10": "le(size,10)",
+		"size >= 10": "ge(size,10)",
+	}
+	for query, expectedExpression := range expected {
+		expression, _ := parse(query, testParsingRules)
+		if display(expression) != expectedExpression {
+			t.Fatalf("Expected parsed expression for %v to be %v, received %v", query, expectedExpression, display(expression))
+		}
+	}
+}
+
+func TestParsesArithmeticWithPrecedence(t *testing.T) {
+	expression, _ := parse("1 + size * 2 - 3 / 4", testParsingRules)
+	expected := "sub(add(1,mul(size,2)),div(3,4))"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesBooleanLogicWithPrecedence(t *testing.T) {
+	expression, _ := parse("isdir or size > 10 and not name like a.*", testParsingRules)
+	expected := "or(isdir,and(gt(size,10),not(like(name,a.*))))"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesAParenthesizedExpression(t *testing.T) {
+	expression, _ := parse("size > 1mb and (ext = '.log' or name like 'err.*')", testParsingRules)
+	expected := "and(gt(size,1000000),or(eq(ext,.log),like(name,err.*)))"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesInfixOperatorsInsideAFunction(t *testing.T) {
+	expression, _ := parse("fmtsize(size * 2, extra)", testParsingRules)
+	expected := "fmtsize(mul(size,2),extra)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesANegation(t *testing.T) {
+	expression, _ := parse("- size", testParsingRules)
+	expected := "sub(0,size)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesAnOperatorAsALiteralInsideAFunction(t *testing.T) {
+	expression, _ := parse("contains(name, -)", testParsingRules)
+	expected := "contains(name,-)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesAQuotedOperatorAsALiteral(t *testing.T) {
+	expression, _ := parse("name = '>'", testParsingRules)
+	expected := "eq(name,>)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesAnAggregateFunctionWithState(t *testing.T) {
+	expression, _ := parse("count() > 1", ParsingRules{AllowAggregates: true, InvalidErrorMessage: "invalid"})
+
+	if !expression.HasAnAggregate() {
+		t.Fatalf("Expected parsed expression to have an aggregate function")
+	}
+}
+
+func TestThrowsAnErrorGivenAnAggregateFunctionIsNotAllowed(t *testing.T) {
+	_, err := parse("count() > 1", testParsingRules)
+
+	if err == nil || err.Error() != "aggregate" {
+		t.Fatalf("Expected an aggregate error, received %v", err)
+	}
+}
+
+func TestThrowsAnErrorGivenAMissingRightOperand(t *testing.T) {
+	_, err := parse("size >", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given a missing right operand")
+	}
+}
+
+func TestThrowsAnErrorGivenAKeywordAsAnOperand(t *testing.T) {
+	_, err := parse("size > order", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given a keyword as an operand")
+	}
+}
+
+func TestThrowsAnErrorGivenAnUnclosedParentheses(t *testing.T) {
+	_, err := parse("(size > 10", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given an unclosed parentheses")
+	}
+}
+
+func TestThrowsAnErrorGivenAFunctionWithoutParentheses(t *testing.T) {
+	_, err := parse("lower > 10", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given a function without parentheses")
+	}
+}
+
+func TestEvaluatesAnInfixExpression(t *testing.T) {
+	expression, _ := parse("(1 + 2) * 3 = 9 and not 2 > 3", testParsingRules)
+	value, _, _ := expression.Evaluate(nil, context.NewFunctions())
+
+	result, _ := value.GetBoolean()
+	if result != true {
+		t.Fatalf("Expected infix expression to evaluate to true, received %v", value)
+	}
+}
+
+func TestParsesInWithAList(t *testing.T) {
+	expression, _ := parse("ext in (.go, .mod, .sum)", testParsingRules)
+	expected := "in(ext,.go,.mod,.sum)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesInWithAListAlongWithOtherOperators(t *testing.T) {
+	expression, _ := parse("size > 10 and lower(ext) in (.go, .mod)", testParsingRules)
+	expected := "and(gt(size,10),in(lower(ext),.go,.mod))"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesInWithASubquery(t *testing.T) {
+	rules := testParsingRules
+	rules.AllowSubqueries = true
+
+	expression, _ := parse("basename in (select basename from ../other where eq(isfile, true))", rules)
+	expected := "in(basename,(select basename from ../other where eq(isfile, true)))"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+	if len(expression.Subqueries()) != 1 {
+		t.Fatalf("Expected 1 subquery, received %v", len(expression.Subqueries()))
+	}
+}
+
+func TestParsesInWithASubqueryGivenSubqueriesAreNotAllowed(t *testing.T) {
+	_, err := parse("basename in (select basename from ../other)", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given subqueries are not allowed")
+	}
+}
+
+func TestParsesInWithAnEmptyList(t *testing.T) {
+	_, err := parse("ext in ()", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given in with an empty list")
+	}
+}
+
+func TestParsesInWithAnUnclosedList(t *testing.T) {
+	_, err := parse("ext in (.go, .mod", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given in with an unclosed list")
+	}
+}
+
+func TestParsesCaseWhenWithElse(t *testing.T) {
+	expression, _ := parse("case when size > 1024 then large else small end", testParsingRules)
+	expected := "if(gt(size,1024),large,small)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesCaseWhenWithMultipleConditions(t *testing.T) {
+	expression, _ := parse("case when size < 1024 then small when size < 1048576 then medium else large end", testParsingRules)
+	expected := "if(lt(size,1024),small,if(lt(size,1048576),medium,large))"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesCaseWhenWithoutElse(t *testing.T) {
+	expression, _ := parse("case when ext = .log then concat(name, -log) end", testParsingRules)
+	expected := "if(eq(ext,.log),concat(name,-log),)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesCaseWhenAsAnOperand(t *testing.T) {
+	expression, _ := parse("lower(case when size > 10 then LARGE else SMALL end) = large", testParsingRules)
+	expected := "eq(lower(if(gt(size,10),LARGE,SMALL)),large)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestEvaluatesCaseWhen(t *testing.T) {
+	expression, _ := parse("case when 1 > 2 then first when 2 > 1 then second else third end", testParsingRules)
+	value, _, _ := expression.Evaluate(nil, context.NewFunctions())
+
+	if value.GetAsString() != "second" {
+		t.Fatalf("Expected case when to evaluate to second, received %v", value)
+	}
+}
+
+func TestParsesCaseWithoutWhen(t *testing.T) {
+	_, err := parse("case else small end", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given case without when")
+	}
+}
+
+func TestParsesCaseWhenWithoutThen(t *testing.T) {
+	_, err := parse("case when size > 10 large end", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given case when without then")
+	}
+}
+
+func TestParsesCaseWhenWithoutEnd(t *testing.T) {
+	_, err := parse("case when size > 10 then large else small", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given case when without end")
+	}
+}
+
+func TestParsesAWindowFunction(t *testing.T) {
+	expression, err := parse("rownumber() over (partition by dirname(path) order by size desc, name)", ParsingRules{AllowWindows: true, InvalidErrorMessage: "invalid"})
+	if err != nil {
+		t.Fatalf("Expected no error while parsing a window function, received %v", err)
+	}
+	expected := "rownumber() over(partition by dirname(path) order by size desc,name)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+	if expression.HasAnAggregate() {
+		t.Fatalf("Expected a window function to not be an aggregate")
+	}
+}
+
+func TestParsesAnAggregateOverAnEmptyWindow(t *testing.T) {
+	expression, err := parse("sum(size) over ()", ParsingRules{AllowAggregates: true, AllowWindows: true, InvalidErrorMessage: "invalid"})
+	if err != nil {
+		t.Fatalf("Expected no error while parsing a window function, received %v", err)
+	}
+	if !expression.IsAWindowFunction() || len(expression.Window().PartitionBy()) != 0 || len(expression.Window().OrderBy()) != 0 {
+		t.Fatalf("Expected an aggregate over an empty window, received %v", display(expression))
+	}
+}
+
+func TestParsesAWindowFunctionWhereWindowsAreNotAllowed(t *testing.T) {
+	_, err := parse("rank() over (order by size)", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given a window function where windows are not allowed")
+	}
+}
+
+func TestParsesAWindowFunctionWithoutOver(t *testing.T) {
+	_, err := parse("rank()", ParsingRules{AllowWindows: true, InvalidErrorMessage: "invalid"})
+
+	if err == nil {
+		t.Fatalf("Expected an error given a window function without over")
+	}
+}
+
+func TestParsesAScalarFunctionWithOver(t *testing.T) {
+	_, err := parse("lower(name) over ()", ParsingRules{AllowWindows: true, InvalidErrorMessage: "invalid"})
+
+	if err == nil {
+		t.Fatalf("Expected an error given a scalar function with over")
+	}
+}
+
+func TestParsesAWindowWithoutBy(t *testing.T) {
+	_, err := parse("rank() over (order size)", ParsingRules{AllowWindows: true, InvalidErrorMessage: "invalid"})
+
+	if err == nil {
+		t.Fatalf("Expected an error given a window without by")
+	}
+}
+
+func TestParsesCastWithAs(t *testing.T) {
+	expression, _ := parse("cast(basename as int) > 10", testParsingRules)
+	expected := "gt(cast(basename,int),10)"
+
+	if display(expression) != expected {
+		t.Fatalf("Expected parsed expression to be %v, received %v", expected, display(expression))
+	}
+}
+
+func TestParsesCastWithAnAttributeNameAsTheType(t *testing.T) {
+	expression, _ := parse("cast(name as size)", testParsingRules)
+	castType := expression.FunctionArgs()[1]
+
+	if len(castType.Attribute()) != 0 || castType.Value().GetAsString() != "size" {
+		t.Fatalf("Expected the type in cast to be the value size, received %v", display(expression))
+	}
+}
+
+func TestParsesCastWithoutAType(t *testing.T) {
+	_, err := parse("cast(name as)", testParsingRules)
+
+	if err == nil {
+		t.Fatalf("Expected an error given cast without a type")
+	}
+}
+
+func TestParsesCastWithoutAs(t *testing.T) {
+	_, err := parse("cast(name int)", testParsingRules)
+
+	if err == nil || err.Error() != messages.ErrorMessageInvalidCastExpression {
+		t.Fatalf("Expected the error %v given cast without as, received %v", messages.ErrorMessageInvalidCastExpression, err)
+	}
+}
+
+func TestParsesCastWithACommaInsteadOfAs(t *testing.T) {
+	_, err := parse("cast(name, int)", testParsingRules)
+
+	if err == nil || err.Error() != messages.ErrorMessageInvalidCastExpression {
+		t.Fatalf("Expected the error %v given cast with a comma instead of as, received %v", messages.ErrorMessageInvalidCastExpression, err)
+	}
+}
+
+func TestParsesCastWithAnUnsupportedType(t *testing.T) {
+	_, err := parse("cast(name as bogus)", testParsingRules)
+
+	if err == nil || !strings.Contains(err.Error(), "received bogus") {
+		t.Fatalf("Expected an error given cast with an unsupported type, received %v", err)
+	}
+}
+
+func TestParsesCastWithATypeInUppercase(t *testing.T) {
+	expression, err := parse("cast(name as INT)", testParsingRules)
+
+	if err != nil || display(expression) != "cast(name,INT)" {
+		t.Fatalf("Expected cast with a type in uppercase to parse, received %v, %v", display(expression), err)
+	}
+}
+
+func TestParsesCastWithoutAClosingParenthesis(t *testing.T) {
+	_, err := parse("cast(name as int lower(name))", testParsingRules)
+
+	if err == nil || err.Error() != messages.ErrorMessageInvalidCastExpression {
+		t.Fatalf("Expected the error %v given cast without a closing parenthesis, received %v", messages.ErrorMessageInvalidCastExpression, err)
+	}
+}
@@ -701,3 +701,60 @@ func simulate2RowExecution(expressions Expressions, functions *context.AllFuncti
 
 	return allExpressions1, allExpressions2
 }
+
+func TestExpressionFullyEvaluateWithANestedScalarFunctionOverAnAggregate(t *testing.T) {
+	functions := context.NewFunctions()
+	expression := WithFunctionInstance(&FunctionInstance{
+		name: "concat",
+		args: []*Expression{
+			WithFunctionInstance(&FunctionInstance{
+				name: "upper",
+				args: []*Expression{
+					WithFunctionInstance(&FunctionInstance{
+						name:        "min",
+						args:        []*Expression{WithValue(context.StringValue("someValue"))},
+						state:       functions.InitialState("min"),
+						isAggregate: true,
+					}),
+				},
+			}),
+			WithValue(context.StringValue("-")),
+		},
+	})
+	expressions := Expressions{Expressions: []*Expression{expression}}
+
+	_, _, allExpressions, _ := expressions.EvaluateWith(nil, functions)
+	value, _ := allExpressions[0].FullyEvaluate(functions)
+
+	expected := "SOMEVALUE-"
+	actual := value.GetAsString()
+	if actual != expected {
+		t.Fatalf("Expected function evaluation to return %v, received %v", expected, actual)
+	}
+}
+
+func TestExpressionHasAnAggregateNestedInsideScalarFunctions(t *testing.T) {
+	functions := context.NewFunctions()
+	expression := WithFunctionInstance(&FunctionInstance{
+		name: "add",
+		args: []*Expression{
+			WithFunctionInstance(&FunctionInstance{
+				name: "div",
+				args: []*Expression{
+					WithFunctionInstance(&FunctionInstance{
+						name:        "sum",
+						args:        []*Expression{WithAttribute("size")},
+						state:       functions.InitialState("sum"),
+						isAggregate: true,
+					}),
+					WithValue(context.Int64Value(1024)),
+				},
+			}),
+			WithValue(context.Int64Value(1)),
+		},
+	})
+
+	if !expression.HasAnAggregate() {
+		t.Fatalf("Expected expression to have an aggregate but was not")
+	}
+}
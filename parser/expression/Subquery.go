@@ -0,0 +1,62 @@
+package expression
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/tokenizer"
+	"strings"
+)
+
+type Subquery struct {
+	tokens *tokenizer.Tokens
+	query  string
+	values *context.ValueSet
+}
+
+func newSubquery(tokens *tokenizer.Tokens, tokenValues []string) *Subquery {
+	return &Subquery{
+		tokens: tokens,
+		query:  queryOf(tokenValues),
+		values: context.NewValueSet(),
+	}
+}
+
+func (subquery *Subquery) Tokens() *tokenizer.Tokens {
+	return subquery.tokens
+}
+
+func (subquery *Subquery) Values() *context.ValueSet {
+	return subquery.values
+}
+
+func (subquery *Subquery) display() string {
+	return "(" + subquery.query + ")"
+}
+
+// contains follows the SQL null semantics of in, a null on either side without a match is null and nothing is in an empty subquery.
+func (subquery *Subquery) contains(values []context.Value) context.Value {
+	if len(values) == 0 || subquery.values.Count() == 0 {
+		return context.BooleanValue(false)
+	}
+	if values[0].IsNull() {
+		return context.NullValue
+	}
+	if subquery.values.Contains(values[0]) {
+		return context.BooleanValue(true)
+	}
+	if subquery.values.Contains(context.NullValue) {
+		return context.NullValue
+	}
+	return context.BooleanValue(false)
+}
+
+func queryOf(tokenValues []string) string {
+	var query strings.Builder
+	for index, tokenValue := range tokenValues {
+		isAttached := tokenValue == "(" || tokenValue == ")" || tokenValue == ","
+		if index > 0 && !isAttached && tokenValues[index-1] != "(" {
+			query.WriteString(" ")
+		}
+		query.WriteString(tokenValue)
+	}
+	return query.String()
+}
@@ -0,0 +1,171 @@
+package groupby
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/expression"
+	"goselect/parser/tokenizer"
+	"strings"
+)
+
+const keySeparator = "\x1f"
+
+type GroupBy struct {
+	expressions expression.Expressions
+}
+
+func NewGroupBy(
+	tokenIterator *tokenizer.TokenIterator,
+	context *context.ParsingApplicationContext,
+) (*GroupBy, error) {
+	if !tokenIterator.HasNext() {
+		return nil, nil
+	}
+	if !tokenIterator.Peek().Equals("group") {
+		return nil, nil
+	}
+	tokenIterator.Next()
+	if !tokenIterator.HasNext() || !tokenIterator.Peek().Equals("by") {
+		return nil, errors.New(messages.ErrorMessageMissingByAfterGroup)
+	}
+	tokenIterator.Next()
+
+	expressions, err := all(tokenIterator, context)
+	if err != nil {
+		return nil, err
+	}
+	if expressions.Count() == 0 {
+		return nil, errors.New(messages.ErrorMessageMissingGroupByExpressions)
+	}
+	return &GroupBy{expressions: expressions}, nil
+}
+
+func NewGroupByWith(expressions []*expression.Expression) (*GroupBy, error) {
+	if len(expressions) == 0 {
+		return nil, errors.New(messages.ErrorMessageMissingGroupByExpressions)
+	}
+	for _, anExpression := range expressions {
+		if anExpression.HasAnAggregate() {
+			return nil, errors.New(messages.ErrorMessageAggregateFunctionInsideGroupBy)
+		}
+	}
+	return &GroupBy{expressions: expression.Expressions{Expressions: expressions}}, nil
+}
+
+func (groupBy GroupBy) Expressions() expression.Expressions {
+	return groupBy.expressions
+}
+
+func (groupBy GroupBy) Count() int {
+	return groupBy.expressions.Count()
+}
+
+func (groupBy GroupBy) DisplayableAttributes() []string {
+	return groupBy.expressions.DisplayableAttributes()
+}
+
+func (groupBy GroupBy) EvaluateWith(
+	fileAttributes *context.FileAttributes,
+	functions *context.AllFunctions,
+) ([]context.Value, string, error) {
+
+	values, _, _, err := groupBy.expressions.EvaluateWith(fileAttributes, functions)
+	if err != nil {
+		return nil, "", err
+	}
+	var key strings.Builder
+	for index, value := range values {
+		if index > 0 {
+			key.WriteString(keySeparator)
+		}
+		key.WriteString(value.GetAsKey())
+	}
+	return values, key.String(), nil
+}
+
+/*
+grouped: a group by expression, a value, an aggregate or a window function, or a function whose parameters are grouped
+example: with group by ext, ext, lower(ext), count() and 1 are grouped, name and lower(name) are not
+*/
+func (groupBy *GroupBy) IsGrouped(anExpression *expression.Expression, attributes *context.AllAttributes) bool {
+	if groupBy.contains(anExpression, attributes) {
+		return true
+	}
+	if anExpression.IsAnAggregateFunction() || anExpression.IsAWindowFunction() {
+		return true
+	}
+	if len(anExpression.Attribute()) > 0 {
+		return false
+	}
+	for _, arg := range anExpression.FunctionArgs() {
+		if !groupBy.IsGrouped(arg, attributes) {
+			return false
+		}
+	}
+	return true
+}
+
+func (groupBy *GroupBy) contains(anExpression *expression.Expression, attributes *context.AllAttributes) bool {
+	if groupBy == nil {
+		return false
+	}
+	display := expression.Expressions{Expressions: []*expression.Expression{anExpression}}.DisplayableAttributes()[0]
+	for index, groupByDisplay := range groupBy.DisplayableAttributes() {
+		if strings.EqualFold(groupByDisplay, display) {
+			return true
+		}
+		attribute := groupBy.expressions.ExpressionAt(index).Attribute()
+		if len(attribute) > 0 && len(anExpression.Attribute()) > 0 &&
+			attributes.CanonicalNameOf(attribute) == attributes.CanonicalNameOf(anExpression.Attribute()) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+group by: attributes Or functions Or expressions, separated by comma
+attributes:  name, size etc
+functions: 	 lower(name), extract(mtime, year) etc, aggregate functions are not allowed
+expressions: size / 1024, lower(ext) = .log etc
+*/
+func all(
+	tokenIterator *tokenizer.TokenIterator,
+	ctx *context.ParsingApplicationContext,
+) (expression.Expressions, error) {
+
+	var expressions []*expression.Expression
+	var expectComma bool
+
+	parser := expression.NewParser(tokenIterator, ctx, expression.ParsingRules{
+		AllowAggregates:       false,
+		AggregateErrorMessage: messages.ErrorMessageAggregateFunctionInsideGroupBy,
+		InvalidErrorMessage:   messages.ErrorMessageInvalidGroupBy,
+	})
+	for tokenIterator.HasNext() &&
+		!tokenIterator.Peek().Equals("having") &&
+		!tokenIterator.Peek().Equals("order") &&
+		!tokenIterator.Peek().Equals("limit") {
+
+		token := tokenIterator.Next()
+		switch {
+		case expectComma:
+			if !token.Equals(",") {
+				return expression.Expressions{}, errors.New(messages.ErrorMessageMissingCommaGroupBy)
+			}
+			expectComma = false
+		case parser.IsAnExpressionStart(token):
+			anExpression, err := parser.ParseFrom(token)
+			if err != nil {
+				return expression.Expressions{}, err
+			}
+			expressions = append(expressions, anExpression)
+			expectComma = true
+		default:
+			return expression.Expressions{}, fmt.Errorf(messages.ErrorMessageInvalidGroupByExpression, token.TokenValue)
+		}
+	}
+	return expression.Expressions{Expressions: expressions}, nil
+}
@@ -0,0 +1,218 @@
+//go:build unit
+// +build unit
+
+package groupby
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/expression"
+	"goselect/parser/tokenizer"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGroupByWithoutGroupByClause(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+
+	groupBy, _ := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if groupBy != nil {
+		t.Fatalf("Expected group by to be nil but was not")
+	}
+}
+
+func TestGroupByWithAKeywordOtherThanGroup(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+
+	groupBy, _ := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if groupBy != nil {
+		t.Fatalf("Expected group by to be nil but was not")
+	}
+}
+
+func TestGroupByWithMissingBy(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+
+	_, err := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given group keyword without by")
+	}
+}
+
+func TestGroupByWithUnknownKeywordAfterGroup(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "unknown"))
+
+	_, err := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given group keyword without by")
+	}
+}
+
+func TestGroupByWithoutAnyExpression(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+
+	_, err := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given group by without any expression")
+	}
+}
+
+func TestGroupByWithoutAnyExpressionBeforeOrder(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+
+	_, err := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given group by without any expression")
+	}
+}
+
+func TestGroupByWithMissingComma(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "ext"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+
+	_, err := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given group by without a comma between the expressions")
+	}
+}
+
+func TestGroupByWithAnUnsupportedExpression(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "unknown"))
+
+	_, err := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given group by with an unsupported expression")
+	}
+}
+
+func TestGroupByWithAnAggregateFunction(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "count"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+
+	_, err := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given group by with an aggregate function")
+	}
+}
+
+func TestGroupByWithAnAggregateFunctionInsideAScalarFunction(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "lower"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "min"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+
+	_, err := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given group by with an aggregate function inside a scalar function")
+	}
+}
+
+func TestGroupByWithAFunctionWithoutClosingParentheses(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "lower"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+
+	_, err := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given group by with a function without closing parentheses")
+	}
+}
+
+func TestGroupByWithAttributesAndAFunction(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "ext"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "lower"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "uname"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+
+	groupBy, _ := NewGroupBy(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := []string{"ext", "lower(uname)"}
+
+	if !reflect.DeepEqual(expected, groupBy.DisplayableAttributes()) {
+		t.Fatalf("Expected group by expressions to be %v, received %v", expected, groupBy.DisplayableAttributes())
+	}
+}
+
+func TestGroupByEvaluatesTheKeyForAFile(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Group, "group"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "lower"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "ext"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "isdir"))
+
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	groupBy, _ := NewGroupBy(tokens.Iterator(), newContext)
+
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/multi/TestResultsWithProjections_A.log")
+	if err != nil {
+		panic(err)
+	}
+	fileAttributes := context.ToFileAttributes("../test/resources/TestResultsWithProjections/multi/", file, newContext)
+	values, key, _ := groupBy.EvaluateWith(fileAttributes, newContext.AllFunctions())
+
+	expectedValues := []context.Value{context.StringValue(".log"), context.BooleanValue(false)}
+	if !reflect.DeepEqual(expectedValues, values) {
+		t.Fatalf("Expected group by values to be %v, received %v", expectedValues, values)
+	}
+	expectedKey := ".log" + keySeparator + "N"
+	if key != expectedKey {
+		t.Fatalf("Expected group by key to be %v, received %v", expectedKey, key)
+	}
+}
+
+func TestIsGroupedForExpressionsOverTheGroupByExpressions(t *testing.T) {
+	groupBy, _ := NewGroupByWith([]*expression.Expression{expression.WithAttribute("ext")})
+	attributes := context.NewAttributes()
+	lowerOf := func(anExpression *expression.Expression) *expression.Expression {
+		return expression.WithFunctionInstance(expression.FunctionInstanceWith("lower", []*expression.Expression{anExpression}, nil, false))
+	}
+	count := expression.WithFunctionInstance(expression.FunctionInstanceWith("count", nil, context.NewFunctions().InitialState("count"), true))
+
+	for _, anExpression := range []*expression.Expression{expression.WithAttribute("extension"), lowerOf(expression.WithAttribute("ext")), count, expression.WithValue(context.Int64Value(1))} {
+		if !groupBy.IsGrouped(anExpression, attributes) {
+			t.Fatalf("Expected %v to be grouped", anExpression)
+		}
+	}
+	for _, anExpression := range []*expression.Expression{expression.WithAttribute("name"), lowerOf(expression.WithAttribute("name"))} {
+		if groupBy.IsGrouped(anExpression, attributes) {
+			t.Fatalf("Expected %v not to be grouped", anExpression)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package having
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/expression"
+	"goselect/parser/projection"
+	"goselect/parser/tokenizer"
+)
+
+type Having struct {
+	expressions expression.Expressions
+}
+
+func NewHaving(
+	tokenIterator *tokenizer.TokenIterator,
+	projections *projection.Projections,
+	context *context.ParsingApplicationContext,
+) (*Having, error) {
+	if !tokenIterator.HasNext() {
+		return nil, nil
+	}
+	if !tokenIterator.Peek().Equals("having") {
+		return nil, nil
+	}
+	tokenIterator.Next()
+
+	expressions, err := all(tokenIterator, projections, context)
+	if err != nil {
+		return nil, err
+	}
+	if expressions.Count() == 0 {
+		return nil, errors.New(messages.ErrorMessageExpectedExpressionInHaving)
+	}
+	return &Having{expressions: expressions}, nil
+}
+
+func NewHavingWith(anExpression *expression.Expression, ctx *context.ParsingApplicationContext) (*Having, error) {
+	if functionName := anExpression.FunctionName(); !ctx.IsASupportedFunction(functionName) || !ctx.FunctionContainsATag(functionName, "where") {
+		return nil, errors.New(messages.ErrorMessageInvalidHavingFunctionUsed)
+	}
+	return &Having{expressions: expression.Expressions{Expressions: []*expression.Expression{anExpression}}}, nil
+}
+
+func (having Having) Expression() *expression.Expression {
+	return having.expressions.ExpressionAt(0)
+}
+
+func (having Having) Clone(functions *context.AllFunctions) *Having {
+	return &Having{expressions: having.expressions.Clone(functions)}
+}
+
+func (having Having) Display() string {
+	if attributes := having.expressions.DisplayableAttributes(); len(attributes) >= 1 {
+		return attributes[0]
+	}
+	return ""
+}
+
+func (having Having) EvaluateWith(
+	fileAttributes *context.FileAttributes,
+	functions *context.AllFunctions,
+) error {
+
+	if expr := having.expressions.ExpressionAt(0); expr != nil {
+		_, err, _ := expr.Evaluate(fileAttributes, functions)
+		return err
+	}
+	return nil
+}
+
+func (having Having) FullyEvaluate(
+	fileAttributes *context.FileAttributes,
+	functions *context.AllFunctions,
+) (bool, error) {
+
+	if expr := having.expressions.ExpressionAt(0); expr != nil {
+		value, err := expr.FullyEvaluateWith(fileAttributes, functions)
+		if err != nil {
+			return false, err
+		}
+		return value.GetBoolean()
+	}
+	return true, nil
+}
+
+/*
+having: a single expression supported in where, which may contain aggregate functions
+alias:  an unquoted projection alias becomes the projected expression, a window alias is an error
+example: gt(count(), 10), count() > 10 and avg(size) > 1kb, select ext, count() as cnt ... having cnt > 10
+*/
+func all(
+	tokenIterator *tokenizer.TokenIterator,
+	projections *projection.Projections,
+	ctx *context.ParsingApplicationContext,
+) (expression.Expressions, error) {
+
+	var expressions []*expression.Expression
+	parser := expression.NewParser(tokenIterator, ctx, expression.ParsingRules{
+		AllowAggregates:     true,
+		InvalidErrorMessage: messages.ErrorMessageInvalidHaving,
+		ResolveAlias: func(alias string) (*expression.Expression, bool, error) {
+			return expressionOfAlias(alias, projections, ctx)
+		},
+	})
+	for tokenIterator.HasNext() &&
+		!tokenIterator.Peek().Equals("order") &&
+		!tokenIterator.Peek().Equals("limit") {
+
+		token := tokenIterator.Next()
+		if len(expressions) > 0 {
+			return expression.Expressions{}, errors.New(messages.ErrorMessageInvalidHavingFunctionUsed)
+		}
+		anExpression, err := parser.ParseFrom(token)
+		if err != nil {
+			return expression.Expressions{}, err
+		}
+		if functionName := anExpression.FunctionName(); !ctx.IsASupportedFunction(functionName) || !ctx.FunctionContainsATag(functionName, "where") {
+			return expression.Expressions{}, errors.New(messages.ErrorMessageInvalidHavingFunctionUsed)
+		}
+		expressions = append(expressions, anExpression)
+	}
+	return expression.Expressions{Expressions: expressions}, nil
+}
+
+func expressionOfAlias(
+	alias string,
+	projections *projection.Projections,
+	ctx *context.ParsingApplicationContext,
+) (*expression.Expression, bool, error) {
+
+	position, ok := projections.PositionOfAlias(alias)
+	if !ok {
+		return nil, false, nil
+	}
+	if projections.IsAWindowAt(position) {
+		return nil, true, fmt.Errorf(messages.ErrorMessageWindowAliasInsideHaving, alias)
+	}
+	projected := expression.Expressions{
+		Expressions: []*expression.Expression{projections.Expressions().ExpressionAt(position - 1)},
+	}
+	return projected.Clone(ctx.AllFunctions()).ExpressionAt(0), true, nil
+}
@@ -0,0 +1,206 @@
+//go:build unit
+// +build unit
+
+package having
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/projection"
+	"goselect/parser/tokenizer"
+	"os"
+	"testing"
+)
+
+func TestHavingWithoutHavingClause(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+
+	having, _ := NewHaving(tokens.Iterator(), &projection.Projections{}, context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if having != nil {
+		t.Fatalf("Expected having to be nil but was not")
+	}
+}
+
+func TestHavingWithAKeywordOtherThanHaving(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+
+	having, _ := NewHaving(tokens.Iterator(), &projection.Projections{}, context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if having != nil {
+		t.Fatalf("Expected having to be nil but was not")
+	}
+}
+
+func TestHavingWithoutAnyExpression(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Having, "having"))
+
+	_, err := NewHaving(tokens.Iterator(), &projection.Projections{}, context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given having without any expression")
+	}
+}
+
+func TestHavingWithAFunctionNotSupportedInHaving(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Having, "having"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "count"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+
+	_, err := NewHaving(tokens.Iterator(), &projection.Projections{}, context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given having with a function that does not return a boolean")
+	}
+}
+
+func TestHavingWithMultipleExpressions(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Having, "having"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "gt"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "count"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "eq"))
+
+	_, err := NewHaving(tokens.Iterator(), &projection.Projections{}, context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given having with multiple expressions")
+	}
+}
+
+func TestHavingWithAFunctionWithoutClosingParentheses(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Having, "having"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "gt"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "count"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+
+	_, err := NewHaving(tokens.Iterator(), &projection.Projections{}, context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given having with a function without closing parentheses")
+	}
+}
+
+func TestHavingDisplay(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Having, "having"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "gt"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "count"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+
+	having, _ := NewHaving(tokens.Iterator(), &projection.Projections{}, context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := "gt(count(),1)"
+
+	if having.Display() != expected {
+		t.Fatalf("Expected having to be displayed as %v, received %v", expected, having.Display())
+	}
+}
+
+func TestHavingFullyEvaluatesAnAggregateAfterAllTheFiles(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Having, "having"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "and"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "gt"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "count"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "eq"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "ext"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, ".log"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	having, _ := NewHaving(tokens.Iterator(), &projection.Projections{}, newContext)
+
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/multi/TestResultsWithProjections_A.log")
+	if err != nil {
+		panic(err)
+	}
+	fileAttributes := context.ToFileAttributes("../test/resources/TestResultsWithProjections/multi/", file, newContext)
+
+	_ = having.EvaluateWith(fileAttributes, newContext.AllFunctions())
+	passes, _ := having.FullyEvaluate(fileAttributes, newContext.AllFunctions())
+	if passes {
+		t.Fatalf("Expected having to fail given a count of 1")
+	}
+
+	_ = having.EvaluateWith(fileAttributes, newContext.AllFunctions())
+	passes, _ = having.FullyEvaluate(fileAttributes, newContext.AllFunctions())
+	if !passes {
+		t.Fatalf("Expected having to pass given a count of 2")
+	}
+}
+
+func TestHavingCloneStartsWithAFreshAggregateState(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Having, "having"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "gt"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "count"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	having, _ := NewHaving(tokens.Iterator(), &projection.Projections{}, newContext)
+
+	file, err := os.Stat("../test/resources/TestResultsWithProjections/multi/TestResultsWithProjections_A.log")
+	if err != nil {
+		panic(err)
+	}
+	fileAttributes := context.ToFileAttributes("../test/resources/TestResultsWithProjections/multi/", file, newContext)
+	_ = having.EvaluateWith(fileAttributes, newContext.AllFunctions())
+	_ = having.EvaluateWith(fileAttributes, newContext.AllFunctions())
+
+	clone := having.Clone(newContext.AllFunctions())
+	passes, _ := clone.FullyEvaluate(fileAttributes, newContext.AllFunctions())
+	if passes {
+		t.Fatalf("Expected the cloned having to fail given it does not share the aggregate state")
+	}
+}
+
+func TestHavingWithAProjectionAlias(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	projectionTokens := tokenizer.NewEmptyTokens()
+	projectionTokens.Add(tokenizer.NewToken(tokenizer.RawString, "count"))
+	projectionTokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	projectionTokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	projectionTokens.Add(tokenizer.NewToken(tokenizer.RawString, "as"))
+	projectionTokens.Add(tokenizer.NewToken(tokenizer.RawString, "cnt"))
+	projections, _ := projection.NewProjections(projectionTokens.Iterator(), newContext)
+
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Having, "having"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "cnt"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Operator, ">"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "1"))
+
+	having, _ := NewHaving(tokens.Iterator(), projections, newContext)
+	expected := "gt(count(),1)"
+
+	if having.Display() != expected {
+		t.Fatalf("Expected having to be displayed as %v, received %v", expected, having.Display())
+	}
+}
@@ -10,30 +10,67 @@ import (
 )
 
 type Limit struct {
-	Limit uint32
+	Limit  uint32
+	Offset uint32
 }
 
+/*
+limit: limit <count> [offset <offset>] | limit <offset>, <count>
+*/
 func NewLimit(iterator *tokenizer.TokenIterator) (*Limit, error) {
 	if !iterator.HasNext() {
 		return nil, nil
 	}
-	if !iterator.Next().Equals("limit") {
+	if !iterator.Peek().Equals("limit") {
 		return nil, nil
 	}
+	iterator.Next()
 	if !iterator.HasNext() {
 		return nil, errors.New(messages.ErrorMessageLimitValue)
 	}
-	token := iterator.Next()
+	limitValue, err := valueOf(iterator.Next(), messages.ErrorMessageLimitValueInt)
+	if err != nil {
+		return nil, err
+	}
+	if !iterator.HasNext() {
+		return &Limit{Limit: limitValue}, nil
+	}
+	switch {
+	case iterator.Peek().Equals(","):
+		iterator.Next()
+		if !iterator.HasNext() {
+			return nil, errors.New(messages.ErrorMessageLimitValue)
+		}
+		count, err := valueOf(iterator.Next(), messages.ErrorMessageLimitValueInt)
+		if err != nil {
+			return nil, err
+		}
+		return &Limit{Limit: count, Offset: limitValue}, nil
+	case iterator.Peek().Equals("offset"):
+		iterator.Next()
+		if !iterator.HasNext() {
+			return nil, errors.New(messages.ErrorMessageOffsetValue)
+		}
+		offset, err := valueOf(iterator.Next(), messages.ErrorMessageOffsetValueInt)
+		if err != nil {
+			return nil, err
+		}
+		return &Limit{Limit: limitValue, Offset: offset}, nil
+	}
+	return &Limit{Limit: limitValue}, nil
+}
+
+func valueOf(token tokenizer.Token, errorMessage string) (uint32, error) {
 	tokenValue := token.TokenValue
 	if strings.HasPrefix(tokenValue, "+") {
 		tokenValue = tokenValue[1:]
 	}
 	if len(tokenValue) == 0 {
-		return nil, fmt.Errorf(messages.ErrorMessageLimitValueInt, token.TokenValue)
+		return 0, fmt.Errorf(errorMessage, token.TokenValue)
 	}
-	limitValue, err := strconv.ParseUint(tokenValue, 10, 32)
+	value, err := strconv.ParseUint(tokenValue, 10, 32)
 	if err != nil {
-		return nil, fmt.Errorf(messages.ErrorMessageLimitValueInt, token.TokenValue)
+		return 0, fmt.Errorf(errorMessage, token.TokenValue)
 	}
-	return &Limit{Limit: uint32(limitValue)}, nil
+	return uint32(value), nil
 }
@@ -126,3 +126,86 @@ func TestLimitWithOnlyAPlusSign(t *testing.T) {
 		t.Fatalf("Expected an error with limit as %v", "+")
 	}
 }
+
+func TestLimitWithAnOffset(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Limit, "limit"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "10"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Offset, "offset"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "20"))
+
+	limit, _ := NewLimit(tokens.Iterator())
+	if limit.Limit != 10 {
+		t.Fatalf("Expected limit to be %v, but received %v", 10, limit.Limit)
+	}
+	if limit.Offset != 20 {
+		t.Fatalf("Expected offset to be %v, but received %v", 20, limit.Offset)
+	}
+}
+
+func TestLimitWithAnOffsetSeparatedByComma(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Limit, "limit"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "100"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "50"))
+
+	limit, _ := NewLimit(tokens.Iterator())
+	if limit.Limit != 50 {
+		t.Fatalf("Expected limit to be %v, but received %v", 50, limit.Limit)
+	}
+	if limit.Offset != 100 {
+		t.Fatalf("Expected offset to be %v, but received %v", 100, limit.Offset)
+	}
+}
+
+func TestLimitWithoutAnOffsetValue(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Limit, "limit"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "10"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Offset, "offset"))
+
+	_, err := NewLimit(tokens.Iterator())
+	if err == nil {
+		t.Fatalf("Expected an error given no offset value")
+	}
+}
+
+func TestLimitWithANegativeOffset(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Limit, "limit"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "10"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Offset, "offset"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "-2"))
+
+	_, err := NewLimit(tokens.Iterator())
+	if err == nil {
+		t.Fatalf("Expected an error given a negative offset")
+	}
+}
+
+func TestLimitWithoutACountAfterComma(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Limit, "limit"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "10"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+
+	_, err := NewLimit(tokens.Iterator())
+	if err == nil {
+		t.Fatalf("Expected an error given no limit value after comma")
+	}
+}
+
+func TestLimitDoesNotConsumeATokenOtherThanLimit(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "unknown"))
+
+	iterator := tokens.Iterator()
+	limit, _ := NewLimit(iterator)
+	if limit != nil {
+		t.Fatalf("Expected limit to be nil, received %v", limit)
+	}
+	if !iterator.HasNext() {
+		t.Fatalf("Expected the token to be left unconsumed")
+	}
+}
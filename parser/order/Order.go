@@ -3,7 +3,10 @@ package order
 import (
 	"errors"
 	"fmt"
+	"goselect/parser/context"
 	"goselect/parser/error/messages"
+	"goselect/parser/expression"
+	"goselect/parser/projection"
 	"goselect/parser/tokenizer"
 	"strconv"
 )
@@ -22,7 +25,16 @@ const (
 	sortingDirectionDescending     = 1
 )
 
-func NewOrder(iterator *tokenizer.TokenIterator, projectionCount int) (*Order, error) {
+/*
+order by:  [position | alias | attribute | expression] [asc | desc], ...
+example:   order by 1, hsize desc, lower(name)
+an attribute or an expression that is not projected becomes a hidden projection, used only for ordering
+*/
+func NewOrder(
+	iterator *tokenizer.TokenIterator,
+	projections *projection.Projections,
+	ctx *context.ParsingApplicationContext,
+) (*Order, error) {
 	if !iterator.HasNext() {
 		return nil, nil
 	}
@@ -41,6 +53,10 @@ func NewOrder(iterator *tokenizer.TokenIterator, projectionCount int) (*Order, e
 	var directions []bool
 	var expectComma bool
 
+	parser := expression.NewParser(iterator, ctx, expression.ParsingRules{
+		AllowAggregates:     true,
+		InvalidErrorMessage: messages.ErrorMessageInvalidOrderBy,
+	})
 	for iterator.HasNext() && !iterator.Peek().Equals("limit") {
 		token := iterator.Next()
 		if token.Equals("by") {
@@ -53,15 +69,9 @@ func NewOrder(iterator *tokenizer.TokenIterator, projectionCount int) (*Order, e
 			}
 			expectComma = false
 		default:
-			projectionPosition, err := strconv.Atoi(token.TokenValue)
+			projectionPosition, err := positionOf(token, iterator, parser, projections)
 			if err != nil {
-				return nil, fmt.Errorf(messages.ErrorMessageNonZeroPositivePositionsWithExistingError, err)
-			}
-			if projectionPosition <= 0 {
-				return nil, errors.New(messages.ErrorMessageNonZeroPositivePositions)
-			}
-			if projectionPosition > projectionCount {
-				return nil, fmt.Errorf(messages.ErrorMessageOrderByPositionOutOfRange, 1, projectionCount)
+				return nil, err
 			}
 			attributes = append(attributes, AttributeRef{ProjectionPosition: projectionPosition})
 			if sortingDirection(iterator) == sortingDirectionDescending {
@@ -78,6 +88,46 @@ func NewOrder(iterator *tokenizer.TokenIterator, projectionCount int) (*Order, e
 	return &Order{Attributes: attributes, directions: directions}, nil
 }
 
+func NewOrderWith(attributes []AttributeRef, directions []bool) (*Order, error) {
+	if len(attributes) == 0 {
+		return nil, errors.New(messages.ErrorMessageMissingOrderByAttributes)
+	}
+	return &Order{Attributes: attributes, directions: directions}, nil
+}
+
+func positionOf(
+	token tokenizer.Token,
+	iterator *tokenizer.TokenIterator,
+	parser *expression.Parser,
+	projections *projection.Projections,
+) (int, error) {
+
+	if projectionPosition, err := strconv.Atoi(token.TokenValue); err == nil {
+		if projectionPosition <= 0 {
+			return -1, errors.New(messages.ErrorMessageNonZeroPositivePositions)
+		}
+		if projectionPosition > projections.Count() {
+			return -1, fmt.Errorf(messages.ErrorMessageOrderByPositionOutOfRange, 1, projections.Count())
+		}
+		return projectionPosition, nil
+	}
+	isAnExpressionNext := iterator.HasNext() && expression.IsAnInfixOperator(iterator.Peek())
+	if projectionPosition, ok := projections.PositionOfAlias(token.TokenValue); ok && !isAnExpressionNext {
+		return projectionPosition, nil
+	}
+	if !parser.IsAnExpressionStart(token) {
+		return -1, fmt.Errorf(messages.ErrorMessageInvalidOrderByAttribute, token.TokenValue)
+	}
+	anExpression, err := parser.ParseFrom(token)
+	if err != nil {
+		return -1, err
+	}
+	if projectionPosition, ok := projections.PositionOf(anExpression); ok {
+		return projectionPosition, nil
+	}
+	return projections.AddHidden(anExpression), nil
+}
+
 func sortingDirection(iterator *tokenizer.TokenIterator) int {
 	if iterator.HasNext() && iterator.Peek().Equals("desc") {
 		iterator.Next()
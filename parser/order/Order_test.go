@@ -4,6 +4,8 @@
 package order
 
 import (
+	"goselect/parser/context"
+	"goselect/parser/projection"
 	"goselect/parser/tokenizer"
 	"reflect"
 	"testing"
@@ -12,7 +14,7 @@ import (
 func TestOrderWithoutAnyOrderByClause(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
 
-	order, _ := NewOrder(tokens.Iterator(), 1)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 	if order != nil {
 		t.Fatalf("Expected order to be nil but was not")
 	}
@@ -22,7 +24,7 @@ func TestOrderWithAKeywordOtherThanOrder(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "unknown"))
 
-	order, _ := NewOrder(tokens.Iterator(), 1)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 	if order != nil {
 		t.Fatalf("Expected order to be nil but was not")
 	}
@@ -32,7 +34,7 @@ func TestOrderByWithMissingBy(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
 	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
 
-	_, err := NewOrder(tokens.Iterator(), 1)
+	_, err := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 	if err == nil {
 		t.Fatalf("Expected an error given order keyword without by")
 	}
@@ -43,7 +45,7 @@ func TestOrderByWithUnknownKeywordAfterOrder(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "unknown"))
 
-	_, err := NewOrder(tokens.Iterator(), 1)
+	_, err := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 	if err == nil {
 		t.Fatalf("Expected an error given order keyword without by")
 	}
@@ -56,7 +58,7 @@ func TestOrderByWithMissingComma(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 
-	_, err := NewOrder(tokens.Iterator(), 1)
+	_, err := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 	if err == nil {
 		t.Fatalf("Expected an error given order keyword with missing comma")
 	}
@@ -68,7 +70,7 @@ func TestOrderByWithNonNumericOrderByPosition(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "a"))
 
-	_, err := NewOrder(tokens.Iterator(), 1)
+	_, err := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 	if err == nil {
 		t.Fatalf("Expected an error given order keyword with non-numeric order by position")
 	}
@@ -80,7 +82,7 @@ func TestOrderByAttributeInAscending(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
 
-	order, _ := NewOrder(tokens.Iterator(), 1)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 	expectedOrder := Order{
 		Attributes: []AttributeRef{{ProjectionPosition: 1}},
 		directions: []bool{true},
@@ -98,7 +100,7 @@ func TestOrderByAnAttributeInAscendingWithExplicitAsc(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
 	tokens.Add(tokenizer.NewToken(tokenizer.AscendingOrder, "asc"))
 
-	order, _ := NewOrder(tokens.Iterator(), 1)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 	expectedOrder := Order{
 		Attributes: []AttributeRef{{ProjectionPosition: 1}},
 		directions: []bool{true},
@@ -117,7 +119,7 @@ func TestOrderBy2AttributesInAscending(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 
-	order, _ := NewOrder(tokens.Iterator(), 2)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(2), parsingContext())
 	expectedOrder := Order{
 		Attributes: []AttributeRef{{ProjectionPosition: 1}, {ProjectionPosition: 2}},
 		directions: []bool{true, true},
@@ -135,7 +137,7 @@ func TestOrderByAAttributeInDescending(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
 	tokens.Add(tokenizer.NewToken(tokenizer.DescendingOrder, "desc"))
 
-	order, _ := NewOrder(tokens.Iterator(), 1)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 	expectedOrder := Order{
 		Attributes: []AttributeRef{{ProjectionPosition: 1}},
 		directions: []bool{false},
@@ -156,7 +158,7 @@ func TestOrderBy2AttributesInDescending(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 	tokens.Add(tokenizer.NewToken(tokenizer.DescendingOrder, "desc"))
 
-	order, _ := NewOrder(tokens.Iterator(), 2)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(2), parsingContext())
 	expectedOrder := Order{
 		Attributes: []AttributeRef{{ProjectionPosition: 1}, {ProjectionPosition: 2}},
 		directions: []bool{false, false},
@@ -176,7 +178,7 @@ func TestOrderBy2AttributesOneInAscendingOtherInDescending(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 	tokens.Add(tokenizer.NewToken(tokenizer.DescendingOrder, "desc"))
 
-	order, _ := NewOrder(tokens.Iterator(), 2)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(2), parsingContext())
 	expectedOrder := Order{
 		Attributes: []AttributeRef{{ProjectionPosition: 1}, {ProjectionPosition: 2}},
 		directions: []bool{true, false},
@@ -192,7 +194,7 @@ func TestThrowsAErrorGivenNoAttributeAfterOrderBy(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
 	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
 
-	_, err := NewOrder(tokens.Iterator(), 1)
+	_, err := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 
 	if err == nil {
 		t.Fatalf("Expected an error when no attributes are given after order by but received none")
@@ -205,7 +207,7 @@ func TestThrowsAErrorOrderByAttributePositionZero(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "0"))
 
-	_, err := NewOrder(tokens.Iterator(), 1)
+	_, err := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 
 	if err == nil {
 		t.Fatalf("Expected an error when 0 is given as the order by position")
@@ -218,7 +220,7 @@ func TestThrowsAErrorOrderByAttributePositionNegative(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "-9"))
 
-	_, err := NewOrder(tokens.Iterator(), 1)
+	_, err := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 
 	if err == nil {
 		t.Fatalf("Expected an error when -9 is given as the order by position")
@@ -231,7 +233,7 @@ func TestThrowsAErrorOrderByAttributePositionBeyondProjectCount(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 
-	_, err := NewOrder(tokens.Iterator(), 1)
+	_, err := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
 
 	if err == nil {
 		t.Fatalf("Expected an error when 2 is given as the order by position and projection count is 1")
@@ -247,7 +249,7 @@ func TestOrderBy2AttributesWithOneAsTheProjectionPosition(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 
-	order, _ := NewOrder(tokens.Iterator(), 2)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(2), parsingContext())
 	expectedOrder := Order{
 		Attributes: []AttributeRef{{ProjectionPosition: 1}, {ProjectionPosition: 2}},
 		directions: []bool{false, true},
@@ -267,7 +269,7 @@ func TestIsAscendingAtAnIndex(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 
-	order, _ := NewOrder(tokens.Iterator(), 2)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(2), parsingContext())
 	isAscendingAt := order.IsAscendingAt(0)
 
 	if isAscendingAt {
@@ -284,7 +286,7 @@ func TestIsDescendingAtAnIndex(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 
-	order, _ := NewOrder(tokens.Iterator(), 2)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(2), parsingContext())
 	isAscendingAt := order.IsAscendingAt(1)
 
 	if !isAscendingAt {
@@ -301,10 +303,133 @@ func TestIsAscendingAtAnIllegalIndex(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "2"))
 
-	order, _ := NewOrder(tokens.Iterator(), 2)
+	order, _ := NewOrder(tokens.Iterator(), projectionsWithCount(2), parsingContext())
 	isAscendingAt := order.IsAscendingAt(3)
 
 	if isAscendingAt {
 		t.Fatalf("Expected descending order at index 3 but received ascending")
 	}
 }
+
+func TestOrderByAnAlias(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "hsize"))
+	tokens.Add(tokenizer.NewToken(tokenizer.DescendingOrder, "desc"))
+
+	projections := projectionsOf("name", ",", "fmtsize", "(", "size", ")", "as", "hsize")
+	order, _ := NewOrder(tokens.Iterator(), projections, parsingContext())
+	expected := []AttributeRef{{ProjectionPosition: 2}}
+
+	if !reflect.DeepEqual(expected, order.Attributes) {
+		t.Fatalf("Expected order by attributes to be %v, received %v", expected, order.Attributes)
+	}
+	if order.IsAscendingAt(0) {
+		t.Fatalf("Expected descending order at index 0 but received ascending")
+	}
+}
+
+func TestOrderByAProjectedAttribute(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+
+	projections := projectionsOf("name", ",", "size")
+	order, _ := NewOrder(tokens.Iterator(), projections, parsingContext())
+	expected := []AttributeRef{{ProjectionPosition: 2}}
+
+	if !reflect.DeepEqual(expected, order.Attributes) {
+		t.Fatalf("Expected order by attributes to be %v, received %v", expected, order.Attributes)
+	}
+	if projections.HiddenCount() != 0 {
+		t.Fatalf("Expected no hidden projections, received %v", projections.HiddenCount())
+	}
+}
+
+func TestOrderByAProjectedExpression(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "lower"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+
+	projections := projectionsOf("size", ",", "lower", "(", "name", ")")
+	order, _ := NewOrder(tokens.Iterator(), projections, parsingContext())
+	expected := []AttributeRef{{ProjectionPosition: 2}}
+
+	if !reflect.DeepEqual(expected, order.Attributes) {
+		t.Fatalf("Expected order by attributes to be %v, received %v", expected, order.Attributes)
+	}
+}
+
+func TestOrderByAnExpressionThatIsNotProjected(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "lower"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "ext"))
+
+	projections := projectionsOf("size")
+	order, _ := NewOrder(tokens.Iterator(), projections, parsingContext())
+	expected := []AttributeRef{{ProjectionPosition: 2}, {ProjectionPosition: 3}}
+
+	if !reflect.DeepEqual(expected, order.Attributes) {
+		t.Fatalf("Expected order by attributes to be %v, received %v", expected, order.Attributes)
+	}
+	if projections.HiddenCount() != 2 {
+		t.Fatalf("Expected 2 hidden projections, received %v", projections.HiddenCount())
+	}
+	if projections.Count() != 1 {
+		t.Fatalf("Expected 1 visible projection, received %v", projections.Count())
+	}
+}
+
+func TestThrowsAnErrorGivenAnInvalidExpressionInOrderBy(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+	tokens.Add(tokenizer.NewToken(tokenizer.By, "by"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "lower"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+
+	_, err := NewOrder(tokens.Iterator(), projectionsWithCount(1), parsingContext())
+	if err == nil {
+		t.Fatalf("Expected an error given an invalid expression in order by")
+	}
+}
+
+func parsingContext() *context.ParsingApplicationContext {
+	return context.NewContext(context.NewFunctions(), context.NewAttributes())
+}
+
+func projectionsWithCount(count int) *projection.Projections {
+	attributes := []string{"name", "size", "ext"}
+	var values []string
+	for index := 0; index < count; index++ {
+		if index > 0 {
+			values = append(values, ",")
+		}
+		values = append(values, attributes[index])
+	}
+	return projectionsOf(values...)
+}
+
+func projectionsOf(values ...string) *projection.Projections {
+	tokens := tokenizer.NewEmptyTokens()
+	for _, value := range values {
+		tokens.Add(tokenizer.NewToken(tokenizer.RawString, value))
+	}
+	projections, err := projection.NewProjections(tokens.Iterator(), parsingContext())
+	if err != nil {
+		panic(err)
+	}
+	return projections
+}
@@ -7,10 +7,23 @@ import (
 	"goselect/parser/error/messages"
 	"goselect/parser/expression"
 	"goselect/parser/tokenizer"
+	"strings"
 )
 
 type Projections struct {
 	expressions expression.Expressions
+	aliases     []string
+	hiddenCount int
+	distinct    bool
+	windows     []*WindowColumn
+}
+
+type WindowColumn struct {
+	Position           int
+	Expression         *expression.Expression
+	ArgPositions       []int
+	PartitionPositions []int
+	OrderPositions     []int
 }
 
 func NewProjections(
@@ -18,22 +31,83 @@ func NewProjections(
 	context *context.ParsingApplicationContext,
 ) (*Projections, error) {
 
-	expressions, err := all(tokenIterator, context)
+	expressions, aliases, distinct, err := all(tokenIterator, context)
 	if err != nil {
 		return nil, err
 	}
 	if expressions.Count() == 0 {
 		return nil, errors.New(messages.ErrorMessageExpectedExpressionInProjection)
 	}
-	return &Projections{expressions: expressions}, nil
+	projections := &Projections{expressions: expressions, aliases: aliases, distinct: distinct}
+	if err := projections.addWindowColumns(); err != nil {
+		return nil, err
+	}
+	return projections, nil
+}
+
+func NewProjectionsWith(expressions []*expression.Expression, aliases []string, distinct bool) (*Projections, error) {
+	if len(expressions) == 0 {
+		return nil, errors.New(messages.ErrorMessageExpectedExpressionInProjection)
+	}
+	projections := &Projections{expressions: expression.Expressions{Expressions: expressions}, aliases: aliases, distinct: distinct}
+	if err := projections.addWindowColumns(); err != nil {
+		return nil, err
+	}
+	return projections, nil
+}
+
+func (projections Projections) Clone(functions *context.AllFunctions) *Projections {
+	return &Projections{
+		expressions: projections.expressions.Clone(functions),
+		aliases:     projections.aliases,
+		hiddenCount: projections.hiddenCount,
+		distinct:    projections.distinct,
+		windows:     projections.windows,
+	}
+}
+
+func (projections Projections) Expressions() expression.Expressions {
+	return projections.expressions
+}
+
+func (projections Projections) AliasAt(index int) string {
+	if index < len(projections.aliases) {
+		return projections.aliases[index]
+	}
+	return ""
+}
+
+func (projections Projections) IsDistinct() bool {
+	return projections.distinct
 }
 
 func (projections Projections) Count() int {
-	return projections.expressions.Count()
+	return projections.expressions.Count() - projections.hiddenCount
+}
+
+func (projections Projections) HiddenCount() int {
+	return projections.hiddenCount
+}
+
+func (projections Projections) Windows() []*WindowColumn {
+	return projections.windows
+}
+
+func (projections Projections) HasWindows() bool {
+	return len(projections.windows) > 0
+}
+
+func (projections Projections) IsAWindowAt(position int) bool {
+	for _, window := range projections.windows {
+		if window.Position == position {
+			return true
+		}
+	}
+	return false
 }
 
 func (projections Projections) AggregationCount() int {
-	return projections.expressions.AggregationCount()
+	return projections.visibleExpressions().AggregationCount()
 }
 
 func (projections Projections) HasAllAggregates() bool {
@@ -41,7 +115,67 @@ func (projections Projections) HasAllAggregates() bool {
 }
 
 func (projections Projections) DisplayableAttributes() []string {
-	return projections.expressions.DisplayableAttributes()
+	attributes := projections.visibleExpressions().DisplayableAttributes()
+	for index, alias := range projections.aliases {
+		if index < len(attributes) && len(alias) > 0 {
+			attributes[index] = alias
+		}
+	}
+	return attributes
+}
+
+func (projections Projections) PositionOfAlias(alias string) (int, bool) {
+	for index, anAlias := range projections.aliases {
+		if len(anAlias) > 0 && strings.EqualFold(anAlias, alias) {
+			return index + 1, true
+		}
+	}
+	return -1, false
+}
+
+func (projections Projections) PositionOf(anExpression *expression.Expression) (int, bool) {
+	display := expression.Expressions{Expressions: []*expression.Expression{anExpression}}.DisplayableAttributes()[0]
+	for index, attribute := range projections.visibleExpressions().DisplayableAttributes() {
+		if strings.EqualFold(attribute, display) {
+			return index + 1, true
+		}
+	}
+	return -1, false
+}
+
+func (projections *Projections) AddHidden(anExpression *expression.Expression) int {
+	projections.expressions = expression.Expressions{
+		Expressions: append(projections.expressions.Expressions, anExpression),
+	}
+	projections.hiddenCount = projections.hiddenCount + 1
+	return projections.expressions.Count()
+}
+
+/*
+window: a window function is a projection of its own, its args, partition by and order by become hidden projections
+hidden: the hidden projections are evaluated for every row, a window function is evaluated over them after the traversal
+*/
+func (projections *Projections) addWindowColumns() error {
+	for index, anExpression := range projections.visibleExpressions().Expressions {
+		if !anExpression.HasAWindow() {
+			continue
+		}
+		if !anExpression.IsAWindowFunction() {
+			return errors.New(messages.ErrorMessageWindowFunctionNotSupported)
+		}
+		column := &WindowColumn{Position: index + 1, Expression: anExpression}
+		for _, arg := range anExpression.FunctionArgs() {
+			column.ArgPositions = append(column.ArgPositions, projections.AddHidden(arg))
+		}
+		for _, partitionBy := range anExpression.Window().PartitionBy() {
+			column.PartitionPositions = append(column.PartitionPositions, projections.AddHidden(partitionBy))
+		}
+		for _, orderBy := range anExpression.Window().OrderBy() {
+			column.OrderPositions = append(column.OrderPositions, projections.AddHidden(orderBy))
+		}
+		projections.windows = append(projections.windows, column)
+	}
+	return nil
 }
 
 func (projections Projections) EvaluateWith(
@@ -51,112 +185,84 @@ func (projections Projections) EvaluateWith(
 	return projections.expressions.EvaluateWith(fileAttributes, functions)
 }
 
+func (projections Projections) visibleExpressions() expression.Expressions {
+	return expression.Expressions{Expressions: projections.expressions.Expressions[:projections.Count()]}
+}
+
 /*
-projection:  attributes Or functions Or expressions
+projection:  [distinct] attributes Or functions Or expressions
 attributes:  name, size etc
 functions: 	 min(size), lower(name), min(Count(size)) etc
-expressions: add(..), mul(..), gt(..)
+expressions: add(..), mul(..), gt(..), size / 1024, lower(name) = readme.md
+alias:       any of the above followed by as <alias>, fmtsize(size) as hsize
+windows:     rownumber() over (partition by ext order by size desc), sum(size) over (order by mtime)
+constants:   a quoted value, a number, null, a duration or a relative time, 'x', 5, 1.5, null, 2h, yesterday, start of month
+glued:       an arithmetic operator without spaces around it, -size, size/1024, 1+2 is an error in every mode
+strict:      any other token or a trailing comma is an error in the strict mode and is ignored otherwise
 */
 func all(
 	tokenIterator *tokenizer.TokenIterator,
 	ctx *context.ParsingApplicationContext,
-) (expression.Expressions, error) {
+) (expression.Expressions, []string, bool, error) {
 
 	var expressions []*expression.Expression
-	var expectComma bool
+	var aliases []string
+	var expectComma, distinct bool
 
+	parser := expression.NewParser(tokenIterator, ctx, expression.ParsingRules{
+		AllowAggregates:     true,
+		AllowWindows:        true,
+		InvalidErrorMessage: messages.ErrorMessageInvalidProjection,
+	})
+	if tokenIterator.HasNext() && tokenIterator.Peek().Equals("select") {
+		tokenIterator.Next()
+	}
 	for tokenIterator.HasNext() && !tokenIterator.Peek().Equals("from") {
 		token := tokenIterator.Next()
 		switch {
 		case expectComma:
 			if !token.Equals(",") {
-				return expression.Expressions{}, errors.New(messages.ErrorMessageMissingCommaProjection)
+				return expression.Expressions{}, nil, false, errors.New(messages.ErrorMessageMissingCommaProjection)
 			}
 			expectComma = false
+		case token.Equals("distinct") && len(expressions) == 0 && !distinct:
+			distinct = true
 		case context.IsAWildcardAttribute(token.TokenValue):
-			expressions = append(expressions, expression.WithAttributes(context.AttributesOnWildcard())...)
-			expectComma = true
-		case ctx.IsASupportedAttribute(token.TokenValue):
-			expressions = append(expressions, expression.WithAttribute(token.TokenValue))
+			wildcardAttributes := expression.WithAttributes(context.AttributesOnWildcard())
+			expressions = append(expressions, wildcardAttributes...)
+			aliases = append(aliases, make([]string, len(wildcardAttributes))...)
 			expectComma = true
-		case ctx.IsASupportedFunction(token.TokenValue):
-			function, err := function(token, tokenIterator, ctx)
+		case parser.IsAnExpressionStart(token) || token.IsANumber() || token.IsANull() || context.IsATimeLiteral(token.TokenValue):
+			anExpression, err := parser.ParseFrom(token)
 			if err != nil {
-				return expression.Expressions{}, err
+				return expression.Expressions{}, nil, false, err
 			}
-			expressions = append(expressions, expression.WithFunctionInstance(function))
+			alias, err := aliasOf(tokenIterator)
+			if err != nil {
+				return expression.Expressions{}, nil, false, err
+			}
+			expressions = append(expressions, anExpression)
+			aliases = append(aliases, alias)
 			expectComma = true
+		case parser.IsGluedToAnArithmeticOperator(token):
+			return expression.Expressions{}, nil, false, fmt.Errorf(messages.ErrorMessageOperatorWithoutSpaces, token.TokenValue)
+		case ctx.IsInStrictMode():
+			return expression.Expressions{}, nil, false, fmt.Errorf(messages.ErrorMessageUnexpectedTokenInProjection, token.TokenValue)
 		}
 	}
-	return expression.Expressions{Expressions: expressions}, nil
+	if ctx.IsInStrictMode() && len(expressions) > 0 && !expectComma {
+		return expression.Expressions{}, nil, false, errors.New(messages.ErrorMessageTrailingCommaInProjection)
+	}
+	return expression.Expressions{Expressions: expressions}, aliases, distinct, nil
 }
 
-func function(
-	functionNameToken tokenizer.Token,
-	tokenIterator *tokenizer.TokenIterator,
-	ctx *context.ParsingApplicationContext,
-) (*expression.FunctionInstance, error) {
-
-	var parseFunction func(functionNameToken tokenizer.Token) (*expression.FunctionInstance, error)
-
-	aggregateFunctionStateOrNil := func(fn string) *context.FunctionState {
-		if ctx.IsAnAggregateFunction(fn) {
-			return ctx.InitialState(fn)
-		}
-		return nil
-	}
-	parseFunction = func(functionNameToken tokenizer.Token) (*expression.FunctionInstance, error) {
-		var functionArgs []*expression.Expression
-		expectOpeningParentheses := true
-
-		for tokenIterator.HasNext() && !tokenIterator.Peek().Equals("from") {
-			token := tokenIterator.Next()
-			switch {
-			case expectOpeningParentheses:
-				if !token.Equals("(") {
-					return nil, fmt.Errorf(messages.ErrorMessageOpeningParenthesesProjection, functionNameToken.TokenValue)
-				}
-				expectOpeningParentheses = false
-			case token.Equals(")"):
-				var isAggregate bool
-				if ctx.IsAnAggregateFunction(functionNameToken.TokenValue) {
-					isAggregate = true
-				} else {
-					isAggregate = false
-				}
-				return expression.FunctionInstanceWith(
-					functionNameToken.TokenValue,
-					functionArgs,
-					aggregateFunctionStateOrNil(functionNameToken.TokenValue),
-					isAggregate,
-				), nil
-			case ctx.IsASupportedFunction(token.TokenValue):
-				fn, err := parseFunction(token)
-				if err != nil {
-					return nil, err
-				}
-				functionArgs = append(functionArgs, expression.WithFunctionInstance(fn))
-			case ctx.IsASupportedAttribute(token.TokenValue):
-				functionArgs = append(functionArgs, expression.WithAttribute(token.TokenValue))
-				expectOpeningParentheses = false
-			default:
-				if !token.Equals(",") {
-					value, err := context.ToValue(token)
-					if err != nil {
-						value = context.StringValue(token.TokenValue)
-					}
-					functionArgs = append(functionArgs, expression.WithValue(value))
-				}
-			}
-		}
-		return nil, nil
-	}
-	fn, err := parseFunction(functionNameToken)
-	if err != nil {
-		return nil, err
+func aliasOf(tokenIterator *tokenizer.TokenIterator) (string, error) {
+	if !tokenIterator.HasNext() || !tokenIterator.Peek().Equals("as") {
+		return "", nil
 	}
-	if fn == nil {
-		return nil, errors.New(messages.ErrorMessageInvalidProjection)
+	tokenIterator.Next()
+	if !tokenIterator.HasNext() || tokenIterator.Peek().Equals("from") || tokenIterator.Peek().Equals(",") {
+		return "", errors.New(messages.ErrorMessageExpectedAliasAfterAs)
 	}
-	return fn, nil
+	return tokenIterator.Next().TokenValue, nil
 }
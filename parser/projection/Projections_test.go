@@ -5,6 +5,7 @@ package projection
 
 import (
 	"goselect/parser/context"
+	"goselect/parser/expression"
 	"goselect/parser/tokenizer"
 	"reflect"
 	"testing"
@@ -422,3 +423,190 @@ func TestProjectionEvaluation4(t *testing.T) {
 		t.Fatalf("Expected fullyEvaluated to be %v, received %v", fullyEvaluated, fullyEvaluated[0])
 	}
 }
+
+func TestAllAttributesWithInfixOperators(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Operator, "/"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "1024"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Operator, "+"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "2"))
+	tokens.Add(tokenizer.NewToken(tokenizer.From, "from"))
+
+	projections, _ := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := []string{"name", "div(size,1024)", "add(1,2)"}
+
+	if !reflect.DeepEqual(expected, projections.DisplayableAttributes()) {
+		t.Fatalf("Expected attributes to be %v, received %v", expected, projections.DisplayableAttributes())
+	}
+}
+
+func TestProjectionHasAllAggregatesWithInfixOperators(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "sum"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Operator, "/"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "1024"))
+
+	projections, _ := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if !projections.HasAllAggregates() {
+		t.Fatalf("Expected all the projections to be aggregates but were not")
+	}
+}
+
+func TestAllAttributesWithAnAlias(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "fmtsize"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "as"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "hsize"))
+
+	projections, _ := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := []string{"name", "hsize"}
+
+	if !reflect.DeepEqual(expected, projections.DisplayableAttributes()) {
+		t.Fatalf("Expected attributes to be %v, received %v", expected, projections.DisplayableAttributes())
+	}
+}
+
+func TestPositionOfAnAlias(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "as"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "bytes"))
+
+	projections, _ := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	position, ok := projections.PositionOfAlias("BYTES")
+
+	if !ok || position != 2 {
+		t.Fatalf("Expected position of the alias to be 2, received %v", position)
+	}
+}
+
+func TestThrowsAnErrorWithMissingAliasAfterAs(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "as"))
+	tokens.Add(tokenizer.NewToken(tokenizer.From, "from"))
+
+	_, err := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given a missing alias after as but received none")
+	}
+}
+
+func TestHiddenProjectionsAreNotDisplayed(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+
+	projections, _ := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	position := projections.AddHidden(expression.WithAttribute("size"))
+	expected := []string{"name"}
+
+	if position != 2 {
+		t.Fatalf("Expected position of the hidden projection to be 2, received %v", position)
+	}
+	if !reflect.DeepEqual(expected, projections.DisplayableAttributes()) {
+		t.Fatalf("Expected attributes to be %v, received %v", expected, projections.DisplayableAttributes())
+	}
+	if projections.Count() != 1 {
+		t.Fatalf("Expected visible projection count to be 1, received %v", projections.Count())
+	}
+}
+
+func TestProjectionsWithDistinct(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "distinct"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "ext"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+
+	projections, _ := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := []string{"ext", "size"}
+
+	if !projections.IsDistinct() {
+		t.Fatalf("Expected projections to be distinct")
+	}
+	if !reflect.DeepEqual(expected, projections.DisplayableAttributes()) {
+		t.Fatalf("Expected attributes to be %v, received %v", expected, projections.DisplayableAttributes())
+	}
+}
+
+func TestProjectionsWithoutDistinct(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "ext"))
+
+	projections, _ := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if projections.IsDistinct() {
+		t.Fatalf("Expected projections to not be distinct")
+	}
+}
+
+func TestProjectionsIgnoreAnUnknownTokenOutsideTheStrictMode(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "nmae"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+
+	projections, err := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err != nil {
+		t.Fatalf("Expected no error outside the strict mode, received %v", err)
+	}
+	expected := []string{"size"}
+	if !reflect.DeepEqual(expected, projections.DisplayableAttributes()) {
+		t.Fatalf("Expected attributes to be %v, received %v", expected, projections.DisplayableAttributes())
+	}
+}
+
+func TestProjectionsWithAnUnknownTokenInTheStrictMode(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "select"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "nmae"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+
+	_, err := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode())
+	if err == nil {
+		t.Fatalf("Expected an error given an unknown token in the strict mode")
+	}
+}
+
+func TestProjectionsWithATrailingCommaInTheStrictMode(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "select"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "from"))
+
+	_, err := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode())
+	if err == nil {
+		t.Fatalf("Expected an error given a trailing comma in the strict mode")
+	}
+}
+
+func TestProjectionsWithAttributesInTheStrictMode(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "select"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+
+	projections, err := NewProjections(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode())
+	if err != nil {
+		t.Fatalf("Expected no error in the strict mode, received %v", err)
+	}
+	expected := []string{"name", "size"}
+	if !reflect.DeepEqual(expected, projections.DisplayableAttributes()) {
+		t.Fatalf("Expected attributes to be %v, received %v", expected, projections.DisplayableAttributes())
+	}
+}
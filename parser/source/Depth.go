@@ -0,0 +1,63 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser/error/messages"
+	"goselect/parser/tokenizer"
+	"strconv"
+	"strings"
+)
+
+type Depth struct {
+	Min uint32
+	Max uint32
+}
+
+/*
+depth:   depth <max> | depth <min> to <max>, files directly inside a source directory are at depth 1
+example: from . depth 2
+example: from ~/projects depth 2 to 3
+*/
+func NewDepth(minDepth uint32, maxDepth uint32) (*Depth, error) {
+	if maxDepth == 0 {
+		return nil, fmt.Errorf(messages.ErrorMessageDepthValueInt, maxDepth)
+	}
+	if minDepth > maxDepth {
+		return nil, fmt.Errorf(messages.ErrorMessageInvalidDepthRange, minDepth, maxDepth)
+	}
+	return &Depth{Min: minDepth, Max: maxDepth}, nil
+}
+
+func depthOf(tokenIterator *tokenizer.TokenIterator) (*Depth, error) {
+	if !tokenIterator.HasNext() || !tokenIterator.Peek().Equals("depth") {
+		return nil, nil
+	}
+	tokenIterator.Next()
+	maxDepth, err := depthValueOf(tokenIterator)
+	if err != nil {
+		return nil, err
+	}
+	if !tokenIterator.HasNext() || !tokenIterator.Peek().Equals("to") {
+		return NewDepth(0, maxDepth)
+	}
+	tokenIterator.Next()
+	minDepth := maxDepth
+	maxDepth, err = depthValueOf(tokenIterator)
+	if err != nil {
+		return nil, err
+	}
+	return NewDepth(minDepth, maxDepth)
+}
+
+func depthValueOf(tokenIterator *tokenizer.TokenIterator) (uint32, error) {
+	if !tokenIterator.HasNext() || isAClauseAfterSource(tokenIterator.Peek()) {
+		return 0, tokenIterator.ErrorAtNext(errors.New(messages.ErrorMessageDepthValue))
+	}
+	token := tokenIterator.Next()
+	value, err := strconv.ParseUint(strings.TrimPrefix(token.TokenValue, "+"), 10, 32)
+	if err != nil || value == 0 {
+		return 0, fmt.Errorf(messages.ErrorMessageDepthValueInt, token.TokenValue)
+	}
+	return uint32(value), nil
+}
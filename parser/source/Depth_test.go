@@ -0,0 +1,124 @@
+//go:build unit
+// +build unit
+
+package source
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/tokenizer"
+	"testing"
+)
+
+func TestCreatesASourceWithAMaximumDepth(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "."))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "depth"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "2"))
+
+	source, err := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if !source.IsDepthDefined() || source.Depth.Min != 0 || source.Depth.Max != 2 {
+		t.Fatalf("Expected depth to be up to %v, received %v", 2, source.Depth)
+	}
+}
+
+func TestCreatesASourceWithADepthRange(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "."))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "depth"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "2"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "to"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "3"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "where"))
+
+	iterator := tokens.Iterator()
+	source, err := NewSource(iterator, context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if !source.IsDepthDefined() || source.Depth.Min != 2 || source.Depth.Max != 3 {
+		t.Fatalf("Expected depth to be from %v to %v, received %v", 2, 3, source.Depth)
+	}
+	if !iterator.HasNext() || !iterator.Peek().Equals("where") {
+		t.Fatalf("Expected where to be the next token after the depth clause")
+	}
+}
+
+func TestCreatesASourceWithoutADepth(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "."))
+
+	source, _ := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if source.IsDepthDefined() {
+		t.Fatalf("Expected depth to be undefined, received %v", source.Depth)
+	}
+}
+
+func TestCreatesAJoinedSourceWithADepth(t *testing.T) {
+	source, err := newSourceFrom(". as a join . as b on eq(a.name, b.name) depth 1")
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if len(source.Joins) != 1 || !source.IsDepthDefined() || source.Depth.Max != 1 {
+		t.Fatalf("Expected a join and a depth of %v, received %v joins and depth %v", 1, len(source.Joins), source.Depth)
+	}
+}
+
+func TestThrowsAnErrorWithoutADepthValue(t *testing.T) {
+	_, err := newSourceFrom(". depth")
+	if err == nil || err.Error() != messages.ErrorMessageDepthValue {
+		t.Fatalf("Expected error %v, received %v", messages.ErrorMessageDepthValue, err)
+	}
+}
+
+func TestThrowsAnErrorWithADepthValueBeforeWhere(t *testing.T) {
+	_, err := newSourceFrom(". depth where")
+	if err == nil || err.Error() != messages.ErrorMessageDepthValue {
+		t.Fatalf("Expected error %v, received %v", messages.ErrorMessageDepthValue, err)
+	}
+}
+
+func TestThrowsAnErrorWithAZeroDepth(t *testing.T) {
+	_, err := newSourceFrom(". depth 0")
+	if err == nil {
+		t.Fatalf("Expected an error with a zero depth")
+	}
+}
+
+func TestThrowsAnErrorWithANonNumericDepth(t *testing.T) {
+	_, err := newSourceFrom(". depth two")
+	if err == nil {
+		t.Fatalf("Expected an error with a non numeric depth")
+	}
+}
+
+func TestThrowsAnErrorWithoutTheMaximumDepthInARange(t *testing.T) {
+	_, err := newSourceFrom(". depth 1 to")
+	if err == nil || err.Error() != messages.ErrorMessageDepthValue {
+		t.Fatalf("Expected error %v, received %v", messages.ErrorMessageDepthValue, err)
+	}
+}
+
+func TestThrowsAnErrorWithTheMinimumDepthGreaterThanTheMaximumDepth(t *testing.T) {
+	_, err := NewDepth(3, 2)
+	if err == nil {
+		t.Fatalf("Expected an error with the minimum depth greater than the maximum depth")
+	}
+}
+
+func TestCreatesADepthWithoutAMinimum(t *testing.T) {
+	depth, err := NewDepth(0, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if depth.Min != 0 || depth.Max != 2 {
+		t.Fatalf("Expected depth to be up to %v, received %v", 2, depth)
+	}
+}
+
+func newSourceFrom(source string) (*Source, error) {
+	return NewSource(tokenizer.NewTokenizer(source).Tokenize().Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+}
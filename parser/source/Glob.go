@@ -0,0 +1,115 @@
+package source
+
+import (
+	"fmt"
+	"goselect/parser/error/messages"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const doubleStar = "**"
+
+type Glob struct {
+	Pattern  string
+	segments []string
+}
+
+/*
+glob:    directory/pattern, the pattern starts at the first path segment with *, ? or [ and ** matches any number of directories
+example: from ~/logs/app-*.log
+example: from ./src/** matches every file and directory inside ./src at any depth
+*/
+func NewGlob(pattern string) (*Glob, error) {
+	var segments []string
+	for _, segment := range strings.Split(filepath.ToSlash(pattern), "/") {
+		if len(segment) == 0 {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, fmt.Errorf(messages.ErrorMessageInvalidGlobPattern, pattern)
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf(messages.ErrorMessageInvalidGlobPattern, pattern)
+	}
+	return &Glob{Pattern: strings.Join(segments, "/"), segments: segments}, nil
+}
+
+func IsAGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+func (glob *Glob) Matches(pathFromRoot string) bool {
+	return matchesSegments(glob.segments, segmentsOf(pathFromRoot))
+}
+
+func (glob *Glob) CanMatchInside(directoryFromRoot string) bool {
+	return matchesPrefixSegments(glob.segments, segmentsOf(directoryFromRoot))
+}
+
+func (glob *Glob) PathFrom(directory string) string {
+	if directory == "." {
+		return "./" + glob.Pattern
+	}
+	return strings.TrimSuffix(directory, "/") + "/" + glob.Pattern
+}
+
+func splitGlob(globPath string) (string, *Glob, error) {
+	segments := strings.Split(filepath.ToSlash(globPath), "/")
+	staticSegments := 0
+	for staticSegments < len(segments) && !IsAGlob(segments[staticSegments]) {
+		staticSegments = staticSegments + 1
+	}
+	glob, err := NewGlob(strings.Join(segments[staticSegments:], "/"))
+	if err != nil {
+		return "", nil, err
+	}
+	directory := strings.Join(segments[:staticSegments], "/")
+	switch {
+	case staticSegments == 0:
+		directory = "."
+	case len(directory) == 0:
+		directory = "/"
+	}
+	return filepath.FromSlash(directory), glob, nil
+}
+
+func segmentsOf(pathFromRoot string) []string {
+	var segments []string
+	for _, segment := range strings.Split(filepath.ToSlash(pathFromRoot), "/") {
+		if len(segment) > 0 && segment != "." {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func matchesSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == doubleStar {
+		return matchesSegments(pattern[1:], segments) || (len(segments) > 0 && matchesSegments(pattern, segments[1:]))
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, _ := path.Match(pattern[0], segments[0])
+	return matched && matchesSegments(pattern[1:], segments[1:])
+}
+
+func matchesPrefixSegments(pattern []string, segments []string) bool {
+	if len(segments) == 0 {
+		return true
+	}
+	if len(pattern) == 0 {
+		return false
+	}
+	if pattern[0] == doubleStar {
+		return true
+	}
+	matched, _ := path.Match(pattern[0], segments[0])
+	return matched && matchesPrefixSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,127 @@
+//go:build unit
+// +build unit
+
+package source
+
+import (
+	"fmt"
+	"goselect/parser/error/messages"
+	"testing"
+)
+
+func TestGlobMatchesAPathWithASingleStar(t *testing.T) {
+	glob, _ := NewGlob("*.go")
+	if !glob.Matches("main.go") {
+		t.Fatalf("Expected %v to match %v", glob.Pattern, "main.go")
+	}
+	if glob.Matches("cmd/main.go") {
+		t.Fatalf("Expected %v to not match %v", glob.Pattern, "cmd/main.go")
+	}
+}
+
+func TestGlobMatchesPathsAtAnyDepthWithADoubleStar(t *testing.T) {
+	glob, _ := NewGlob("**/*.go")
+	for _, path := range []string{"main.go", "cmd/main.go", "parser/source/Glob.go"} {
+		if !glob.Matches(path) {
+			t.Fatalf("Expected %v to match %v", glob.Pattern, path)
+		}
+	}
+	if glob.Matches("README.md") {
+		t.Fatalf("Expected %v to not match %v", glob.Pattern, "README.md")
+	}
+}
+
+func TestGlobMatchesADoubleStarInTheMiddle(t *testing.T) {
+	glob, _ := NewGlob("parser/**/test/*.json")
+	for _, path := range []string{"parser/test/a.json", "parser/source/test/a.json"} {
+		if !glob.Matches(path) {
+			t.Fatalf("Expected %v to match %v", glob.Pattern, path)
+		}
+	}
+	if glob.Matches("parser/test/resources/a.json") {
+		t.Fatalf("Expected %v to not match %v", glob.Pattern, "parser/test/resources/a.json")
+	}
+}
+
+func TestGlobMatchesACharacterClassAndAQuestionMark(t *testing.T) {
+	glob, _ := NewGlob("*/app-[ab]?.log")
+	if !glob.Matches("server/app-a1.log") {
+		t.Fatalf("Expected %v to match %v", glob.Pattern, "server/app-a1.log")
+	}
+	if glob.Matches("server/app-c1.log") {
+		t.Fatalf("Expected %v to not match %v", glob.Pattern, "server/app-c1.log")
+	}
+}
+
+func TestGlobCanMatchInsideADirectoryOfTheStaticPrefix(t *testing.T) {
+	glob, _ := NewGlob("*/app-*.log")
+	if !glob.CanMatchInside("server") {
+		t.Fatalf("Expected %v to match inside %v", glob.Pattern, "server")
+	}
+	if glob.CanMatchInside("server/archive") {
+		t.Fatalf("Expected %v to not match inside %v", glob.Pattern, "server/archive")
+	}
+}
+
+func TestGlobWithADoubleStarCanMatchInsideEveryDirectory(t *testing.T) {
+	glob, _ := NewGlob("src/**/*.go")
+	if !glob.CanMatchInside("src/a/b/c") {
+		t.Fatalf("Expected %v to match inside %v", glob.Pattern, "src/a/b/c")
+	}
+	if glob.CanMatchInside("node_modules") {
+		t.Fatalf("Expected %v to not match inside %v", glob.Pattern, "node_modules")
+	}
+}
+
+func TestAttemptToCreateAGlobWithAnInvalidPattern(t *testing.T) {
+	_, err := NewGlob("[a.log")
+	expected := fmt.Sprintf(messages.ErrorMessageInvalidGlobPattern, "[a.log")
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %v, received %v", expected, err)
+	}
+}
+
+func TestSplitsAGlobIntoItsStaticDirectory(t *testing.T) {
+	inputs := []struct {
+		path      string
+		directory string
+		pattern   string
+	}{
+		{path: "./src/**/*.go", directory: "./src", pattern: "**/*.go"},
+		{path: "/var/logs/*/app-*.log", directory: "/var/logs", pattern: "*/app-*.log"},
+		{path: "/*.log", directory: "/", pattern: "*.log"},
+		{path: "*.go", directory: ".", pattern: "*.go"},
+	}
+	for _, input := range inputs {
+		directory, glob, err := splitGlob(input.path)
+		if err != nil {
+			t.Fatalf("error is %v", err)
+		}
+		if directory != input.directory || glob.Pattern != input.pattern {
+			t.Fatalf("Expected %v to split into %v and %v, received %v and %v", input.path, input.directory, input.pattern, directory, glob.Pattern)
+		}
+	}
+}
+
+func TestCreatesASourceWithAGlob(t *testing.T) {
+	source, err := newSourceFrom("./**/*.go, .")
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	if source.Directories[0] != "." || source.GlobAt(0) == nil || source.GlobAt(0).Pattern != "**/*.go" {
+		t.Fatalf("Expected a glob **/*.go in the directory ., received %v", source.PathAt(0))
+	}
+	if source.GlobAt(1) != nil || source.PathAt(1) != "." {
+		t.Fatalf("Expected the directory . without a glob, received %v", source.PathAt(1))
+	}
+	if source.PathAt(0) != "./**/*.go" {
+		t.Fatalf("Expected the path to be %v, received %v", "./**/*.go", source.PathAt(0))
+	}
+}
+
+func TestAttemptToCreateASourceWithAGlobInANonExistingDirectory(t *testing.T) {
+	_, err := newSourceFrom("./non-existing/*.go")
+	if err == nil {
+		t.Fatalf("Expected an error with a glob in a non existing directory")
+	}
+}
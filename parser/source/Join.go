@@ -0,0 +1,129 @@
+package source
+
+import (
+	"errors"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/expression"
+	"goselect/parser/tokenizer"
+)
+
+type JoinType int
+
+const (
+	JoinTypeInner JoinType = iota
+	JoinTypeLeft
+)
+
+type Join struct {
+	Type      JoinType
+	Directory string
+	Alias     string
+	On        *expression.Expression
+}
+
+func (join *Join) IsLeft() bool {
+	return join.Type == JoinTypeLeft
+}
+
+func NewJoinWith(
+	joinType JoinType,
+	directory string,
+	alias string,
+	on *expression.Expression,
+	ctx *context.ParsingApplicationContext,
+) (*Join, error) {
+	expandedDirectory, err := ExpandDirectoryPath(directory)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureDirectory(expandedDirectory); err != nil {
+		return nil, err
+	}
+	if len(alias) == 0 {
+		return nil, errors.New(messages.ErrorMessageMissingAliasInJoin)
+	}
+	if on.HasAnAggregate() {
+		return nil, errors.New(messages.ErrorMessageAggregateFunctionInsideJoin)
+	}
+	if functionName := on.FunctionName(); !ctx.IsASupportedFunction(functionName) || !ctx.FunctionContainsATag(functionName, "where") {
+		return nil, errors.New(messages.ErrorMessageInvalidJoinConditionFunctionUsed)
+	}
+	return &Join{Type: joinType, Directory: expandedDirectory, Alias: alias, On: on}, nil
+}
+
+func allJoins(tokenIterator *tokenizer.TokenIterator, ctx *context.ParsingApplicationContext) ([]*Join, error) {
+	var joins []*Join
+	for isAJoinNext(tokenIterator) {
+		join, err := newJoin(tokenIterator, ctx)
+		if err != nil {
+			return nil, err
+		}
+		joins = append(joins, join)
+	}
+	return joins, nil
+}
+
+func newJoin(tokenIterator *tokenizer.TokenIterator, ctx *context.ParsingApplicationContext) (*Join, error) {
+	joinType := JoinTypeInner
+	if tokenIterator.Peek().Equals("left") {
+		joinType = JoinTypeLeft
+		tokenIterator.Next()
+	} else if tokenIterator.Peek().Equals("inner") {
+		tokenIterator.Next()
+	}
+	if !tokenIterator.HasNext() || !tokenIterator.Peek().Equals("join") {
+		return nil, tokenIterator.ErrorAtNext(errors.New(messages.ErrorMessageMissingJoinAfterJoinType))
+	}
+	tokenIterator.Next()
+	if !tokenIterator.HasNext() || tokenIterator.Peek().Equals("as") || tokenIterator.Peek().Equals("on") {
+		return nil, tokenIterator.ErrorAtNext(errors.New(messages.ErrorMessageMissingSourceInJoin))
+	}
+	directory, err := ExpandDirectoryPath(tokenIterator.Next().TokenValue)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureDirectory(directory); err != nil {
+		return nil, err
+	}
+	alias, err := aliasOf(tokenIterator)
+	if err != nil {
+		return nil, err
+	}
+	if len(alias) == 0 {
+		return nil, errors.New(messages.ErrorMessageMissingAliasInJoin)
+	}
+	on, err := joinCondition(tokenIterator, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Join{Type: joinType, Directory: directory, Alias: alias, On: on}, nil
+}
+
+func joinCondition(tokenIterator *tokenizer.TokenIterator, ctx *context.ParsingApplicationContext) (*expression.Expression, error) {
+	if !tokenIterator.HasNext() || !tokenIterator.Peek().Equals("on") {
+		return nil, tokenIterator.ErrorAtNext(errors.New(messages.ErrorMessageMissingOnInJoin))
+	}
+	tokenIterator.Next()
+	if !tokenIterator.HasNext() || isAClauseAfterSource(tokenIterator.Peek()) {
+		return nil, tokenIterator.ErrorAtNext(errors.New(messages.ErrorMessageMissingOnInJoin))
+	}
+	parser := expression.NewParser(tokenIterator, ctx, expression.ParsingRules{
+		AllowAggregates:       false,
+		AggregateErrorMessage: messages.ErrorMessageAggregateFunctionInsideJoin,
+		InvalidErrorMessage:   messages.ErrorMessageInvalidJoinCondition,
+	})
+	on, err := parser.ParseFrom(tokenIterator.Next())
+	if err != nil {
+		return nil, err
+	}
+	if functionName := on.FunctionName(); !ctx.IsASupportedFunction(functionName) || !ctx.FunctionContainsATag(functionName, "where") {
+		return nil, errors.New(messages.ErrorMessageInvalidJoinConditionFunctionUsed)
+	}
+	return on, nil
+}
+
+func isAJoinNext(tokenIterator *tokenizer.TokenIterator) bool {
+	return tokenIterator.HasNext() &&
+		(tokenIterator.Peek().Equals("join") || tokenIterator.Peek().Equals("left") || tokenIterator.Peek().Equals("inner"))
+}
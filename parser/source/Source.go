@@ -3,38 +3,229 @@ package source
 import (
 	"errors"
 	"fmt"
+	"goselect/parser/context"
 	"goselect/parser/error/messages"
 	"goselect/parser/tokenizer"
 	"os"
+	"strings"
 )
 
 type Source struct {
-	Directory string
+	Directories []string
+	Globs       []*Glob
+	Alias       string
+	Joins       []*Join
+	Depth       *Depth
 }
 
-func NewSource(tokenIterator *tokenizer.TokenIterator) (*Source, error) {
-	directory, err := getDirectory(tokenIterator)
+/*
+source:  directory [, directory]* [as alias] [join]* [depth], a directory can be a glob like ./src/**
+join:    [inner | left] join directory as alias on condition, every source needs an alias in a join
+depth:   limits the traversal of every directory in the source, including the joined ones
+example: from ~/projects/a, ~/projects/b, /var/log
+example: from ./src as a left join ./backup as b on eq(a.relpath, b.relpath)
+*/
+func NewSource(tokenIterator *tokenizer.TokenIterator, ctx *context.ParsingApplicationContext) (*Source, error) {
+	paths, err := getDirectories(tokenIterator)
+	if err != nil {
+		return nil, err
+	}
+	directories, globs, err := directoriesOf(paths)
+	if err != nil {
+		return nil, err
+	}
+	alias, err := aliasOf(tokenIterator)
+	if err != nil {
+		return nil, err
+	}
+	joins, err := allJoins(tokenIterator, ctx)
+	if err != nil {
+		return nil, err
+	}
+	depth, err := depthOf(tokenIterator)
+	if err != nil {
+		return nil, err
+	}
+	source := &Source{Directories: directories, Globs: globs, Alias: alias, Joins: joins, Depth: depth}
+	if err := source.ensureAliases(); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+func NewSourceWith(directories []string, alias string, joins []*Join) (*Source, error) {
+	if len(directories) == 0 {
+		return nil, errors.New(messages.ErrorMessageMissingSource)
+	}
+	var paths []string
+	for _, directory := range directories {
+		expandedDirectory, err := ExpandDirectoryPath(directory)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, expandedDirectory)
+	}
+	expandedDirectories, globs, err := directoriesOf(paths)
 	if err != nil {
 		return nil, err
 	}
+	source := &Source{Directories: expandedDirectories, Globs: globs, Alias: alias, Joins: joins}
+	if err := source.ensureAliases(); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+func AliasesAhead(tokenIterator *tokenizer.TokenIterator) []string {
+	iterator := tokenIterator.Clone()
+	var aliases []string
+	depth, isInSource := 0, false
+	for iterator.HasNext() {
+		token := iterator.Next()
+		switch {
+		case token.Equals("("):
+			depth = depth + 1
+		case token.Equals(")"):
+			depth = depth - 1
+		case depth > 0:
+		case token.Equals("from"):
+			isInSource = true
+		case !isInSource:
+		case isAClauseAfterSource(token):
+			return aliases
+		case token.Equals("as") && iterator.HasNext():
+			aliases = append(aliases, iterator.Next().TokenValue)
+		}
+	}
+	return aliases
+}
+
+func (source *Source) IsJoined() bool {
+	return len(source.Joins) > 0
+}
+
+func (source *Source) GlobAt(index int) *Glob {
+	if index >= len(source.Globs) {
+		return nil
+	}
+	return source.Globs[index]
+}
+
+func (source *Source) PathAt(index int) string {
+	if glob := source.GlobAt(index); glob != nil {
+		return glob.PathFrom(source.Directories[index])
+	}
+	return source.Directories[index]
+}
+
+func (source *Source) IsDepthDefined() bool {
+	return source.Depth != nil
+}
+
+func (source *Source) Aliases() []string {
+	if len(source.Alias) == 0 {
+		return nil
+	}
+	aliases := []string{source.Alias}
+	for _, join := range source.Joins {
+		aliases = append(aliases, join.Alias)
+	}
+	return aliases
+}
+
+func (source *Source) ensureAliases() error {
+	if !source.IsJoined() {
+		return nil
+	}
+	if len(source.Alias) == 0 {
+		return errors.New(messages.ErrorMessageMissingAliasInJoin)
+	}
+	aliases := make(map[string]bool)
+	for _, alias := range source.Aliases() {
+		if aliases[strings.ToLower(alias)] {
+			return fmt.Errorf(messages.ErrorMessageDuplicateAliasInJoin, alias)
+		}
+		aliases[strings.ToLower(alias)] = true
+	}
+	return nil
+}
+
+func aliasOf(tokenIterator *tokenizer.TokenIterator) (string, error) {
+	if !tokenIterator.HasNext() || !tokenIterator.Peek().Equals("as") {
+		return "", nil
+	}
+	tokenIterator.Next()
+	if !tokenIterator.HasNext() || isAClauseAfterSource(tokenIterator.Peek()) || isAJoinNext(tokenIterator) {
+		return "", tokenIterator.ErrorAtNext(errors.New(messages.ErrorMessageExpectedAliasAfterAsInSource))
+	}
+	return tokenIterator.Next().TokenValue, nil
+}
+
+func isAClauseAfterSource(token tokenizer.Token) bool {
+	return token.Equals("depth") ||
+		token.Equals("where") ||
+		token.Equals("group") ||
+		token.Equals("having") ||
+		token.Equals("order") ||
+		token.Equals("limit")
+}
+
+func ensureDirectory(directory string) error {
 	file, err := os.Stat(directory)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf(messages.ErrorMessageInaccessibleSource, directory)
+			return fmt.Errorf(messages.ErrorMessageInaccessibleSource, directory)
 		}
-		return nil, err
+		return err
 	}
 	if !file.IsDir() {
-		return nil, errors.New(messages.ErrorMessageSourceNotADirectory)
+		return errors.New(messages.ErrorMessageSourceNotADirectory)
+	}
+	return nil
+}
+
+func directoriesOf(paths []string) ([]string, []*Glob, error) {
+	var directories []string
+	var globs []*Glob
+	for _, aPath := range paths {
+		directory, glob := aPath, (*Glob)(nil)
+		if IsAGlob(aPath) {
+			var err error
+			if directory, glob, err = splitGlob(aPath); err != nil {
+				return nil, nil, err
+			}
+		}
+		if err := ensureDirectory(directory); err != nil {
+			return nil, nil, err
+		}
+		directories = append(directories, directory)
+		globs = append(globs, glob)
+	}
+	return directories, globs, nil
+}
+
+func getDirectories(tokenIterator *tokenizer.TokenIterator) ([]string, error) {
+	directory, err := getDirectory(tokenIterator)
+	if err != nil {
+		return nil, err
+	}
+	directories := []string{directory}
+	for tokenIterator.HasNext() && tokenIterator.Peek().Equals(",") {
+		tokenIterator.Next()
+		directory, err := getDirectory(tokenIterator)
+		if err != nil {
+			return nil, err
+		}
+		directories = append(directories, directory)
 	}
-	return &Source{Directory: directory}, nil
+	return directories, nil
 }
 
 func getDirectory(tokenIterator *tokenizer.TokenIterator) (string, error) {
 	if tokenIterator.HasNext() && tokenIterator.Peek().Equals("from") {
 		tokenIterator.Next()
 	}
-	if tokenIterator.HasNext() && !tokenIterator.Peek().Equals("where") {
+	if tokenIterator.HasNext() && !tokenIterator.Peek().Equals("where") && !tokenIterator.Peek().Equals(",") {
 		token := tokenIterator.Next()
 		path := token.TokenValue
 		return ExpandDirectoryPath(path)
@@ -4,8 +4,10 @@
 package source
 
 import (
+	"goselect/parser/context"
 	"goselect/parser/tokenizer"
 	"os/user"
+	"reflect"
 	"testing"
 )
 
@@ -13,9 +15,9 @@ func TestCreatesANewSourceFromCurrentDirectory1(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "."))
 
-	source, _ := NewSource(tokens.Iterator())
-	if source.Directory != "." {
-		t.Fatalf("Expected Directory path to be %v, received %v", ".", source.Directory)
+	source, _ := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if len(source.Directories) != 1 || source.Directories[0] != "." {
+		t.Fatalf("Expected Directory path to be %v, received %v", ".", source.Directories)
 	}
 }
 
@@ -24,9 +26,9 @@ func TestCreatesANewSourceFromCurrentDirectory2(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "."))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "where"))
 
-	source, _ := NewSource(tokens.Iterator())
-	if source.Directory != "." {
-		t.Fatalf("Expected Directory path to be %v, received %v", ".", source.Directory)
+	source, _ := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if len(source.Directories) != 1 || source.Directories[0] != "." {
+		t.Fatalf("Expected Directory path to be %v, received %v", ".", source.Directories)
 	}
 }
 
@@ -35,9 +37,9 @@ func TestCreatesANewSourceFromCurrentDirectory3(t *testing.T) {
 	tokens.Add(tokenizer.NewToken(tokenizer.From, "from"))
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "."))
 
-	source, _ := NewSource(tokens.Iterator())
-	if source.Directory != "." {
-		t.Fatalf("Expected Directory path to be %v, received %v", ".", source.Directory)
+	source, _ := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if len(source.Directories) != 1 || source.Directories[0] != "." {
+		t.Fatalf("Expected Directory path to be %v, received %v", ".", source.Directories)
 	}
 }
 
@@ -45,11 +47,11 @@ func TestCreatesANewSourceWithHomeDirectorySymbol1(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "~"))
 
-	source, _ := NewSource(tokens.Iterator())
+	source, _ := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 	expectedPath := homeDirectory()
 
-	if source.Directory != expectedPath {
-		t.Fatalf("Expected Directory path to be %v, received %v", expectedPath, source.Directory)
+	if len(source.Directories) != 1 || source.Directories[0] != expectedPath {
+		t.Fatalf("Expected Directory path to be %v, received %v", expectedPath, source.Directories)
 	}
 }
 
@@ -57,11 +59,11 @@ func TestCreatesANewSourceWithHomeDirectorySymbol2(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "~"))
 
-	source, _ := NewSource(tokens.Iterator())
+	source, _ := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 	expectedPath := homeDirectory()
 
-	if source.Directory != expectedPath {
-		t.Fatalf("Expected Directory path to be %v, received %v", expectedPath, source.Directory)
+	if len(source.Directories) != 1 || source.Directories[0] != expectedPath {
+		t.Fatalf("Expected Directory path to be %v, received %v", expectedPath, source.Directories)
 	}
 }
 
@@ -69,7 +71,7 @@ func TestThrowsAnErrorForInaccessiblePath(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "~/apps"))
 
-	_, err := NewSource(tokens.Iterator())
+	_, err := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 	if err == nil {
 		t.Fatalf("Expected an error given an invalid path, received no error")
 	}
@@ -79,7 +81,7 @@ func TestThrowsAnErrorForSourcePathIfItIsNotADirectory(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "./Source.go"))
 
-	_, err := NewSource(tokens.Iterator())
+	_, err := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 	if err == nil {
 		t.Fatalf("Expected an error given source path as a file, not a directory, received no error")
 	}
@@ -87,7 +89,7 @@ func TestThrowsAnErrorForSourcePathIfItIsNotADirectory(t *testing.T) {
 
 func TestThrowsAnErrorWithoutAnyTokens(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
-	_, err := NewSource(tokens.Iterator())
+	_, err := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
 
 	if err == nil {
 		t.Fatalf("Expected error to be non-nil when creating a source without any tokens")
@@ -101,3 +103,142 @@ func homeDirectory() string {
 	}
 	return ""
 }
+
+func TestCreatesANewSourceWithMultipleDirectories(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.From, "from"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "."))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "~"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Where, "where"))
+
+	source, _ := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := []string{".", homeDirectory()}
+
+	if !reflect.DeepEqual(expected, source.Directories) {
+		t.Fatalf("Expected Directory paths to be %v, received %v", expected, source.Directories)
+	}
+}
+
+func TestThrowsAnErrorGivenMissingDirectoryAfterComma(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "."))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+
+	_, err := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given no directory after comma, received no error")
+	}
+}
+
+func TestThrowsAnErrorGivenOneOfTheDirectoriesIsInaccessible(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "."))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "~/apps"))
+
+	_, err := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given an invalid path, received no error")
+	}
+}
+
+func TestCreatesANewSourceWithAnAlias(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("from . as a where").Tokenize()
+
+	source, _ := NewSource(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if source.Alias != "a" {
+		t.Fatalf("Expected source alias to be %v, received %v", "a", source.Alias)
+	}
+}
+
+func TestCreatesANewSourceWithAJoin(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("from . as a join ~ as b on eq(a.name, b.name) where").Tokenize()
+	ctx := context.NewContext(context.NewFunctions(), context.NewAttributes()).WithSourceAliases([]string{"a", "b"})
+
+	source, err := NewSource(tokens.Iterator(), ctx)
+	if err != nil {
+		t.Fatalf("Expected no error while creating a source with a join, received %v", err)
+	}
+	if len(source.Joins) != 1 || source.Joins[0].Directory != homeDirectory() || source.Joins[0].IsLeft() {
+		t.Fatalf("Expected one inner join with the directory %v, received %v", homeDirectory(), source.Joins)
+	}
+	expected := []string{"a", "b"}
+	if !reflect.DeepEqual(expected, source.Aliases()) {
+		t.Fatalf("Expected source aliases to be %v, received %v", expected, source.Aliases())
+	}
+}
+
+func TestCreatesANewSourceWithALeftJoin(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("from . as a left join . as b on a.name = b.name").Tokenize()
+	ctx := context.NewContext(context.NewFunctions(), context.NewAttributes()).WithSourceAliases([]string{"a", "b"})
+
+	source, _ := NewSource(tokens.Iterator(), ctx)
+	if len(source.Joins) != 1 || !source.Joins[0].IsLeft() {
+		t.Fatalf("Expected one left join, received %v", source.Joins)
+	}
+}
+
+func TestThrowsAnErrorGivenAJoinWithoutAnAliasForTheSource(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("from . join . as b on eq(name, b.name)").Tokenize()
+	ctx := context.NewContext(context.NewFunctions(), context.NewAttributes()).WithSourceAliases([]string{"b"})
+
+	_, err := NewSource(tokens.Iterator(), ctx)
+	if err == nil {
+		t.Fatalf("Expected an error given a join without an alias for the source, received no error")
+	}
+}
+
+func TestThrowsAnErrorGivenAJoinWithoutAnAlias(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("from . as a join . on eq(a.name, a.name)").Tokenize()
+	ctx := context.NewContext(context.NewFunctions(), context.NewAttributes()).WithSourceAliases([]string{"a"})
+
+	_, err := NewSource(tokens.Iterator(), ctx)
+	if err == nil {
+		t.Fatalf("Expected an error given a join without an alias, received no error")
+	}
+}
+
+func TestThrowsAnErrorGivenAJoinWithADuplicateAlias(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("from . as a join . as A on eq(a.name, a.name)").Tokenize()
+	ctx := context.NewContext(context.NewFunctions(), context.NewAttributes()).WithSourceAliases([]string{"a"})
+
+	_, err := NewSource(tokens.Iterator(), ctx)
+	if err == nil {
+		t.Fatalf("Expected an error given a join with a duplicate alias, received no error")
+	}
+}
+
+func TestThrowsAnErrorGivenAJoinWithoutOn(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("from . as a join . as b where eq(a.name, b.name)").Tokenize()
+	ctx := context.NewContext(context.NewFunctions(), context.NewAttributes()).WithSourceAliases([]string{"a", "b"})
+
+	_, err := NewSource(tokens.Iterator(), ctx)
+	if err == nil {
+		t.Fatalf("Expected an error given a join without on, received no error")
+	}
+}
+
+func TestThrowsAnErrorGivenLeftWithoutJoin(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("from . as a left . as b on eq(a.name, b.name)").Tokenize()
+	ctx := context.NewContext(context.NewFunctions(), context.NewAttributes()).WithSourceAliases([]string{"a", "b"})
+
+	_, err := NewSource(tokens.Iterator(), ctx)
+	if err == nil {
+		t.Fatalf("Expected an error given left without join, received no error")
+	}
+}
+
+func TestAliasesAheadOfTheSource(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("select a.name, lower(b.name) as lowered from . as a join . as b on eq(a.name, b.name) where eq(a.size, 10)").Tokenize()
+	iterator := tokens.Iterator()
+
+	aliases := AliasesAhead(iterator)
+	expected := []string{"a", "b"}
+	if !reflect.DeepEqual(expected, aliases) {
+		t.Fatalf("Expected aliases to be %v, received %v", expected, aliases)
+	}
+	if !iterator.Peek().Equals("select") {
+		t.Fatalf("Expected aliases ahead to not consume the tokens, received %v", iterator.Peek())
+	}
+}
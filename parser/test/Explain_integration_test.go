@@ -0,0 +1,123 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"strings"
+	"testing"
+)
+
+func explain(t *testing.T, query string) *executor.Plan {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser(query, newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	return executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Explain()
+}
+
+func labelsOf(plan *executor.Plan) []string {
+	labels := []string{plan.Label}
+	for _, child := range plan.Children {
+		labels = append(labels, labelsOf(child)...)
+	}
+	return labels
+}
+
+func assertContainsLabel(t *testing.T, plan *executor.Plan, expected string) {
+	labels := labelsOf(plan)
+	for _, label := range labels {
+		if label == expected {
+			return
+		}
+	}
+	t.Fatalf("Expected the plan to contain %v, received %v", expected, strings.Join(labels, " | "))
+}
+
+func TestExplainResolvesTheCanonicalNames(t *testing.T) {
+	plan := explain(t, "select low(fname), count() from ./resources/TestResultsWithProjections/single group by low(fname)")
+
+	assertContainsLabel(t, plan, "scalar function lower (written as low)")
+	assertContainsLabel(t, plan, "attribute name (written as fname)")
+	assertContainsLabel(t, plan, "aggregate function count")
+	assertContainsLabel(t, plan, "group by")
+}
+
+func TestExplainShowsTheWhereClause(t *testing.T) {
+	plan := explain(t, "select name from ./resources/TestResultsWithProjections/single where size > 1 and ext = .txt")
+
+	assertContainsLabel(t, plan, "where")
+	assertContainsLabel(t, plan, "scalar function and")
+	assertContainsLabel(t, plan, "scalar function greaterthan (written as gt)")
+	assertContainsLabel(t, plan, "value .txt")
+}
+
+func TestExplainShowsTheOrderAndTheLimit(t *testing.T) {
+	plan := explain(t, "select name from ./resources/TestResultsWithProjections/single order by size desc limit 5 offset 2")
+
+	assertContainsLabel(t, plan, "hidden column 2, used only by order by")
+	assertContainsLabel(t, plan, "column 2 (size) descending")
+	assertContainsLabel(t, plan, "limit 5 offset 2")
+	assertContainsLabel(t, plan, "limit applies after the traversal, order by, group by or aggregate functions need every file")
+}
+
+func TestExplainShowsTheLimitShortCircuit(t *testing.T) {
+	plan := explain(t, "select name from ./resources/TestResultsWithProjections/single limit 5")
+
+	assertContainsLabel(t, plan, "limit short-circuits the traversal once 5 rows after an offset of 0 are collected")
+}
+
+func TestExplainShowsTheAggregateOnlyLimit(t *testing.T) {
+	plan := explain(t, "select count(), sum(size) from ./resources/TestResultsWithProjections/single")
+
+	assertContainsLabel(t, plan, "only aggregate functions without group by, the traversal produces a single row (limit 1)")
+}
+
+func TestExplainShowsAHashJoin(t *testing.T) {
+	plan := explain(t, "select a.name from ./resources/TestResultsWithProjections/single as a join ./resources/TestResultsWithProjections/multi as b on a.fsize = b.size")
+
+	assertContainsLabel(t, plan, "join ./resources/TestResultsWithProjections/multi as b")
+	assertContainsLabel(t, plan, "hash join on 1 equality key(s), the condition is evaluated for the matching files")
+	assertContainsLabel(t, plan, "attribute a.size (written as a.fsize)")
+}
+
+func TestExplainShowsASetOperationAndASubquery(t *testing.T) {
+	plan := explain(t, "select name from ./resources/TestResultsWithProjections/single where name in (select name from ./resources/TestResultsWithProjections/multi) union select name from ./resources/TestResultsWithProjections/multi")
+
+	assertContainsLabel(t, plan, "subquery")
+	assertContainsLabel(t, plan, "union")
+	assertContainsLabel(t, plan, "directory ./resources/TestResultsWithProjections/multi")
+	assertContainsLabel(t, plan, "every query of the set operations is executed in full, order by and limit apply to the combined rows")
+}
+
+func TestExplainShowsAWindowFunction(t *testing.T) {
+	plan := explain(t, "select name, rank() over (partition by ext order by size desc) from ./resources/TestResultsWithProjections/multi")
+
+	assertContainsLabel(t, plan, "window function rank")
+	assertContainsLabel(t, plan, "partition by")
+	assertContainsLabel(t, plan, "hidden column 3, used only by the window function in column 2")
+	assertContainsLabel(t, plan, "window functions are evaluated over their ordered partitions after the traversal")
+}
+
+func TestExplainShowsTheDepth(t *testing.T) {
+	plan := explain(t, "select name from ./resources depth 2 to 3")
+
+	assertContainsLabel(t, plan, "depth from 2 to 3")
+	assertContainsLabel(t, plan, "directories past depth 3 are not read")
+	assertContainsLabel(t, plan, "files at a depth less than 2 are traversed but not chosen")
+}
+
+func TestExplainShowsAGlob(t *testing.T) {
+	plan := explain(t, "select name from ./resources/**/*.log")
+
+	assertContainsLabel(t, plan, "directory ./resources")
+	assertContainsLabel(t, plan, "glob **/*.log, only the directories that can match the glob are read")
+}
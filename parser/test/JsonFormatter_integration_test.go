@@ -30,3 +30,23 @@ func TestJsonFormatter(t *testing.T) {
 		t.Fatalf("Expected json formatter to format %v, received %v", expected, json)
 	}
 }
+
+func TestJsonFormatterWithNull(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select a.name, b.name from ./resources/TestResultsWithProjections/multi as a left join ./resources/TestResultsWithProjections/single as b on eq(a.basename, b.basename) where isnull(b.name) order by 1 limit 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+
+	json := writer.NewJsonFormatter().Format(selectQuery.Projections, queryResults)
+	expected := "[{\"a.name\" : \"TestResultsWithProjections_B.log\", \"b.name\" : null}]"
+
+	if expected != json {
+		t.Fatalf("Expected json formatter to format %v, received %v", expected, json)
+	}
+}
@@ -0,0 +1,120 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithPositionalParameters(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	parameters := parser.NewParameters().AddPositional(".log").AddPositional("60")
+	aParser, err := parser.NewParserWithParameters("select name from ./resources/TestResultsWithProjections/multi where eq(ext, ?) and gt(size, ?)", newContext, parameters)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithNamedParameters(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	parameters := parser.NewParameters().AddNamed("source", "./resources/TestResultsWithProjections/multi").AddNamed(":ext", ".txt")
+	aParser, err := parser.NewParserWithParameters("select name from :source where ext = :ext order by 1 limit :limit", newContext, parameters.AddNamed("limit", "1"))
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_C.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithParametersThatAreNotTokenized(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	parameters := parser.NewParameters().AddPositional("a,b(1) from").AddPositional("order")
+	aParser, err := parser.NewParserWithParameters("select ?, ? from ./resources/TestResultsWithProjections/single", newContext, parameters)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("a,b(1) from"), context.StringValue("order")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAParameterInASubquery(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	parameters := parser.NewParameters().AddNamed("size", "58")
+	aParser, err := parser.NewParserWithParameters("select name from ./resources/TestResultsWithProjections/multi where basename in (select basename from ./resources/TestResultsWithProjections/single where eq(size, :size))", newContext, parameters)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestParsesAQueryWithAMissingPositionalParameter(t *testing.T) {
+	parameters := parser.NewParameters().AddPositional(".log")
+	aParser, _ := parser.NewParserWithParameters("select name from . where eq(ext, ?) and gt(size, ?)", context.NewContext(context.NewFunctions(), context.NewAttributes()), parameters)
+	_, err := aParser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given a missing positional parameter")
+	}
+}
+
+func TestParsesAQueryWithAMissingNamedParameter(t *testing.T) {
+	aParser, _ := parser.NewParser("select name from . where eq(ext, :ext)", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	_, err := aParser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given a missing named parameter")
+	}
+}
+
+func TestResultsWithRelativeTimeParameters(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	parameters := parser.NewParameters().AddNamed("before", "0 days ago").AddNamed("age", "0s")
+	aParser, err := parser.NewParserWithParameters("select count() from ./resources/TestResultsWithProjections/multi where mtime < :before and age > :age", newContext, parameters)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Uint32Value(4)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
@@ -0,0 +1,152 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"errors"
+	"goselect/parser"
+	"goselect/parser/context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorPointsAtTheOffendingToken(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/single order by nmae", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	var parseError *parser.ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf("Expected a parse error, received %v", err)
+	}
+	if parseError.Offset != 72 || parseError.Column != 73 {
+		t.Fatalf("Expected the parse error at offset 72 and column 73, received %v and %v", parseError.Offset, parseError.Column)
+	}
+	expectedCaret := "\n" + strings.Repeat(" ", 72) + "^\n"
+	if !strings.Contains(err.Error(), expectedCaret) {
+		t.Fatalf("Expected the parse error to render a caret under nmae, received %v", err)
+	}
+}
+
+func TestParseErrorSuggestsAnAttribute(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/single order by nmae", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	var parseError *parser.ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf("Expected a parse error, received %v", err)
+	}
+	if !reflect.DeepEqual([]string{"name"}, parseError.Suggestions) {
+		t.Fatalf("Expected the suggestions to be %v, received %v", []string{"name"}, parseError.Suggestions)
+	}
+	if !strings.HasSuffix(err.Error(), "did you mean name?") {
+		t.Fatalf("Expected the parse error to suggest name, received %v", err)
+	}
+}
+
+func TestParseErrorSuggestsAFunction(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/single group by lowre(name)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	var parseError *parser.ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf("Expected a parse error, received %v", err)
+	}
+	if !reflect.DeepEqual([]string{"lower"}, parseError.Suggestions) {
+		t.Fatalf("Expected the suggestions to be %v, received %v", []string{"lower"}, parseError.Suggestions)
+	}
+}
+
+func TestParseErrorPointsAtAMissingPlaceholder(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from . where eq(name, :name)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	var parseError *parser.ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf("Expected a parse error, received %v", err)
+	}
+	if parseError.Column != 35 {
+		t.Fatalf("Expected the parse error at column 35, received %v", parseError.Column)
+	}
+	if len(parseError.Suggestions) != 0 {
+		t.Fatalf("Expected no suggestions for a missing placeholder, received %v", parseError.Suggestions)
+	}
+}
+
+func TestParseErrorPointsAtTheTokenAfterTheSource(t *testing.T) {
+	queries := []string{
+		"select name from ./resources/TestResultsWithProjections/single, ./resources/TestResultsWithProjections/multi junk",
+		"select name from ./resources/TestResultsWithProjections/single depth 2 junk",
+		"select a.name from ./resources/TestResultsWithProjections/single as a join ./resources/TestResultsWithProjections/multi as b on eq(a.name, b.name) junk",
+	}
+	for _, query := range queries {
+		newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+		aParser, err := parser.NewParser(query, newContext)
+		if err != nil {
+			t.Fatalf("error is %v", err)
+		}
+		_, err = aParser.Parse()
+
+		var parseError *parser.ParseError
+		if !errors.As(err, &parseError) {
+			t.Fatalf("Expected a parse error, received %v", err)
+		}
+		expectedOffset := strings.Index(query, "junk")
+		if parseError.Offset != expectedOffset {
+			t.Fatalf("Expected the parse error at offset %v for %v, received %v", expectedOffset, query, parseError.Offset)
+		}
+	}
+}
+
+func TestParseErrorPointsAtTheTokenAfterAJoinType(t *testing.T) {
+	query := "select a.name from ./resources/TestResultsWithProjections/single as a left junk"
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser(query, newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	var parseError *parser.ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf("Expected a parse error, received %v", err)
+	}
+	if parseError.Offset != strings.Index(query, "junk") {
+		t.Fatalf("Expected the parse error at offset %v, received %v", strings.Index(query, "junk"), parseError.Offset)
+	}
+}
+
+func TestParseErrorPointsAtTheLastTokenGivenNoTokenFollows(t *testing.T) {
+	query := "select name from ./resources/TestResultsWithProjections/single depth"
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser(query, newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	var parseError *parser.ParseError
+	if !errors.As(err, &parseError) {
+		t.Fatalf("Expected a parse error, received %v", err)
+	}
+	if parseError.Offset != strings.Index(query, "depth") {
+		t.Fatalf("Expected the parse error at offset %v, received %v", strings.Index(query, "depth"), parseError.Offset)
+	}
+}
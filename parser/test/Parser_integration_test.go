@@ -253,3 +253,122 @@ func TestParsesAQueryIntoAnASTWithMultipleProjectionsInCaseInsensitiveManner(t *
 		t.Fatalf("Expected attributes to be %v, received %v", attributes, expectedAttributes)
 	}
 }
+
+func TestParsesAQueryIntoAnASTWithGroupBy(t *testing.T) {
+	parser, _ := parser.NewParser("select ext, count() from ./resources group by ext order by 1", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	selectStatement, _ := parser.Parse()
+
+	if !selectStatement.IsGroupByDefined() {
+		t.Fatalf("Expected group by to be defined but was not")
+	}
+	groupByAttributes := selectStatement.GroupBy.DisplayableAttributes()
+	expectedAttributes := []string{"ext"}
+
+	if !reflect.DeepEqual(groupByAttributes, expectedAttributes) {
+		t.Fatalf("Expected group by attributes to be %v, received %v", expectedAttributes, groupByAttributes)
+	}
+}
+
+func TestParsesAQueryWithGroupByWithoutAnyExpression(t *testing.T) {
+	parser, _ := parser.NewParser("select ext, count() from ./resources group by order by 1", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	_, err := parser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given group by without any expression")
+	}
+}
+
+func TestParsesAQueryIntoAnASTWithHaving(t *testing.T) {
+	parser, _ := parser.NewParser("select ext, count() from ./resources group by ext having gt(count(), 1) order by 1", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	selectStatement, _ := parser.Parse()
+
+	if !selectStatement.IsHavingDefined() {
+		t.Fatalf("Expected having to be defined but was not")
+	}
+	expected := "gt(count(),1)"
+	if selectStatement.Having.Display() != expected {
+		t.Fatalf("Expected having to be %v, received %v", expected, selectStatement.Having.Display())
+	}
+}
+
+func TestParsesAQueryWithHavingWithoutAnyExpression(t *testing.T) {
+	parser, _ := parser.NewParser("select ext, count() from ./resources group by ext having order by 1", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	_, err := parser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given having without any expression")
+	}
+}
+
+func TestParsesAQueryIntoAnASTWithSetOperations(t *testing.T) {
+	aParser, _ := parser.NewParser("select name from ./resources union all select name from ./resources except select name from ./resources order by 1 limit 2", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	selectStatement, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	if !selectStatement.IsCombined() {
+		t.Fatalf("Expected the query to be combined but was not")
+	}
+	operators := []int{int(selectStatement.SetOperations[0].Operator), int(selectStatement.SetOperations[1].Operator)}
+	expected := []int{int(parser.SetOperatorUnionAll), int(parser.SetOperatorExcept)}
+	if !reflect.DeepEqual(expected, operators) {
+		t.Fatalf("Expected set operators to be %v, received %v", expected, operators)
+	}
+	if !selectStatement.IsOrderDefined() || !selectStatement.IsLimitDefined() {
+		t.Fatalf("Expected order by and limit to be defined on the combined query")
+	}
+}
+
+func TestParsesAQueryWithSetOperationWithIncompatibleProjections(t *testing.T) {
+	parser, _ := parser.NewParser("select name, size from ./resources union select name from ./resources", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	_, err := parser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given set operation with different number of projections")
+	}
+}
+
+func TestParsesAQueryWithOrderByBeforeSetOperation(t *testing.T) {
+	parser, _ := parser.NewParser("select name from ./resources order by 1 union select name from ./resources", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	_, err := parser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given order by before a set operation")
+	}
+}
+
+func TestParsesAQueryWithSetOperationWithoutSelect(t *testing.T) {
+	parser, _ := parser.NewParser("select name from ./resources intersect", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	_, err := parser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given no select after a set operation")
+	}
+}
+
+func TestParsesAQueryWithSetOperationOrderedByANonProjectedAttribute(t *testing.T) {
+	parser, _ := parser.NewParser("select name from ./resources union select name from ./resources order by size", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	_, err := parser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given set operation ordered by a non projected attribute")
+	}
+}
+
+func TestParsesAQueryWithDistinctOrderedByANonProjectedAttribute(t *testing.T) {
+	parser, _ := parser.NewParser("select distinct ext from ./resources order by name", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	_, err := parser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given distinct ordered by a non projected attribute")
+	}
+}
+
+func TestParsesAQueryWithDistinctOrderedByAProjectedAttribute(t *testing.T) {
+	parser, _ := parser.NewParser("select distinct ext from ./resources order by ext desc", context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	_, err := parser.Parse()
+
+	if err != nil {
+		t.Fatalf("Expected no error given distinct ordered by a projected attribute, received %v", err)
+	}
+}
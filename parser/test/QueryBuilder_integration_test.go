@@ -0,0 +1,234 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestBuildsTheSameQueryAsTheParser(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	built, err := parser.
+		Select("name", parser.Fn("lower", parser.Attr("ext")).As("e")).
+		From("./resources/TestResultsWithProjections/multi").
+		Where(parser.Fn("gt", parser.Attr("size"), parser.Val(10))).
+		OrderBy(parser.Desc("e"), "name").
+		Limit(3).
+		Build(newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	expected := format(t, "select name, lower(ext) as e from ./resources/TestResultsWithProjections/multi where size > 10 order by e desc, name limit 3")
+
+	if built.String() != expected {
+		t.Fatalf("Expected built query to be %v, received %v", expected, built.String())
+	}
+}
+
+func TestExecutesABuiltQuery(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	built, err := parser.
+		Select("name").
+		From("./resources/TestResultsWithProjections/multi").
+		Where(parser.Fn("eq", parser.Attr("ext"), parser.Val(".log"))).
+		OrderBy(parser.Desc(1)).
+		Build(newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(built, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_B.log")},
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestExecutesABuiltQueryWithGroupByAndHaving(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	built, err := parser.
+		Select("ext", parser.Fn("count")).
+		From("./resources/TestResultsWithProjections/multi").
+		GroupBy("ext").
+		Having(parser.Fn("gt", parser.Fn("count"), parser.Val(1))).
+		OrderBy(1).
+		Build(newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(built, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Uint32Value(2)},
+		{context.StringValue(".txt"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestBuildsAJoinedQuery(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	built, err := parser.
+		Select(parser.Attr("a.name"), parser.Attr("b.name")).
+		From("./resources/TestResultsWithProjections/single").
+		As("a").
+		Join("./resources/TestResultsWithProjections/multi", "b", parser.Fn("eq", parser.Attr("a.size"), parser.Attr("b.size"))).
+		Build(newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	expected := format(t, "select a.name, b.name from ./resources/TestResultsWithProjections/single as a join ./resources/TestResultsWithProjections/multi as b on a.size = b.size")
+
+	if built.String() != expected {
+		t.Fatalf("Expected built query to be %v, received %v", expected, built.String())
+	}
+}
+
+func TestBuildsAQueryWithAWindowFunction(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	built, err := parser.
+		Select("name", parser.Fn("rownumber").Over(parser.NewWindow().PartitionBy("ext").OrderBy(parser.Desc("size"), "name")).As("rn")).
+		From("./resources/TestResultsWithProjections/multi").
+		OrderBy("rn", "name").
+		Build(newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	expected := format(t, "select name, rownumber() over (partition by ext order by size desc, name) as rn from ./resources/TestResultsWithProjections/multi order by rn, name")
+
+	if built.String() != expected {
+		t.Fatalf("Expected built query to be %v, received %v", expected, built.String())
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(built, newContext, executor.NewDefaultOptions()).Execute()
+	expectedResults := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Uint32Value(1)},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.Uint32Value(1)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Uint32Value(2)},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expectedResults, queryResults)
+}
+
+func TestAttemptToBuildAQueryWithAWindowFunctionWithoutOver(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	_, err := parser.Select("name", parser.Fn("rank")).From("./resources/TestResultsWithProjections/single").Build(newContext)
+
+	if err == nil {
+		t.Fatalf("Expected an error while building a query with a window function without over")
+	}
+}
+
+func TestAttemptToBuildAQueryWithAWindowFunctionInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	_, err := parser.
+		Select("name").
+		From("./resources/TestResultsWithProjections/single").
+		Where(parser.Fn("eq", parser.Fn("rank").Over(nil), parser.Val(1))).
+		Build(newContext)
+
+	if err == nil || err.Error() != messages.ErrorMessageWindowFunctionNotSupported {
+		t.Fatalf("Expected error %v, received %v", messages.ErrorMessageWindowFunctionNotSupported, err)
+	}
+}
+
+func TestBuildsAQueryWithADepth(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	built, err := parser.Select("name").From("./resources").DepthBetween(2, 3).Build(newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	expected := format(t, "select name from ./resources depth 2 to 3")
+
+	if built.String() != expected {
+		t.Fatalf("Expected built query to be %v, received %v", expected, built.String())
+	}
+}
+
+func TestExecutesABuiltQueryWithAGlob(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	built, err := parser.Select("name").From("./resources/TestResultsWithProjections/**/*.log").OrderBy(1).Build(newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(built, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("Empty.log")},
+		{context.StringValue("TestResultsWithProjections_A.log")},
+		{context.StringValue("TestResultsWithProjections_B.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestAttemptToBuildAQueryWithAZeroDepth(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	_, err := parser.Select("name").From("./resources").Depth(0).Build(newContext)
+
+	if err == nil {
+		t.Fatalf("Expected an error while building a query with a zero depth")
+	}
+}
+
+func TestAttemptToBuildAQueryWithAnUnsupportedAttribute(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	_, err := parser.Select("nmae").From("./resources/TestResultsWithProjections/single").Build(newContext)
+
+	if err == nil {
+		t.Fatalf("Expected an error while building a query with an unsupported attribute")
+	}
+}
+
+func TestAttemptToBuildAQueryWithAnUnsupportedValue(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	_, err := parser.Select(parser.Val([]int{1})).From("./resources/TestResultsWithProjections/single").Build(newContext)
+
+	if err == nil {
+		t.Fatalf("Expected an error while building a query with an unsupported value")
+	}
+}
+
+func TestAttemptToBuildAQueryWithoutASource(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	_, err := parser.Select("name").Build(newContext)
+
+	if err == nil || err.Error() != messages.ErrorMessageMissingSource {
+		t.Fatalf("Expected error %v, received %v", messages.ErrorMessageMissingSource, err)
+	}
+}
+
+func TestAttemptToBuildAQueryWithANonWhereFunctionInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	_, err := parser.
+		Select("name").
+		From("./resources/TestResultsWithProjections/single").
+		Where(parser.Fn("lower", parser.Attr("name"))).
+		Build(newContext)
+
+	if err == nil || err.Error() != messages.ErrorMessageInvalidWhereFunctionUsed {
+		t.Fatalf("Expected error %v, received %v", messages.ErrorMessageInvalidWhereFunctionUsed, err)
+	}
+}
+
+func TestAttemptToBuildAQueryWithAnAggregateInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	_, err := parser.
+		Select("name").
+		From("./resources/TestResultsWithProjections/single").
+		Where(parser.Fn("gt", parser.Fn("count"), parser.Val(1))).
+		Build(newContext)
+
+	if err == nil || err.Error() != messages.ErrorMessageAggregateFunctionInsideWhere {
+		t.Fatalf("Expected error %v, received %v", messages.ErrorMessageAggregateFunctionInsideWhere, err)
+	}
+}
+
+func TestAttemptToBuildAQueryWithAnOrderByPositionOutOfRange(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	_, err := parser.Select("name").From("./resources/TestResultsWithProjections/single").OrderBy(2).Build(newContext)
+
+	if err == nil {
+		t.Fatalf("Expected an error while building a query with an order by position out of range")
+	}
+}
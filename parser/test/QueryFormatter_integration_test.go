@@ -0,0 +1,253 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func format(t *testing.T, query string) string {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser(query, newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	return selectQuery.String()
+}
+
+func TestFormatsAQueryWithCanonicalNames(t *testing.T) {
+	formatted := format(t, "select fname, fsize / 1024 from ./resources/TestResultsWithProjections/single where ext = .txt order by 2 desc limit 5")
+	expected := "select name, divide(size, 1024) from ./resources/TestResultsWithProjections/single where equal(extension, .txt) order by 2 desc limit 5"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatsAQueryWithQuotedValues(t *testing.T) {
+	formatted := format(t, "select concat(name, 'a b', \"it's\") from ./resources/TestResultsWithProjections/single")
+	expected := "select concat(name, 'a b', \"it's\") from ./resources/TestResultsWithProjections/single"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatsAQueryWithAHiddenOrderByColumn(t *testing.T) {
+	formatted := format(t, "select name from ./resources/TestResultsWithProjections/multi order by lower(ext) desc, 1")
+	expected := "select name from ./resources/TestResultsWithProjections/multi order by lower(extension) desc, 1"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatsAQueryOnePerLine(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, _ := parser.NewParser("select ext, count() from ./resources/TestResultsWithProjections/multi group by ext having count() > 1", newContext)
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	expected := "select extension, count()\nfrom ./resources/TestResultsWithProjections/multi\ngroup by extension\nhaving greaterthan(count(), 1)"
+
+	if selectQuery.Pretty() != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, selectQuery.Pretty())
+	}
+}
+
+func TestFormattedQueriesRoundTrip(t *testing.T) {
+	queries := []string{
+		"select distinct lower(ext) as e, len(name) from ./resources/TestResultsWithProjections/multi where size > 10 and not (name like 'A') order by e limit 2 offset 1",
+		"select name, case when size > 60 then 'large' else 'small' end from ./resources/TestResultsWithProjections/multi",
+		"select name from ./resources/TestResultsWithProjections/single where name in (select name from ./resources/TestResultsWithProjections/multi) union all select name from ./resources/TestResultsWithProjections/multi order by 1",
+		"select a.name, b.size from ./resources/TestResultsWithProjections/single as a left join ./resources/TestResultsWithProjections/multi as b on a.size = b.size",
+		"select ext, sum(size), avg(size) from ./resources/TestResultsWithProjections/multi group by ext having count() >= 2 order by 2 desc",
+		"select name, row_number() over (partition by dirname(path) order by size desc, name) as rn, sum(size) over () from ./resources/TestResultsWithProjections/multi order by rn",
+		"select a.name, depth from ./resources as a join ./resources as b on a.name = b.name depth 2 to 3 where depth > 2",
+		"select name from ./resources/**/*.log, ./resources/images where size > 0",
+		"select cast(size as string), todatetime(name, dt) from ./resources/TestResultsWithProjections/multi where cast(size as int) > 10",
+	}
+	for _, query := range queries {
+		formatted := format(t, query)
+		if reformatted := format(t, formatted); reformatted != formatted {
+			t.Fatalf("Expected the formatted query %v to format to itself, received %v", formatted, reformatted)
+		}
+	}
+}
+
+func TestPrettyFormattedQueriesRoundTrip(t *testing.T) {
+	pretty := func(query string) string {
+		newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+		aParser, err := parser.NewParser(query, newContext)
+		if err != nil {
+			t.Fatalf("error is %v", err)
+		}
+		selectQuery, err := aParser.Parse()
+		if err != nil {
+			t.Fatalf("error is %v", err)
+		}
+		return selectQuery.Pretty()
+	}
+	formatted := pretty("select name from ./resources/TestResultsWithProjections/multi where eq(ext, .log) order by 1")
+	if reformatted := pretty(formatted); reformatted != formatted {
+		t.Fatalf("Expected the formatted query %v to format to itself, received %v", formatted, reformatted)
+	}
+}
+
+func TestFormatsAWindowFunction(t *testing.T) {
+	formatted := format(t, "select name, row_number() over (partition by dirname(path) order by fsize desc) from ./resources/TestResultsWithProjections/multi")
+	expected := "select name, rownumber() over (partition by dirname(path) order by size desc) from ./resources/TestResultsWithProjections/multi"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatsADepth(t *testing.T) {
+	formatted := format(t, "select name from ./resources depth 2 where eq(depth, 2)")
+	expected := "select name from ./resources depth 2 where equal(depth, 2)"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatsACast(t *testing.T) {
+	formatted := format(t, "select cast(fsize as size) from ./resources where cast(name as int) > 1")
+	expected := "select cast(size as size) from ./resources where greaterthan(cast(name as int), 1)"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatsRelativeTimesAndDurations(t *testing.T) {
+	formatted := format(t, "select name from ./resources where mtime < 30 days ago and mtime >= start of month or age > 3d4h or atime < yesterday")
+	expected := "select name from ./resources where or(or(and(lessthan(modifiedtime, 30 days ago), greaterthanequal(modifiedtime, start of month)), greaterthan(age, 3d4h)), lessthan(accessedtime, yesterday))"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatsQuotedValues(t *testing.T) {
+	formatted := format(t, "select name from ./resources where eq(name, 'name') or regexmatch(name, '\\d+\\.log$')")
+	expected := "select name from ./resources where or(equal(name, 'name'), regexmatch(name, \\d+\\.log$))"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+	if reformatted := format(t, formatted); reformatted != expected {
+		t.Fatalf("Expected the formatted query to format to itself, received %v", reformatted)
+	}
+}
+
+func TestFormatsPunctuationAndKeywordValuesQuoted(t *testing.T) {
+	formatted := format(t, "select name from ./resources where eq(name, ',') or in(name, '(', ')', 'from', 'where', 'select', 'as', 'and', 'end')")
+	expected := "select name from ./resources where or(equal(name, ','), in(name, '(', ')', 'from', 'where', 'select', 'as', 'and', 'end'))"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+	if reformatted := format(t, formatted); reformatted != expected {
+		t.Fatalf("Expected the formatted query to format to itself, received %v", reformatted)
+	}
+}
+
+func TestFormattedQueriesWithEscapedValuesReturnTheSameResults(t *testing.T) {
+	query := "select name, :value from ./resources/TestResultsWithProjections/single where eq(:value, :value)"
+	for _, value := range []string{"x\\ y", "a\\'b", "a\\\"b", "it's \"x\"", "'\"", "ends with\\", "(\\w+)\\.log"} {
+		selectQuery, newContext := parseWithParameters(t, query, parser.NewParameters().AddNamed("value", value))
+		queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+		expected := valuesOf(queryResults)
+		if len(expected) != 1 {
+			t.Fatalf("Expected the query with the value %v to return a row, received %v", value, expected)
+		}
+
+		formatted := selectQuery.String()
+		reparsed, newContext := parseWithParameters(t, formatted, parser.NewParameters())
+		formattedResults, _ := executor.NewSelectQueryExecutor(reparsed, newContext, executor.NewDefaultOptions()).Execute()
+		executor.AssertMatch(t, expected, formattedResults)
+		if reformatted := reparsed.String(); reformatted != formatted {
+			t.Fatalf("Expected the formatted query %v to format to itself, received %v", formatted, reformatted)
+		}
+	}
+}
+
+func parseWithParameters(t *testing.T, query string, parameters *parser.Parameters) (*parser.SelectQuery, *context.ParsingApplicationContext) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParserWithParameters(query, newContext, parameters)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("Expected the query %v to parse, received %v", query, err)
+	}
+	return selectQuery, newContext
+}
+
+func valuesOf(rows *executor.EvaluatingRows) [][]context.Value {
+	var values [][]context.Value
+	for iterator := rows.RowIterator(); iterator.HasNext(); {
+		values = append(values, iterator.Next().AllAttributes())
+	}
+	return values
+}
+
+func TestFormatsValuesWithBackSlashesAndBothQuotes(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	parameters := parser.NewParameters().AddPositional("x\\ y").AddPositional("a\\'b").AddPositional("it's \"x\"")
+	aParser, _ := parser.NewParserWithParameters("select name from ./resources where in(name, ?, ?, ?)", newContext, parameters)
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	expected := "select name from ./resources where in(name, 'x\\\\ y', \"a\\\\'b\", concat(it, \"'\", 's \"x\"'))"
+
+	if selectQuery.String() != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, selectQuery.String())
+	}
+}
+
+func TestFormatsAnEmptyQuotedValue(t *testing.T) {
+	formatted := format(t, "select concat(name, '', ext) from ./resources where ext != ''")
+	expected := "select concat(name, '', extension) from ./resources where notequal(extension, '')"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+	if reformatted := format(t, formatted); reformatted != expected {
+		t.Fatalf("Expected the formatted query to format to itself, received %v", reformatted)
+	}
+}
+
+func TestFormatsTheNullLiteralAndTheQuotedNull(t *testing.T) {
+	formatted := format(t, "select null, 'null' from ./resources where isnull(null)")
+	expected := "select null, 'null' from ./resources where isnull(null)"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+}
+
+func TestFormatsAQueryWithRelativeTimesAndTheirQuotedTexts(t *testing.T) {
+	formatted := format(t, "select yesterday, 'today', 2h from ./resources/TestResultsWithProjections/single where mtime > 7 days ago and name != '2h'")
+	expected := "select yesterday, 'today', 2h from ./resources/TestResultsWithProjections/single where and(greaterthan(modifiedtime, 7 days ago), notequal(name, '2h'))"
+
+	if formatted != expected {
+		t.Fatalf("Expected formatted query to be %v, received %v", expected, formatted)
+	}
+	if reformatted := format(t, formatted); reformatted != formatted {
+		t.Fatalf("Expected formatting to be idempotent, received %v", reformatted)
+	}
+}
@@ -0,0 +1,28 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func executeQuery(t *testing.T, query string, options *executor.Options) *executor.EvaluatingRows {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser(query, newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, err := executor.NewSelectQueryExecutor(selectQuery, newContext, options).Execute()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	return queryResults
+}
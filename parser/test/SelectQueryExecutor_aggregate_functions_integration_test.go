@@ -348,7 +348,7 @@ func TestResultsWithProjectionsWithMimeTypeAndCountDistinct(t *testing.T) {
 	}
 	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
 	expected := [][]context.Value{
-		{context.Uint32Value(3)},
+		{context.Uint32Value(2)},
 	}
 	executor.AssertMatch(t, expected, queryResults)
 }
@@ -365,7 +365,7 @@ func TestResultsWithProjectionsWithMimeTypeAndMin(t *testing.T) {
 	}
 	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
 	expected := [][]context.Value{
-		{context.StringValue("NA")},
+		{context.StringValue("text/plain")},
 	}
 	executor.AssertMatch(t, expected, queryResults)
 }
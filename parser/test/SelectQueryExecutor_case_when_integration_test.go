@@ -0,0 +1,89 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithCaseWhenInProjections(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name, case when size > 60 then large else small end as bucket from ./resources/TestResultsWithProjections/multi order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.StringValue("large")},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.StringValue("small")},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.StringValue("small")},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.StringValue("small")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithIfInProjections(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name, if(eq(ext, .log), log, other) from ./resources/TestResultsWithProjections/multi order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.StringValue("log")},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.StringValue("log")},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.StringValue("other")},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.StringValue("other")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithCaseWhenInOrderBy(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi order by case when ext = .txt then 1 else 2 end, name desc", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_D.txt")},
+		{context.StringValue("TestResultsWithProjections_C.txt")},
+		{context.StringValue("TestResultsWithProjections_B.log")},
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithCaseWhenInGroupBy(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select case when size > 60 then large else small end as bucket, count() from ./resources/TestResultsWithProjections/multi group by case when size > 60 then large else small end order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("large"), context.Uint32Value(1)},
+		{context.StringValue("small"), context.Uint32Value(3)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
@@ -0,0 +1,83 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithAMaximumDepth(t *testing.T) {
+	queryResults := executeQuery(t, "select name, depth from ./resources/TestResultsWithProjections depth 1 order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("empty"), context.Int64Value(1)},
+		{context.StringValue("hidden"), context.Int64Value(1)},
+		{context.StringValue("multi"), context.Int64Value(1)},
+		{context.StringValue("single"), context.Int64Value(1)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithADepthRange(t *testing.T) {
+	queryResults := executeQuery(t, "select name, depth from ./resources depth 3 to 3 order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue(".Make"), context.Int64Value(3)},
+		{context.StringValue("Empty.log"), context.Int64Value(3)},
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Int64Value(3)},
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.Int64Value(3)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Int64Value(3)},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.Int64Value(3)},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.Int64Value(3)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithTheDepthAttributeInWhere(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections where eq(depth, 2) and lt(size, 60) and eq(isfile, true) order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue(".Make")},
+		{context.StringValue("Empty.log")},
+		{context.StringValue("TestResultsWithProjections_A.txt")},
+		{context.StringValue("TestResultsWithProjections_B.log")},
+		{context.StringValue("TestResultsWithProjections_C.txt")},
+		{context.StringValue("TestResultsWithProjections_D.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAMaximumDepthInOptions(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections order by 1", executor.NewDefaultOptions().MaxDepth(1))
+	expected := [][]context.Value{
+		{context.StringValue("empty")},
+		{context.StringValue("hidden")},
+		{context.StringValue("multi")},
+		{context.StringValue("single")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAMinimumDepthInOptions(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections where eq(isfile, false) order by 1", executor.NewDefaultOptions().MinDepth(2))
+	executor.AssertMatch(t, [][]context.Value{}, queryResults)
+}
+
+func TestResultsWithTheDepthInTheQueryOverTheDepthInOptions(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections/multi depth 1 order by 1 limit 1", executor.NewDefaultOptions().MinDepth(2).MaxDepth(3))
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithADepthInAJoin(t *testing.T) {
+	queryResults := executeQuery(t, "select a.name, b.relpath from ./resources/TestResultsWithProjections as a join ./resources/TestResultsWithProjections as b on eq(a.name, b.name) depth 1 order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("empty"), context.StringValue("empty")},
+		{context.StringValue("hidden"), context.StringValue("hidden")},
+		{context.StringValue("multi"), context.StringValue("multi")},
+		{context.StringValue("single"), context.StringValue("single")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
@@ -0,0 +1,60 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithAGlobInTheSource(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections/multi/*.log order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+		{context.StringValue("TestResultsWithProjections_B.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithADoubleStarGlobInTheSource(t *testing.T) {
+	queryResults := executeQuery(t, "select relpath from ./resources/TestResultsWithProjections/**/*.txt order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("multi/TestResultsWithProjections_C.txt")},
+		{context.StringValue("multi/TestResultsWithProjections_D.txt")},
+		{context.StringValue("single/TestResultsWithProjections_A.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAGlobInTheMiddleOfTheSource(t *testing.T) {
+	queryResults := executeQuery(t, "select name, root from ./resources/*/m*/*_[AC].* order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.StringValue("./resources")},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.StringValue("./resources")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAGlobAndADirectoryInTheSource(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections/multi/*_A.*, ./resources/TestResultsWithProjections/single order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+		{context.StringValue("TestResultsWithProjections_A.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAGlobAndADepth(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/**/*.log depth 2 order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("File_(1).log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAGlobWithoutTheNestedTraversal(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections/**/*.txt", executor.NewDefaultOptions().DisableNestedTraversal())
+	executor.AssertMatch(t, [][]context.Value{}, queryResults)
+}
@@ -0,0 +1,215 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithGroupByExtensionAndCount(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext, count() from ./resources/TestResultsWithProjections/multi group by ext order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Uint32Value(2)},
+		{context.StringValue(".txt"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByExtensionAndSumOfSize(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext, sum(size), min(size), max(size) from ./resources/TestResultsWithProjections/multi group by ext order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Float64Value(129), context.Int64Value(58), context.Int64Value(71)},
+		{context.StringValue(".txt"), context.Float64Value(116), context.Int64Value(58), context.Int64Value(58)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByAFunctionAndAnAggregateInsideAScalarFunction(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select upper(ext), fmtsize(avg(size)) from ./resources/TestResultsWithProjections/multi group by upper(ext) order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".LOG"), context.StringValue("64 B")},
+		{context.StringValue(".TXT"), context.StringValue("58 B")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByMultipleExpressions(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext, size, count() from ./resources/TestResultsWithProjections/multi group by ext, size order by 1, 2", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Int64Value(58), context.Uint32Value(1)},
+		{context.StringValue(".log"), context.Int64Value(71), context.Uint32Value(1)},
+		{context.StringValue(".txt"), context.Int64Value(58), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByAndWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext, count() from ./resources/TestResultsWithProjections/multi where eq(size, 58) group by ext order by 2 desc", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".txt"), context.Uint32Value(2)},
+		{context.StringValue(".log"), context.Uint32Value(1)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByAndOnlyAggregatesInProjection(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select count() from ./resources/TestResultsWithProjections/multi group by ext", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Uint32Value(2)},
+		{context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByAndLimit(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext, count() from ./resources/TestResultsWithProjections/multi group by ext order by 1 desc limit 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".txt"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByAndAScalarFunctionCombiningAnAttributeAndAnAggregate(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select concat(ext, count()) from ./resources/TestResultsWithProjections/multi group by ext order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log2")},
+		{context.StringValue(".txt2")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByAndAnAttributeAggregateAndMixedProjection(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext, sum(size), concat(upper(ext), '-', sum(size)) from ./resources/TestResultsWithProjections/multi group by ext order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Float64Value(129), context.StringValue(".LOG-129.00")},
+		{context.StringValue(".txt"), context.Float64Value(116), context.StringValue(".TXT-116.00")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByAndAnAliasOfTheGroupByAttribute(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select extension, lower(ext), count() from ./resources/TestResultsWithProjections/multi group by ext order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.StringValue(".log"), context.Uint32Value(2)},
+		{context.StringValue(".txt"), context.StringValue(".txt"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestAttemptToGroupWithANonGroupedExpression(t *testing.T) {
+	queries := map[string]string{
+		"select name, ext, count() from ./resources/TestResultsWithProjections/multi group by ext":                     "name",
+		"select ext, count() from ./resources/TestResultsWithProjections/multi group by ext order by lower(name)":      "lower(name)",
+		"select ext, count() from ./resources/TestResultsWithProjections/multi group by ext having name = a.log":       "eq(name,a.log)",
+		"select ext, count() from ./resources/TestResultsWithProjections/multi group by lower(ext)":                    "ext",
+		"select name from ./resources/TestResultsWithProjections/multi having count() > 1":                             "name",
+		"select ext from ./resources/TestResultsWithProjections/multi union select name from ./resources group by ext": "name",
+	}
+	for query, nonGrouped := range queries {
+		newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+		aParser, _ := parser.NewParser(query, newContext)
+		_, err := aParser.Parse()
+
+		expected := fmt.Sprintf(messages.ErrorMessageNonGroupedExpression, nonGrouped)
+		if err == nil || errors.Unwrap(err) == nil || errors.Unwrap(err).Error() != expected {
+			t.Fatalf("Expected an error %v given %v, received %v", expected, query, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithInFunctionInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where in(name, TestResultsWithProjections_A.log, TestResultsWithProjections_D.txt) order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+		{context.StringValue("TestResultsWithProjections_D.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithInfixInListInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where size in (71, 1024) and ext in (.log, .go)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithInSubqueryInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where basename in (select basename from ./resources/TestResultsWithProjections/single where eq(isfile, true))", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithNegatedInSubqueryInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where not(basename in (select basename from ./resources/TestResultsWithProjections/single)) order by 1 limit 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_B.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestParsesAQueryWithSubqueryHavingMultipleProjections(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, _ := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where basename in (select basename, size from ./resources/TestResultsWithProjections/single)", newContext)
+	_, err := aParser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given a subquery with multiple projections")
+	}
+}
+
+func TestParsesAQueryWithSubqueryInProjection(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, _ := parser.NewParser("select basename in (select basename from ./resources/TestResultsWithProjections/single) from ./resources/TestResultsWithProjections/multi", newContext)
+	_, err := aParser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given a subquery in projection")
+	}
+}
+
+func TestResultsWithInSubqueryOfADifferentNumericTypeInWhere(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections/multi where size in (select size * 1.0 from ./resources/TestResultsWithProjections/multi where size > 60)", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithInListHavingAMatchAndANullInWhere(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections/multi where ext in (.txt, null) order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_C.txt")},
+		{context.StringValue("TestResultsWithProjections_D.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithNegatedInListHavingANullInWhere(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections/multi where not (ext in (.txt, null))", executor.NewDefaultOptions())
+	executor.AssertMatch(t, [][]context.Value{}, queryResults)
+}
+
+func TestResultsWithNegatedInSubqueryHavingANullInWhere(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections/multi where not (basename in (select nullif(basename, basename) from ./resources/TestResultsWithProjections/single))", executor.NewDefaultOptions())
+	executor.AssertMatch(t, [][]context.Value{}, queryResults)
+}
+
+func TestResultsWithANullInSubqueryInWhere(t *testing.T) {
+	queryResults := executeQuery(t, "select name from ./resources/TestResultsWithProjections/multi where isnull(nullif(basename, basename) in (select basename from ./resources/TestResultsWithProjections/single)) order by 1 limit 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
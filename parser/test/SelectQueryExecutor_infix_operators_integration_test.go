@@ -0,0 +1,160 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithInfixArithmeticInProjection(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name, size * 2 + 1, (size - 8) / 10 from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.Float64Value(117), context.Float64Value(5)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithInfixComparisonInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where size > 60 order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithInfixBooleanLogicAndParenthesesInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where size < 1kb and (ext = '.log' or name like '.*_C.*') order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log")},
+		{context.StringValue("TestResultsWithProjections_B.log")},
+		{context.StringValue("TestResultsWithProjections_C.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithInfixNotInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where not ext = .log order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_C.txt")},
+		{context.StringValue("TestResultsWithProjections_D.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithInfixOperatorsOverAggregates(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select sum(size) / count(), max(size) - min(size) from ./resources/TestResultsWithProjections/multi", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Float64Value(61.25), context.Float64Value(13)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithInfixOperatorsInGroupByAndHaving(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext, count() from ./resources/TestResultsWithProjections/multi group by ext having count() > 1 and sum(size) > 120", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithComparisonOperatorsWithoutSpacesInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where ext!=.log and size>0 order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_C.txt")},
+		{context.StringValue("TestResultsWithProjections_D.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestAttemptToUseArithmeticOperatorsWithoutSpaces(t *testing.T) {
+	queries := map[string]string{
+		"select -size from ./resources/TestResultsWithProjections/multi":                  "-size",
+		"select name, size/1024 from ./resources/TestResultsWithProjections/multi":        "size/1024",
+		"select name from ./resources/TestResultsWithProjections/multi where size*2 > 10": "size*2",
+	}
+	for query, token := range queries {
+		newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+		aParser, err := parser.NewParser(query, newContext)
+		if err != nil {
+			t.Fatalf("error is %v", err)
+		}
+		_, err = aParser.Parse()
+		if err == nil {
+			t.Fatalf("Expected an error for the query %v", query)
+		}
+		expected := fmt.Sprintf(messages.ErrorMessageOperatorWithoutSpaces, token)
+		if errors.Unwrap(err).Error() != expected {
+			t.Fatalf("Expected error message to be %v, received %v", expected, errors.Unwrap(err).Error())
+		}
+	}
+}
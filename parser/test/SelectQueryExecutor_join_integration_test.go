@@ -0,0 +1,223 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithJoinOnAnEquality(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select a.name, b.name from ./resources/TestResultsWithProjections/multi as a join ./resources/TestResultsWithProjections/single as b on eq(a.basename, b.basename)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.StringValue("TestResultsWithProjections_A.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithJoinAndWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select a.name, a.ext, b.ext from ./resources/TestResultsWithProjections/multi as a join ./resources/TestResultsWithProjections/single as b on a.size = b.size where ne(a.ext, b.ext)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_B.log"), context.StringValue(".log"), context.StringValue(".txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithJoinOnANonEquality(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select a.name, b.name from ./resources/TestResultsWithProjections/multi as a join ./resources/TestResultsWithProjections/single as b on gt(a.size, b.size)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.StringValue("TestResultsWithProjections_A.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithLeftJoin(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select a.name, b.name from ./resources/TestResultsWithProjections/multi as a left join ./resources/TestResultsWithProjections/single as b on eq(a.basename, b.basename) order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.StringValue("TestResultsWithProjections_A.txt")},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.NullValue},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.NullValue},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.NullValue},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithLeftJoinFindingMissingFiles(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select a.relpath from ./resources/TestResultsWithProjections/multi as a left join ./resources/TestResultsWithProjections/single as b on eq(a.basename, b.basename) where isnull(b.name) order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_B.log")},
+		{context.StringValue("TestResultsWithProjections_C.txt")},
+		{context.StringValue("TestResultsWithProjections_D.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithLeftJoinCoalescingNulls(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select a.name, coalesce(b.name, missing) from ./resources/TestResultsWithProjections/multi as a left join ./resources/TestResultsWithProjections/single as b on eq(a.basename, b.basename) order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.StringValue("TestResultsWithProjections_A.txt")},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.StringValue("missing")},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.StringValue("missing")},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.StringValue("missing")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithLeftJoinCountingSkippingNulls(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select count(b.name), count(), max(b.size) from ./resources/TestResultsWithProjections/multi as a left join ./resources/TestResultsWithProjections/single as b on eq(a.basename, b.basename)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Uint32Value(1), context.Uint32Value(4), context.Int64Value(58)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithLeftJoinGroupingByANull(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select b.name, count() from ./resources/TestResultsWithProjections/multi as a left join ./resources/TestResultsWithProjections/single as b on eq(a.basename, b.basename) group by b.name order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.NullValue, context.Uint32Value(3)},
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.Uint32Value(1)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithJoinOnPathFromRoot(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select count() from ./resources/TestResultsWithProjections/multi as a join ./resources/TestResultsWithProjections/multi/ as b on eq(a.relpath, b.relpath) where ne(a.root, b.root)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Uint32Value(4)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithJoinAndGroupBy(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select b.name, count() from ./resources/TestResultsWithProjections/multi as a join ./resources/TestResultsWithProjections/single as b on eq(a.size, b.size) group by b.name", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.Uint32Value(3)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithMultipleJoins(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select a.name, c.name from ./resources/TestResultsWithProjections/single as a join ./resources/TestResultsWithProjections/multi as b on eq(a.basename, b.basename) join ./resources/TestResultsWithProjections/multi as c on eq(b.size, c.size) and ne(b.name, c.name)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	if queryResults.Count() != 0 {
+		t.Fatalf("Expected no results given the file with basename A has no other file of the same size, received %v", queryResults.Count())
+	}
+}
+
+func TestResultsWithAnAliasedSourceWithoutJoin(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select s.name, size from ./resources/TestResultsWithProjections/single as s", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.Int64Value(58)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
@@ -0,0 +1,65 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithMultipleSourceDirectories(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name, root from ./resources/TestResultsWithProjections/single, ./resources/TestResultsWithProjections/multi where ext = .txt order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.StringValue("./resources/TestResultsWithProjections/single")},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.StringValue("./resources/TestResultsWithProjections/multi")},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.StringValue("./resources/TestResultsWithProjections/multi")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAggregatesAcrossMultipleSourceDirectories(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select count() from ./resources/TestResultsWithProjections/single, ./resources/TestResultsWithProjections/multi", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Uint32Value(5)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithGroupByRootAcrossMultipleSourceDirectories(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select root, count() from ./resources/TestResultsWithProjections/single, ./resources/TestResultsWithProjections/multi group by root order by 2", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("./resources/TestResultsWithProjections/single"), context.Uint32Value(1)},
+		{context.StringValue("./resources/TestResultsWithProjections/multi"), context.Uint32Value(4)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
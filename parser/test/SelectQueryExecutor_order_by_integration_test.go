@@ -0,0 +1,100 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"reflect"
+	"testing"
+)
+
+func TestResultsWithOrderByAnAlias(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name, size as bytes from ./resources/TestResultsWithProjections/multi order by bytes desc, 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Int64Value(71)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Int64Value(58)},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.Int64Value(58)},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.Int64Value(58)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+
+	expectedAttributes := []string{"name", "bytes"}
+	if !reflect.DeepEqual(expectedAttributes, selectQuery.Projections.DisplayableAttributes()) {
+		t.Fatalf("Expected displayable attributes to be %v, received %v", expectedAttributes, selectQuery.Projections.DisplayableAttributes())
+	}
+}
+
+func TestResultsWithOrderByAProjectedAttributeName(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select size, name from ./resources/TestResultsWithProjections/multi order by name desc", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Int64Value(58), context.StringValue("TestResultsWithProjections_D.txt")},
+		{context.Int64Value(58), context.StringValue("TestResultsWithProjections_C.txt")},
+		{context.Int64Value(58), context.StringValue("TestResultsWithProjections_B.log")},
+		{context.Int64Value(71), context.StringValue("TestResultsWithProjections_A.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithOrderByAttributesThatAreNotProjected(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi order by ext desc, lower(name)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_C.txt")},
+		{context.StringValue("TestResultsWithProjections_D.txt")},
+		{context.StringValue("TestResultsWithProjections_A.log")},
+		{context.StringValue("TestResultsWithProjections_B.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+
+	expectedAttributes := []string{"name"}
+	if !reflect.DeepEqual(expectedAttributes, selectQuery.Projections.DisplayableAttributes()) {
+		t.Fatalf("Expected displayable attributes to be %v, received %v", expectedAttributes, selectQuery.Projections.DisplayableAttributes())
+	}
+}
+
+func TestResultsWithOrderByAnAggregateInGroupBy(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext from ./resources/TestResultsWithProjections/multi group by ext order by max(size) desc", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log")},
+		{context.StringValue(".txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
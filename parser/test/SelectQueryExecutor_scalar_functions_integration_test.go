@@ -8,9 +8,9 @@ import (
 	"goselect/parser"
 	"goselect/parser/context"
 	"goselect/parser/executor"
-	"math"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestWithAnErrorWhileRunningAProjection(t *testing.T) {
@@ -256,8 +256,8 @@ func TestResultsWithProjectionsWithDayDifference(t *testing.T) {
 	result := queryResults.AtIndex(0).AllAttributes()[0]
 	asFloat64, _ := result.GetNumericAsFloat64()
 
-	if math.Round(asFloat64) != float64(0) {
-		t.Fatalf("Expected day difference of 2 current times to be equal to zero but received %v and round resulted in %v", asFloat64, math.Round(asFloat64))
+	if asFloat64 != float64(0) {
+		t.Fatalf("Expected day difference of 2 current times to be equal to zero but received %v", asFloat64)
 	}
 }
 
@@ -276,8 +276,8 @@ func TestResultsWithProjectionsWithHourDifference(t *testing.T) {
 	result := queryResults.AtIndex(0).AllAttributes()[0]
 	asFloat64, _ := result.GetNumericAsFloat64()
 
-	if math.Round(asFloat64) != float64(0) {
-		t.Fatalf("Expected hour difference of 2 current times to be equal to zero but received %v and round resulted in %v", asFloat64, math.Round(asFloat64))
+	if asFloat64 != float64(0) {
+		t.Fatalf("Expected hour difference of 2 current times to be equal to zero but received %v", asFloat64)
 	}
 }
 
@@ -637,10 +637,10 @@ func TestResultsWithProjectionsWithMimeType3(t *testing.T) {
 	}
 	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
 	expected := [][]context.Value{
-		{context.BooleanValue(true), context.StringValue("empty"), context.StringValue("NA")},
-		{context.BooleanValue(true), context.StringValue("hidden"), context.StringValue("NA")},
-		{context.BooleanValue(true), context.StringValue("multi"), context.StringValue("NA")},
-		{context.BooleanValue(true), context.StringValue("single"), context.StringValue("NA")},
+		{context.BooleanValue(true), context.StringValue("empty"), context.NullValue},
+		{context.BooleanValue(true), context.StringValue("hidden"), context.NullValue},
+		{context.BooleanValue(true), context.StringValue("multi"), context.NullValue},
+		{context.BooleanValue(true), context.StringValue("single"), context.NullValue},
 		{context.BooleanValue(false), context.StringValue(".make"), context.StringValue("text/plain")},
 		{context.BooleanValue(false), context.StringValue("empty.log"), context.StringValue("text/plain")},
 		{context.BooleanValue(false), context.StringValue("testresultswithprojections_a.log"), context.StringValue("text/plain; charset=utf-8")},
@@ -764,3 +764,331 @@ func TestResultsWithProjectionsWithoutProperParametersToAFunction(t *testing.T)
 		t.Fatalf("Expected an error on running a query with lower() without any parameter")
 	}
 }
+
+func TestResultsWithProjectionsIncludingRegexExtractAndRegexReplace(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select regexextract(name, '_([A-Z])[.]', 1), regexextract(name, '[.](?P<suffix>[a-z]+)$', suffix), regexreplace(name, '^([A-Za-z]+)_([A-Z])', '$2-$1') from ./resources/TestResultsWithProjections/multi order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("A"), context.StringValue("log"), context.StringValue("A-TestResultsWithProjections.log")},
+		{context.StringValue("B"), context.StringValue("log"), context.StringValue("B-TestResultsWithProjections.log")},
+		{context.StringValue("C"), context.StringValue("txt"), context.StringValue("C-TestResultsWithProjections.txt")},
+		{context.StringValue("D"), context.StringValue("txt"), context.StringValue("D-TestResultsWithProjections.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingRegexExtractWithCharacterClasses(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select regexextract(name, '(\\w+)\\.(\\w+)', 2), regexextract(name, '\\.(?P<ext>\\w+)$', 'ext'), regexextract(tostring(size), '^(\\d)\\d$', 1) from ./resources/TestResultsWithProjections/multi order by 1, 3", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("log"), context.StringValue("log"), context.StringValue("5")},
+		{context.StringValue("log"), context.StringValue("log"), context.StringValue("7")},
+		{context.StringValue("txt"), context.StringValue("txt"), context.StringValue("5")},
+		{context.StringValue("txt"), context.StringValue("txt"), context.StringValue("5")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingAQuotedAttributeNameAsAValue(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select concat('name', '-', name) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("name-TestResultsWithProjections_A.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingRegexExtractInGroupBy(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select regexextract(name, '[a-z]+$'), count() from ./resources/TestResultsWithProjections/multi group by regexextract(name, '[a-z]+$') order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("log"), context.Uint32Value(2)},
+		{context.StringValue("txt"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingCasts(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select cast(size as string), tofloat(size), cast(size as bool), tostring(isdir) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("58"), context.Float64Value(58), context.BooleanValue(true), context.StringValue("false")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingAFailedCast(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select cast(basename as int) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	if err == nil {
+		t.Fatalf("Expected an error while casting a file name to int")
+	}
+}
+
+func TestResultsWithProjectionsIncludingACastToAnUnsupportedTypeWithoutMatchingRows(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select cast(name as bogus) from ./resources/TestResultsWithProjections/single where name = nothing", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+	if err == nil {
+		t.Fatalf("Expected a parse error while casting to an unsupported type")
+	}
+}
+
+func TestResultsWithProjectionsIncludingACastWithoutAs(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select cast(name int) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+	if err == nil {
+		t.Fatalf("Expected a parse error given cast without as")
+	}
+}
+
+func TestResultsWithProjectionsIncludingAFailedCastInLenientMode(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions().InLenientMode(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name, cast(basename as int), coalesce(toint(basename), -1) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.NullValue, context.Int64Value(-1)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingDateArithmetic(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select dateadd(parsedatetime(2022-01-31, dt), 1, month), datetrunc(parsedatetime(2022-10-09T11:48:05, ts), week), formatdatetime(parsedatetime(2022-10-09T11:48:05, ts), %Y-%m), unixtime(parsedatetime(2022-10-18, dt)), fromunixtime(1666051200) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{
+			context.DateTimeValue(time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC)),
+			context.DateTimeValue(time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC)),
+			context.StringValue("2022-10"),
+			context.Int64Value(1666051200),
+			context.DateTimeValue(time.Date(2022, 10, 18, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingDateTruncInGroupBy(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select count(), eq(datetrunc(mtime, year), max(datetrunc(datetrunc(mtime, month), year))) from ./resources/TestResultsWithProjections/multi where lt(dateadd(mtime, -7, day), mtime) group by datetrunc(mtime, year)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Uint32Value(4), context.BooleanValue(true)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingAgeAndRelativeTimes(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name, ge(age, 0s), eq(formatduration(age, day), formatduration(age, days)), le(start of year, now()), gt(mtime, tomorrow) from ./resources/TestResultsWithProjections/single where mtime < 0 seconds ago and mtime > 100 years ago", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.BooleanValue(true), context.BooleanValue(true), context.BooleanValue(true), context.BooleanValue(false)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingAtTimeZone(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select formatdatetime(attimezone(parsedatetime(2022-10-03T23:30:00, ts), Europe/Berlin), '%F %H:%M %Z'), extract(attz(parsedatetime(2022-10-03T23:30:00, ts), +05:30), date), eq(attz(mtime, Asia/Tokyo), mtime) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("2022-10-04 01:30 CEST"), context.StringValue("2022-October-04"), context.BooleanValue(true)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsInATimeZone(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	if err := newContext.SetTimeZone("America/New_York"); err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	aParser, err := parser.NewParser("select formatdatetime(mtime, %Z), formatdatetime(now(), %Z), formatdatetime(start of month, '%d %H:%M'), parsedatetime(2022-10-03T23:30:00, ts) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	newYork, _ := time.LoadLocation("America/New_York")
+	zone, _ := time.Now().In(newYork).Zone()
+	modifiedZone := queryResults.RowIterator().Next().AllAttributes()[0].GetAsString()
+	if modifiedZone != "EST" && modifiedZone != "EDT" {
+		t.Fatalf("Expected the modified time to be in the zone EST or EDT, received %v", modifiedZone)
+	}
+	expected := [][]context.Value{
+		{context.StringValue(modifiedZone), context.StringValue(zone), context.StringValue("01 00:00"), context.DateTimeValue(time.Date(2022, 10, 3, 23, 30, 0, 0, newYork))},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsComparingStringsWithRelativeTimeAndDurationWords(t *testing.T) {
+	queryResults := executeQuery(t, "select name, eq(concat(to, day), 'today'), eq(concat(to, day), today), in(concat(yester, day), 'yesterday', 'tomorrow'), eq(name, today) from ./resources/TestResultsWithProjections/single", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.BooleanValue(true), context.BooleanValue(false), context.BooleanValue(true), context.BooleanValue(false)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsComparingANumberWithADuration(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/single where size > 2m", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	if err == nil {
+		t.Fatalf("Expected an error while comparing the size with a duration")
+	}
+}
+
+func TestResultsWithProjectionsIncludingRegexExtractWithAGroupNamedLikeABoolean(t *testing.T) {
+	queryResults := executeQuery(t, "select regexextract(name, '_(?P<y>[A-Z])\\.', 'y'), regexextract(name, '\\.(?P<true>\\w+)$', 'true') from ./resources/TestResultsWithProjections/single", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("A"), context.StringValue("txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingAnEmptyQuotedValue(t *testing.T) {
+	queryResults := executeQuery(t, "select concat(basename, '', ext), eq(ext, ''), ext = '' from ./resources/TestResultsWithProjections/single", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.BooleanValue(false), context.BooleanValue(false)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsWithANullMimeTypeForDirectories(t *testing.T) {
+	queryResults := executeQuery(t, "select name, mime, isnull(mime) from ./resources/TestResultsWithProjections/ where isdir = true order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("empty"), context.NullValue, context.BooleanValue(true)},
+		{context.StringValue("hidden"), context.NullValue, context.BooleanValue(true)},
+		{context.StringValue("multi"), context.NullValue, context.BooleanValue(true)},
+		{context.StringValue("single"), context.NullValue, context.BooleanValue(true)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithProjectionsIncludingTheNullLiteral(t *testing.T) {
+	queryResults := executeQuery(t, "select null, isnull(null), null or true, null and true, coalesce(null, name), 'null' from ./resources/TestResultsWithProjections/single", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.NullValue, context.BooleanValue(true), context.BooleanValue(true), context.NullValue, context.StringValue("TestResultsWithProjections_A.txt"), context.StringValue("null")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithRelativeTimesAndDurationsAsProjections(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode()
+	aParser, err := parser.NewParser("select 2h, yesterday < today, start of month <= now(), 7 days ago < today from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.DurationValue(2 * time.Hour), context.BooleanValue(true), context.BooleanValue(true), context.BooleanValue(true)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+	if attributes := selectQuery.Projections.DisplayableAttributes(); attributes[0] != "2h" {
+		t.Fatalf("Expected the duration to be displayed as 2h, received %v", attributes[0])
+	}
+}
@@ -676,3 +676,38 @@ func TestResultsWithAWhereClause30(t *testing.T) {
 	}
 	executor.AssertMatch(t, expected, queryResults)
 }
+
+func TestResultsWithAWhereClauseWithRegexMatch(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select lower(name) from ./resources/TestResultsWithProjections/multi where regexmatch(name, '_[AC][.]') order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("testresultswithprojections_a.log")},
+		{context.StringValue("testresultswithprojections_c.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAWhereClauseWithRegexExtract(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select lower(name) from ./resources/TestResultsWithProjections/multi where eq(regexextract(name, '_([A-Z])[.]', 1), B)", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("testresultswithprojections_b.log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
@@ -0,0 +1,117 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithUnion(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext from ./resources/TestResultsWithProjections/multi union select ext from ./resources/TestResultsWithProjections/single order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log")},
+		{context.StringValue(".txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithUnionAllAndLimit(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext from ./resources/TestResultsWithProjections/multi union all select ext from ./resources/TestResultsWithProjections/single order by 1 desc limit 3", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".txt")},
+		{context.StringValue(".txt")},
+		{context.StringValue(".txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithExcept(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select size from ./resources/TestResultsWithProjections/multi except select size from ./resources/TestResultsWithProjections/multi where ext = .txt", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Int64Value(71)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithIntersect(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select ext from ./resources/TestResultsWithProjections/multi where size > 60 intersect select ext from ./resources/TestResultsWithProjections/multi where size < 60", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue(".log")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithUnionOfAggregates(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select count() from ./resources/TestResultsWithProjections/multi union all select count() from ./resources/TestResultsWithProjections/single order by 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.Uint32Value(1)},
+		{context.Uint32Value(4)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithUnionOfAnIntegerAndAFloat(t *testing.T) {
+	queryResults := executeQuery(t, "select size from ./resources/TestResultsWithProjections/multi union select tofloat(size) from ./resources/TestResultsWithProjections/multi order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.Int64Value(58)},
+		{context.Int64Value(71)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithIntersectOfAnIntegerAndAFloat(t *testing.T) {
+	queryResults := executeQuery(t, "select size from ./resources/TestResultsWithProjections/multi intersect select size * 1.0 from ./resources/TestResultsWithProjections/multi where size > 60", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.Int64Value(71)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
@@ -0,0 +1,157 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestResultsWithRowNumberOverPartitions(t *testing.T) {
+	queryResults := executeQuery(t, "select name, row_number() over (partition by dirname(path) order by size desc, name) as rn from ./resources/TestResultsWithProjections where eq(isfile, true) order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue(".Make"), context.Uint32Value(1)},
+		{context.StringValue("Empty.log"), context.Uint32Value(1)},
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Uint32Value(1)},
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.Uint32Value(1)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Uint32Value(2)},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.Uint32Value(3)},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.Uint32Value(4)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithTheTopFilesOfEveryPartitionUsingOrderByAndLimit(t *testing.T) {
+	queryResults := executeQuery(t, "select name, rownumber() over (order by size desc, name) as rn from ./resources/TestResultsWithProjections/multi order by rn limit 2", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Uint32Value(1)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithRankAndDenseRank(t *testing.T) {
+	queryResults := executeQuery(t, "select name, rank() over (order by size desc), dense_rank() over (order by size desc) from ./resources/TestResultsWithProjections/multi order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Uint32Value(1), context.Uint32Value(1)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Uint32Value(2), context.Uint32Value(2)},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.Uint32Value(2), context.Uint32Value(2)},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.Uint32Value(2), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithARunningSum(t *testing.T) {
+	queryResults := executeQuery(t, "select name, sum(size) over (order by name) from ./resources/TestResultsWithProjections/multi order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Float64Value(71)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Float64Value(129)},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.Float64Value(187)},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.Float64Value(245)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithARunningSumSharedByPeers(t *testing.T) {
+	queryResults := executeQuery(t, "select name, sum(size) over (order by size) from ./resources/TestResultsWithProjections/multi order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Float64Value(245)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Float64Value(174)},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.Float64Value(174)},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.Float64Value(174)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAnAggregateOverAPartitionWithoutOrderBy(t *testing.T) {
+	queryResults := executeQuery(t, "select name, count() over (partition by ext), max(size) over (partition by ext) from ./resources/TestResultsWithProjections/multi order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Uint32Value(2), context.Int64Value(71)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Uint32Value(2), context.Int64Value(71)},
+		{context.StringValue("TestResultsWithProjections_C.txt"), context.Uint32Value(2), context.Int64Value(58)},
+		{context.StringValue("TestResultsWithProjections_D.txt"), context.Uint32Value(2), context.Int64Value(58)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAWindowOverGroups(t *testing.T) {
+	queryResults := executeQuery(t, "select ext, sum(size), rank() over (order by sum(size) desc) from ./resources/TestResultsWithProjections/multi group by ext order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Float64Value(129), context.Uint32Value(1)},
+		{context.StringValue(".txt"), context.Float64Value(116), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithDistinctAndAWindow(t *testing.T) {
+	queryResults := executeQuery(t, "select distinct ext, count() over (partition by ext) from ./resources/TestResultsWithProjections/multi order by 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Uint32Value(2)},
+		{context.StringValue(".txt"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAWindowInASetOperation(t *testing.T) {
+	queryResults := executeQuery(t, "select name, rownumber() over (order by name) from ./resources/TestResultsWithProjections/multi where ext = .log union all select name, rownumber() over (order by name) from ./resources/TestResultsWithProjections/single order by 2, 1", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.log"), context.Uint32Value(1)},
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.Uint32Value(1)},
+		{context.StringValue("TestResultsWithProjections_B.log"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestAttemptToUseAWindowFunctionInWhere(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, _ := parser.NewParser("select name from ./resources/TestResultsWithProjections/multi where rank() over (order by size) = 1", newContext)
+	_, err := aParser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given a window function in where")
+	}
+}
+
+func TestAttemptToUseAWindowFunctionInsideAnExpression(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, _ := parser.NewParser("select rank() over (order by size) + 1 from ./resources/TestResultsWithProjections/multi", newContext)
+	_, err := aParser.Parse()
+
+	if err == nil {
+		t.Fatalf("Expected an error given a window function inside an expression")
+	}
+}
+
+func TestAttemptToUseAWindowAliasInHaving(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, _ := parser.NewParser("select ext, count(), rownumber() over (order by ext) as rn from ./resources/TestResultsWithProjections/multi group by ext having rn <= 1", newContext)
+	_, err := aParser.Parse()
+
+	if err == nil || errors.Unwrap(err) == nil || errors.Unwrap(err).Error() != fmt.Sprintf(messages.ErrorMessageWindowAliasInsideHaving, "rn") {
+		t.Fatalf("Expected an error %v, received %v", fmt.Sprintf(messages.ErrorMessageWindowAliasInsideHaving, "rn"), err)
+	}
+}
+
+func TestResultsWithAWindowAndHavingOnAnAggregate(t *testing.T) {
+	queryResults := executeQuery(t, "select ext, count(), rownumber() over (order by ext) as rn from ./resources/TestResultsWithProjections/multi group by ext having count() > 1 order by rn", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Uint32Value(2), context.Uint32Value(1)},
+		{context.StringValue(".txt"), context.Uint32Value(2), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestResultsWithAQuotedValueMatchingAWindowAliasInHaving(t *testing.T) {
+	queryResults := executeQuery(t, "select ext, rownumber() over (order by ext) as rn from ./resources/TestResultsWithProjections/multi group by ext having ext != 'rn' order by rn", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue(".log"), context.Uint32Value(1)},
+		{context.StringValue(".txt"), context.Uint32Value(2)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
@@ -0,0 +1,188 @@
+//go:build integration
+// +build integration
+
+package test
+
+import (
+	"errors"
+	"fmt"
+	"goselect/parser"
+	"goselect/parser/context"
+	"goselect/parser/error/messages"
+	"goselect/parser/executor"
+	"testing"
+)
+
+func TestStrictModeRejectsAMisspelledProjection(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode()
+	aParser, err := parser.NewParser("select nmae from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	if err == nil || errors.Unwrap(err) == nil || errors.Unwrap(err).Error() != fmt.Sprintf(messages.ErrorMessageUnexpectedTokenInProjection, "nmae") {
+		t.Fatalf("Expected an error %v, received %v", fmt.Sprintf(messages.ErrorMessageUnexpectedTokenInProjection, "nmae"), err)
+	}
+}
+
+func TestStrictModeRejectsTokensAfterTheQuery(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode()
+	aParser, err := parser.NewParser("select name from ./resources/TestResultsWithProjections/single limit 1 2", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	var parseError *parser.ParseError
+	if !errors.As(err, &parseError) || errors.Unwrap(err).Error() != fmt.Sprintf(messages.ErrorMessageUnexpectedTokenAtEnd, "2") {
+		t.Fatalf("Expected an error %v, received %v", fmt.Sprintf(messages.ErrorMessageUnexpectedTokenAtEnd, "2"), err)
+	}
+}
+
+func TestStrictModeRejectsATrailingCommaInProjections(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode()
+	aParser, err := parser.NewParser("select name, from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	var parseError *parser.ParseError
+	if !errors.As(err, &parseError) || errors.Unwrap(err).Error() != messages.ErrorMessageTrailingCommaInProjection {
+		t.Fatalf("Expected an error %v, received %v", messages.ErrorMessageTrailingCommaInProjection, err)
+	}
+	if parseError.Column != 12 {
+		t.Fatalf("Expected the parse error at column 12, received %v", parseError.Column)
+	}
+}
+
+func TestStrictModeWithAValidQuery(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode()
+	aParser, err := parser.NewParser("select lower(name), size / 2 as half from ./resources/TestResultsWithProjections/single where size > 0 order by half limit 1", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("testresultswithprojections_a.txt"), context.Float64Value(29)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestLenientModeIgnoresAMisspelledProjection(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select nmae, name from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestLenientModeIgnoresATrailingCommaInProjections(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes())
+	aParser, err := parser.NewParser("select name, from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestStrictModeRejectsAMissingCommaBetweenFunctionParameters(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode()
+	aParser, err := parser.NewParser("select count(name foo) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	expected := fmt.Sprintf(messages.ErrorMessageMissingCommaInFunction, "count", "foo")
+	var parseError *parser.ParseError
+	if !errors.As(err, &parseError) || errors.Unwrap(err).Error() != expected {
+		t.Fatalf("Expected an error %v, received %v", expected, err)
+	}
+	if parseError.Column != 19 {
+		t.Fatalf("Expected the parse error at column 19, received %v", parseError.Column)
+	}
+}
+
+func TestStrictModeRejectsExtraFunctionParameters(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode()
+	aParser, err := parser.NewParser("select lower(name, foo) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	expected := fmt.Sprintf(messages.ErrorMessageTooManyParametersInFunction, "lower", 2)
+	if err == nil || errors.Unwrap(err) == nil || errors.Unwrap(err).Error() != expected {
+		t.Fatalf("Expected an error %v, received %v", expected, err)
+	}
+}
+
+func TestStrictModeRejectsATrailingCommaInFunctionParameters(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode()
+	aParser, err := parser.NewParser("select concat(name, ext,) from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	_, err = aParser.Parse()
+
+	expected := fmt.Sprintf(messages.ErrorMessageExpectedParameterInFunction, "concat", ")")
+	if err == nil || errors.Unwrap(err) == nil || errors.Unwrap(err).Error() != expected {
+		t.Fatalf("Expected an error %v, received %v", expected, err)
+	}
+}
+
+func TestLenientModeIgnoresAMissingCommaBetweenFunctionParameters(t *testing.T) {
+	queryResults := executeQuery(t, "select count(name foo) from ./resources/TestResultsWithProjections/single", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.Uint32Value(1)},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestStrictModeProjectsAConstant(t *testing.T) {
+	newContext := context.NewContext(context.NewFunctions(), context.NewAttributes()).InStrictMode()
+	aParser, err := parser.NewParser("select name, 5, 'x' from ./resources/TestResultsWithProjections/single", newContext)
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	selectQuery, err := aParser.Parse()
+	if err != nil {
+		t.Fatalf("error is %v", err)
+	}
+	queryResults, _ := executor.NewSelectQueryExecutor(selectQuery, newContext, executor.NewDefaultOptions()).Execute()
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.Int64Value(5), context.StringValue("x")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
+
+func TestLenientModeProjectsAConstant(t *testing.T) {
+	queryResults := executeQuery(t, "select name, 5, 'x' from ./resources/TestResultsWithProjections/single", executor.NewDefaultOptions())
+	expected := [][]context.Value{
+		{context.StringValue("TestResultsWithProjections_A.txt"), context.Int64Value(5), context.StringValue("x")},
+	}
+	executor.AssertMatch(t, expected, queryResults)
+}
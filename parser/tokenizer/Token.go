@@ -20,25 +20,63 @@ const (
 	Numeric                = 11
 	FloatingPoint          = 12
 	Boolean                = 13
+	Group                  = 14
+	Having                 = 15
+	Operator               = 16
+	Offset                 = 17
+	Union                  = 18
+	Except                 = 19
+	Intersect              = 20
+	Placeholder            = 21
 )
 
 var numericRegexp, _ = regexp.Compile("^[-+]?(?:0|[1-9][0-9]*)$")
 var floatingNumbersRegexp, _ = regexp.Compile("^(?:[-+]?[0-9]+)?(?:\\.[0-9]+)?(?:[eE][+\\-]?[0-9]+)?$")
 var booleanRegexp, _ = regexp.Compile("^true|false|y|n$")
+var namedPlaceholderRegexp, _ = regexp.Compile("^:[a-z_][a-z0-9_]*$")
+
+var operators = map[string]bool{
+	"=":  true,
+	"==": true,
+	"!=": true,
+	"<>": true,
+	"<":  true,
+	"<=": true,
+	">":  true,
+	">=": true,
+	"+":  true,
+	"-":  true,
+	"*":  true,
+	"/":  true,
+}
 
 type Token struct {
 	TokenType  int
 	TokenValue string
+	Offset     int
+	Column     int
+	bound      bool
+	quoted     bool
 }
 
 func (token Token) isEmpty() bool {
-	return len(token.TokenValue) == 0
+	return len(token.TokenValue) == 0 && !token.bound && !token.quoted
 }
 
 func NewToken(tokenType int, tokenValue string) Token {
 	return Token{TokenType: tokenType, TokenValue: tokenValue}
 }
 
+func NewBoundToken(value string, placeholder Token) Token {
+	return Token{
+		TokenType:  determineTokenType(strings.ToLower(value)),
+		TokenValue: value,
+		Offset:     placeholder.Offset,
+		Column:     placeholder.Column,
+		bound:      true,
+	}
+}
+
 func tokenFrom(token string) Token {
 	casedToken := strings.ToLower(token)
 	switch {
@@ -56,11 +94,36 @@ func tokenFrom(token string) Token {
 		return NewToken(DescendingOrder, token)
 	case casedToken == "limit":
 		return NewToken(Limit, token)
+	case casedToken == "offset":
+		return NewToken(Offset, token)
+	case casedToken == "union":
+		return NewToken(Union, token)
+	case casedToken == "except":
+		return NewToken(Except, token)
+	case casedToken == "intersect":
+		return NewToken(Intersect, token)
+	case casedToken == "group":
+		return NewToken(Group, token)
+	case casedToken == "having":
+		return NewToken(Having, token)
+	case operators[casedToken]:
+		return NewToken(Operator, token)
+	case casedToken == "?" || namedPlaceholderRegexp.MatchString(casedToken):
+		return NewToken(Placeholder, token)
 	default:
 		return NewToken(determineTokenType(casedToken), token)
 	}
 }
 
+// literalFrom keeps a quoted true, false, y or n as a string, it becomes a boolean only when compared with one.
+func literalFrom(token string) Token {
+	tokenType := determineTokenType(strings.ToLower(token))
+	if tokenType == Boolean {
+		tokenType = RawString
+	}
+	return Token{TokenType: tokenType, TokenValue: token, quoted: true}
+}
+
 func determineTokenType(token string) int {
 	if numericRegexp.MatchString(token) {
 		return Numeric
@@ -75,9 +138,36 @@ func determineTokenType(token string) int {
 }
 
 func (token Token) Equals(value string) bool {
+	if token.bound || token.quoted {
+		return false
+	}
 	return strings.EqualFold(strings.ToLower(token.TokenValue), strings.ToLower(value))
 }
 
+func (token Token) IsBound() bool {
+	return token.bound
+}
+
+func (token Token) IsQuoted() bool {
+	return token.quoted
+}
+
+func (token Token) IsAPlaceholder() bool {
+	return token.TokenType == Placeholder
+}
+
+func (token Token) IsAnOperator() bool {
+	return token.TokenType == Operator
+}
+
+func (token Token) IsANull() bool {
+	return !token.bound && !token.quoted && strings.EqualFold(token.TokenValue, "null")
+}
+
+func (token Token) IsANumber() bool {
+	return token.isNumeric() || token.isFloatingPoint()
+}
+
 func (token Token) isNumeric() bool {
 	return token.TokenType == Numeric
 }
@@ -0,0 +1,14 @@
+package tokenizer
+
+type TokenError struct {
+	Token Token
+	err   error
+}
+
+func (tokenError *TokenError) Error() string {
+	return tokenError.err.Error()
+}
+
+func (tokenError *TokenError) Unwrap() error {
+	return tokenError.err
+}
@@ -1,5 +1,5 @@
 package tokenizer
 
 func isCharATokenSeparator(ch rune) bool {
-	return ch == ' '
+	return ch == ' ' || ch == '\n' || ch == '\t' || ch == '\r'
 }
@@ -1,9 +1,15 @@
 package tokenizer
 
 import (
+	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
+var relativeTimeUnitRegexp, _ = regexp.Compile("^(?i)(?:second|minute|hour|day|week|month|year)s?$")
+
+var comparisonOperators = []string{"<=", ">=", "<>", "!=", "==", "=", "<", ">"}
+
 type Tokenizer struct {
 	query string
 }
@@ -17,59 +23,116 @@ func (tokenizer *Tokenizer) Tokenize() *Tokens {
 	queryLength := len(tokenizer.query)
 
 	var token strings.Builder
+	start := 0
 	for index := 0; index < queryLength; index++ {
 		ch := rune(tokenizer.query[index])
 		switch {
 		case isCharATokenSeparator(ch):
-			tokens.Add(tokenFrom(token.String()))
+			tokens.Add(tokenizer.at(tokenFrom(token.String()), start))
 			token.Reset()
 		case ch == '\\' && (index+1) < queryLength && tokenizer.query[index+1] == '\'':
 			literal, newIndex := tokenizer.readEmphasizedSingleQuotedLiteralFrom(index + 2)
+			tokens.Add(tokenizer.at(literal, index))
 			index = newIndex
-			tokens.Add(literal)
 			token.Reset()
 		case ch == '\\' && (index+1) < queryLength && tokenizer.query[index+1] == '"':
 			literal, newIndex := tokenizer.readEmphasizedDoubleQuotedLiteralFrom(index + 2)
+			tokens.Add(tokenizer.at(literal, index))
 			index = newIndex
-			tokens.Add(literal)
 			token.Reset()
 		case ch == '\'':
-			tokens.Add(tokenFrom(token.String()))
+			tokens.Add(tokenizer.at(tokenFrom(token.String()), start))
 			literal, newIndex := tokenizer.readSingleQuotedLiteralFrom(index + 1)
+			tokens.Add(tokenizer.at(literal, index))
 			index = newIndex
-			tokens.Add(literal)
 			token.Reset()
 		case ch == '"':
-			tokens.Add(tokenFrom(token.String()))
+			tokens.Add(tokenizer.at(tokenFrom(token.String()), start))
 			literal, newIndex := tokenizer.readDoubleQuotedLiteralFrom(index + 1)
+			tokens.Add(tokenizer.at(literal, index))
 			index = newIndex
-			tokens.Add(literal)
 			token.Reset()
 		case ch == ',':
-			tokens.Add(tokenFrom(token.String()))
-			tokens.Add(NewToken(Comma, string(ch)))
+			tokens.Add(tokenizer.at(tokenFrom(token.String()), start))
+			tokens.Add(tokenizer.at(NewToken(Comma, string(ch)), index))
 			token.Reset()
 		case ch == '(':
-			tokens.Add(tokenFrom(token.String()))
-			tokens.Add(NewToken(OpeningParentheses, string(ch)))
+			tokens.Add(tokenizer.at(tokenFrom(token.String()), start))
+			tokens.Add(tokenizer.at(NewToken(OpeningParentheses, string(ch)), index))
 			token.Reset()
 		case ch == ')':
-			tokens.Add(tokenFrom(token.String()))
-			tokens.Add(NewToken(ClosingParentheses, string(ch)))
+			tokens.Add(tokenizer.at(tokenFrom(token.String()), start))
+			tokens.Add(tokenizer.at(NewToken(ClosingParentheses, string(ch)), index))
+			token.Reset()
+		case len(tokenizer.comparisonOperatorAt(index)) > 0:
+			operator := tokenizer.comparisonOperatorAt(index)
+			tokens.Add(tokenizer.at(tokenFrom(token.String()), start))
+			tokens.Add(tokenizer.at(NewToken(Operator, operator), index))
+			index = index + len(operator) - 1
 			token.Reset()
 		default:
+			if token.Len() == 0 {
+				start = index
+			}
 			token.WriteRune(ch)
 		}
 	}
-	tokens.Add(tokenFrom(token.String()))
-	return tokens
+	tokens.Add(tokenizer.at(tokenFrom(token.String()), start))
+	return tokenizer.combineRelativeTimes(tokens)
+}
+
+/*
+comparison: a comparison operator is a token of its own even without the spaces around it, so size>2 is gt(size, 2)
+*/
+func (tokenizer *Tokenizer) comparisonOperatorAt(index int) string {
+	for _, operator := range comparisonOperators {
+		if strings.HasPrefix(tokenizer.query[index:], operator) {
+			return operator
+		}
+	}
+	return ""
+}
+
+/*
+relative time: <number> <unit> ago, like 7 days ago, becomes a single token
+start of:      start of <unit>, like start of month, becomes a single token
+*/
+func (tokenizer *Tokenizer) combineRelativeTimes(tokens *Tokens) *Tokens {
+	combined := NewEmptyTokens()
+	for index := 0; index < len(tokens.tokens); index++ {
+		if index+2 < len(tokens.tokens) && isARelativeTime(tokens.tokens[index:index+3]) {
+			words := []string{tokens.tokens[index].TokenValue, tokens.tokens[index+1].TokenValue, tokens.tokens[index+2].TokenValue}
+			combined.Add(tokenizer.at(NewToken(RawString, strings.Join(words, " ")), tokens.tokens[index].Offset))
+			index = index + 2
+			continue
+		}
+		combined.Add(tokens.tokens[index])
+	}
+	return combined
+}
+
+func isARelativeTime(tokens []Token) bool {
+	switch {
+	case tokens[0].isNumeric() && relativeTimeUnitRegexp.MatchString(tokens[1].TokenValue) && tokens[2].Equals("ago"):
+		return true
+	case tokens[0].Equals("start") && tokens[1].Equals("of") && relativeTimeUnitRegexp.MatchString(tokens[2].TokenValue):
+		return true
+	}
+	return false
+}
+
+func (tokenizer *Tokenizer) at(token Token, offset int) Token {
+	lineStart := strings.LastIndex(tokenizer.query[:offset], "\n") + 1
+	token.Offset = offset
+	token.Column = utf8.RuneCountInString(tokenizer.query[lineStart:offset]) + 1
+	return token
 }
 
 func (tokenizer *Tokenizer) readSingleQuotedLiteralFrom(index int) (Token, int) {
 	token, nextIndex := tokenizer.readQuotedLiteral(index, func(ch rune) bool {
 		return ch == '\''
 	})
-	return tokenFrom(eatBackSlash(token)), nextIndex
+	return literalFrom(eatBackSlash(token)), nextIndex
 }
 
 func (tokenizer *Tokenizer) readEmphasizedSingleQuotedLiteralFrom(index int) (Token, int) {
@@ -83,7 +146,7 @@ func (tokenizer *Tokenizer) readDoubleQuotedLiteralFrom(index int) (Token, int)
 	token, nextIndex := tokenizer.readQuotedLiteral(index, func(ch rune) bool {
 		return ch == '"'
 	})
-	return tokenFrom(eatBackSlash(token)), nextIndex
+	return literalFrom(eatBackSlash(token)), nextIndex
 }
 
 func (tokenizer *Tokenizer) readEmphasizedDoubleQuotedLiteralFrom(index int) (Token, int) {
@@ -108,6 +171,22 @@ func (tokenizer *Tokenizer) readQuotedLiteral(index int, breakOn func(ch rune) b
 	return token, runningIndex
 }
 
+/*
+escape: a backslash before a quote, a space or the closing quote is dropped and every other backslash is kept
+example: '(\w+)\.log' keeps its backslashes, \"file (1).txt\" becomes file (1).txt
+*/
 func eatBackSlash(token strings.Builder) string {
-	return strings.ReplaceAll(token.String(), "\\", "")
+	literal := token.String()
+	var unescaped strings.Builder
+	for index := 0; index < len(literal); index++ {
+		if literal[index] == '\\' && (index+1 == len(literal) || isAnEscapedCharacter(literal[index+1])) {
+			continue
+		}
+		unescaped.WriteByte(literal[index])
+	}
+	return unescaped.String()
+}
+
+func isAnEscapedCharacter(ch byte) bool {
+	return ch == '\'' || ch == '"' || ch == ' '
 }
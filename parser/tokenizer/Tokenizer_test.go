@@ -4,6 +4,7 @@
 package tokenizer
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -143,7 +144,7 @@ func TestTokenizerWithAllTokens8(t *testing.T) {
 	tokens := tokenizer.Tokenize()
 
 	iterator := tokens.Iterator()
-	expectedTokens := []string{"select", "size", "from", "/home/apps", "where", "name=*.txt", "order", "by", "modified"}
+	expectedTokens := []string{"select", "size", "from", "/home/apps", "where", "name", "=", "*.txt", "order", "by", "modified"}
 
 	for count := 1; count <= len(expectedTokens); count++ {
 		actualToken := iterator.Next()
@@ -159,7 +160,7 @@ func TestTokenizerWithAllTokens9(t *testing.T) {
 	tokens := tokenizer.Tokenize()
 
 	iterator := tokens.Iterator()
-	expectedTokens := []string{"select", "size", "from", "/home/apps", "where", "name=", "*.txt", "order", "by", "1"}
+	expectedTokens := []string{"select", "size", "from", "/home/apps", "where", "name", "=", "*.txt", "order", "by", "1"}
 
 	for count := 1; count <= len(expectedTokens); count++ {
 		actualToken := iterator.Next()
@@ -175,7 +176,7 @@ func TestTokenizerWithAllTokens10(t *testing.T) {
 	tokens := tokenizer.Tokenize()
 
 	iterator := tokens.Iterator()
-	expectedTokens := []string{"select", "size", "from", "/home/apps", "where", "name=", "*.txt", "order", "by", "1", "asc"}
+	expectedTokens := []string{"select", "size", "from", "/home/apps", "where", "name", "=", "*.txt", "order", "by", "1", "asc"}
 
 	for count := 1; count <= len(expectedTokens); count++ {
 		actualToken := iterator.Next()
@@ -191,7 +192,7 @@ func TestTokenizerWithAllTokens11(t *testing.T) {
 	tokens := tokenizer.Tokenize()
 
 	iterator := tokens.Iterator()
-	expectedTokens := []string{"select", "size", "from", "/home/apps", "where", "name=", "*.txt", "order", "by", "1", "desc"}
+	expectedTokens := []string{"select", "size", "from", "/home/apps", "where", "name", "=", "*.txt", "order", "by", "1", "desc"}
 
 	for count := 1; count <= len(expectedTokens); count++ {
 		actualToken := iterator.Next()
@@ -207,7 +208,7 @@ func TestTokenizerWithAllTokens12(t *testing.T) {
 	tokens := tokenizer.Tokenize()
 
 	iterator := tokens.Iterator()
-	expectedTokens := []string{"select", "1", "*", "2", ",", "name", "from", "/home/apps", "where", "size>1000"}
+	expectedTokens := []string{"select", "1", "*", "2", ",", "name", "from", "/home/apps", "where", "size", ">", "1000"}
 
 	for count := 1; count <= len(expectedTokens); count++ {
 		actualToken := iterator.Next()
@@ -434,6 +435,66 @@ func TestTokenizerWithAllTokens23(t *testing.T) {
 	}
 }
 
+func TestTokenizerKeepsBackslashesInsideQuotedLiterals(t *testing.T) {
+	tokenizer := NewTokenizer("select regexextract(name, '(\\w+)\\.(\\d+)', 2) from .")
+	tokens := tokenizer.Tokenize()
+
+	iterator := tokens.Iterator()
+	expectedTokens := []string{"select", "regexextract", "(", "name", ",", "(\\w+)\\.(\\d+)", ",", "2", ")", "from", "."}
+
+	for count := 1; count <= len(expectedTokens); count++ {
+		actualToken := iterator.Next()
+		expectedToken := expectedTokens[count-1]
+
+		if expectedToken != actualToken.TokenValue {
+			t.Fatalf("Expected token to be %v, received %v", expectedToken, actualToken)
+		}
+	}
+}
+
+func TestTokenizerWithQuotedLiteralsAsValues(t *testing.T) {
+	tokenizer := NewTokenizer("select name from . where eq(name, 'from')")
+	tokens := tokenizer.Tokenize()
+
+	iterator := tokens.Iterator()
+	for count := 1; count <= 9; count++ {
+		iterator.Next()
+	}
+	literal := iterator.Next()
+	if !literal.IsQuoted() || literal.Equals("from") || literal.TokenType != RawString {
+		t.Fatalf("Expected a quoted literal that is not the keyword from, received %v", literal)
+	}
+}
+
+func TestTokenizerKeepsAnEmptyQuotedLiteral(t *testing.T) {
+	tokenizer := NewTokenizer("select concat(name, '', ext) from .")
+	tokens := tokenizer.Tokenize()
+
+	iterator := tokens.Iterator()
+	expectedTokens := []string{"select", "concat", "(", "name", ",", "", ",", "ext", ")", "from", "."}
+
+	for count := 1; count <= len(expectedTokens); count++ {
+		actualToken := iterator.Next()
+		expectedToken := expectedTokens[count-1]
+
+		if expectedToken != actualToken.TokenValue {
+			t.Fatalf("Expected token to be %v, received %v", expectedToken, actualToken)
+		}
+	}
+	if iterator.HasNext() {
+		t.Fatalf("Expected no more tokens, received %v", iterator.Next())
+	}
+}
+
+func TestTokenizerWithAQuotedBooleanAsAString(t *testing.T) {
+	tokenizer := NewTokenizer("'y'")
+	literal := tokenizer.Tokenize().Iterator().Next()
+
+	if !literal.IsQuoted() || literal.TokenType != RawString {
+		t.Fatalf("Expected a quoted literal of type raw string, received %v", literal)
+	}
+}
+
 func TestTokenEquality1(t *testing.T) {
 	nameToken := NewToken(RawString, "name")
 	equals := nameToken.Equals("NAME")
@@ -460,3 +521,251 @@ func TestTokenEquality3(t *testing.T) {
 		t.Fatalf("Expected token equality to be false but was true")
 	}
 }
+
+func TestTokenizerWithInfixOperators(t *testing.T) {
+	tokenizer := NewTokenizer("select size / 1024 from . where size >= 1mb and (ext = '.log' or name != a)")
+	tokens := tokenizer.Tokenize()
+
+	iterator := tokens.Iterator()
+	expectedTokens := []Token{
+		NewToken(RawString, "select"),
+		NewToken(RawString, "size"),
+		NewToken(Operator, "/"),
+		NewToken(Numeric, "1024"),
+		NewToken(From, "from"),
+		NewToken(RawString, "."),
+		NewToken(Where, "where"),
+		NewToken(RawString, "size"),
+		NewToken(Operator, ">="),
+		NewToken(RawString, "1mb"),
+		NewToken(RawString, "and"),
+		NewToken(OpeningParentheses, "("),
+		NewToken(RawString, "ext"),
+		NewToken(Operator, "="),
+		NewToken(RawString, ".log"),
+		NewToken(RawString, "or"),
+		NewToken(RawString, "name"),
+		NewToken(Operator, "!="),
+		NewToken(RawString, "a"),
+		NewToken(ClosingParentheses, ")"),
+	}
+
+	for count := 1; count <= len(expectedTokens); count++ {
+		actualToken := iterator.Next()
+		expectedToken := expectedTokens[count-1]
+
+		if expectedToken.TokenType != actualToken.TokenType || expectedToken.TokenValue != actualToken.TokenValue {
+			t.Fatalf("Expected token to be %v, received %v", expectedToken, actualToken)
+		}
+	}
+}
+
+func TestTokenizerWithAnOperatorInsideAQuotedLiteral(t *testing.T) {
+	tokenizer := NewTokenizer("select name from . where name = '>'")
+	tokens := tokenizer.Tokenize()
+
+	iterator := tokens.Iterator()
+	var lastToken Token
+	for iterator.HasNext() {
+		lastToken = iterator.Next()
+	}
+	expectedToken := NewToken(RawString, ">")
+	if expectedToken.TokenType != lastToken.TokenType || expectedToken.TokenValue != lastToken.TokenValue {
+		t.Fatalf("Expected token to be %v, received %v", expectedToken, lastToken)
+	}
+}
+
+func TestTokenizerWithAnOperatorAsAPartOfAToken(t *testing.T) {
+	tokenizer := NewTokenizer("select name from /home/apps-1 where like(name, a*b)")
+	tokens := tokenizer.Tokenize()
+
+	iterator := tokens.Iterator()
+	for iterator.HasNext() {
+		token := iterator.Next()
+		if token.IsAnOperator() {
+			t.Fatalf("Expected no operator token, received %v", token)
+		}
+	}
+}
+
+func TestTokenizerWithLimitAndOffset(t *testing.T) {
+	tokenizer := NewTokenizer("select name from . limit 10 offset 20")
+	tokens := tokenizer.Tokenize()
+
+	iterator := tokens.Iterator()
+	expectedTokens := []string{"select", "name", "from", ".", "limit", "10", "offset", "20"}
+	expectedTokenTypes := []int{RawString, RawString, From, RawString, Limit, Numeric, Offset, Numeric}
+
+	for count := 1; count <= len(expectedTokens); count++ {
+		actualToken := iterator.Next()
+		expectedToken := expectedTokens[count-1]
+		expectedTokenType := expectedTokenTypes[count-1]
+
+		if expectedToken != actualToken.TokenValue {
+			t.Fatalf("Expected token to be %v, received %v", expectedToken, actualToken)
+		}
+		if expectedTokenType != actualToken.TokenType {
+			t.Fatalf("Expected token type to be %v, received %v", expectedTokenType, actualToken.TokenType)
+		}
+	}
+}
+
+func TestTokenizerWithSetOperators(t *testing.T) {
+	tokenizer := NewTokenizer("select name from a union all select name from b except select name from c intersect select name from d")
+	tokens := tokenizer.Tokenize()
+
+	var actualTokenTypes []int
+	for iterator := tokens.Iterator(); iterator.HasNext(); {
+		token := iterator.Next()
+		if token.TokenType == Union || token.TokenType == Except || token.TokenType == Intersect {
+			actualTokenTypes = append(actualTokenTypes, token.TokenType)
+		}
+	}
+	expectedTokenTypes := []int{Union, Except, Intersect}
+	if !reflect.DeepEqual(expectedTokenTypes, actualTokenTypes) {
+		t.Fatalf("Expected token types to be %v, received %v", expectedTokenTypes, actualTokenTypes)
+	}
+}
+
+func TestTokenizerWithPlaceholders(t *testing.T) {
+	tokenizer := NewTokenizer("select name from . where eq(name, ?) and eq(ext, :ext) and eq(size, '?') and eq(basename, a?b)")
+	tokens := tokenizer.Tokenize()
+
+	var placeholders []string
+	for iterator := tokens.Iterator(); iterator.HasNext(); {
+		token := iterator.Next()
+		if token.IsAPlaceholder() {
+			placeholders = append(placeholders, token.TokenValue)
+		}
+	}
+	expected := []string{"?", ":ext"}
+	if !reflect.DeepEqual(expected, placeholders) {
+		t.Fatalf("Expected placeholders to be %v, received %v", expected, placeholders)
+	}
+}
+
+func TestBoundTokenDoesNotMatchAKeyword(t *testing.T) {
+	token := NewBoundToken("from", NewToken(Placeholder, "?"))
+
+	if token.Equals("from") {
+		t.Fatalf("Expected a bound token to not match the keyword from")
+	}
+}
+
+func TestBoundTokenIsTyped(t *testing.T) {
+	token := NewBoundToken("1024", NewToken(Placeholder, "?"))
+
+	if token.TokenType != Numeric {
+		t.Fatalf("Expected a bound token to have the type %v, received %v", Numeric, token.TokenType)
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	tokenizer := NewTokenizer("select lower(name) from 'a b'")
+	tokens := tokenizer.Tokenize()
+
+	var offsets, columns []int
+	for iterator := tokens.Iterator(); iterator.HasNext(); {
+		token := iterator.Next()
+		offsets = append(offsets, token.Offset)
+		columns = append(columns, token.Column)
+	}
+	expectedOffsets := []int{0, 7, 12, 13, 17, 19, 24}
+	if !reflect.DeepEqual(expectedOffsets, offsets) {
+		t.Fatalf("Expected offsets to be %v, received %v", expectedOffsets, offsets)
+	}
+	expectedColumns := []int{1, 8, 13, 14, 18, 20, 25}
+	if !reflect.DeepEqual(expectedColumns, columns) {
+		t.Fatalf("Expected columns to be %v, received %v", expectedColumns, columns)
+	}
+}
+
+func TestBoundTokenTakesThePositionOfThePlaceholder(t *testing.T) {
+	tokens := NewTokenizer("select name from . where eq(name, ?)").Tokenize()
+	iterator := tokens.Iterator()
+	var placeholder Token
+	for iterator.HasNext() {
+		if token := iterator.Next(); token.IsAPlaceholder() {
+			placeholder = token
+		}
+	}
+	token := NewBoundToken("a", placeholder)
+
+	if token.Offset != 34 || token.Column != 35 {
+		t.Fatalf("Expected the bound token at offset 34 and column 35, received %v and %v", token.Offset, token.Column)
+	}
+}
+
+func TestTokenizerWithRelativeTimes(t *testing.T) {
+	tokens := NewTokenizer("select name from . where lt(mtime, 7 Days ago) and gt(mtime, start of month)").Tokenize()
+
+	var actualTokens []string
+	for iterator := tokens.Iterator(); iterator.HasNext(); {
+		actualTokens = append(actualTokens, iterator.Next().TokenValue)
+	}
+	expectedTokens := []string{"select", "name", "from", ".", "where", "lt", "(", "mtime", ",", "7 Days ago", ")", "and", "gt", "(", "mtime", ",", "start of month", ")"}
+	if !reflect.DeepEqual(expectedTokens, actualTokens) {
+		t.Fatalf("Expected tokens to be %v, received %v", expectedTokens, actualTokens)
+	}
+}
+
+func TestTokenizerWithARelativeTimePosition(t *testing.T) {
+	tokens := NewTokenizer("select name from . where mtime < 30 days ago").Tokenize()
+	iterator := tokens.Iterator()
+	var token Token
+	for iterator.HasNext() {
+		token = iterator.Next()
+	}
+
+	if token.TokenValue != "30 days ago" || token.Offset != 33 || token.Column != 34 {
+		t.Fatalf("Expected the token 30 days ago at offset 33 and column 34, received %v at %v and %v", token.TokenValue, token.Offset, token.Column)
+	}
+}
+
+func TestTokenizerWithoutARelativeTime(t *testing.T) {
+	tokens := NewTokenizer("select name from . where eq(7, days) limit 7 offset 2").Tokenize()
+	expectedTokenCount := 15
+
+	if tokens.count() != expectedTokenCount {
+		t.Fatalf("Expected token count %v, received %v", expectedTokenCount, tokens.count())
+	}
+}
+
+func TestTokenizerWithNewLinesAndTabs(t *testing.T) {
+	tokenizer := NewTokenizer("select name\nfrom\t/home/apps\r\nwhere eq(ext, '.log\n')")
+	tokens := tokenizer.Tokenize()
+
+	iterator := tokens.Iterator()
+	expectedTokens := []string{"select", "name", "from", "/home/apps", "where", "eq", "(", "ext", ",", ".log\n", ")"}
+
+	for count := 1; count <= len(expectedTokens); count++ {
+		actualToken := iterator.Next()
+		expectedToken := expectedTokens[count-1]
+
+		if expectedToken != actualToken.TokenValue {
+			t.Fatalf("Expected token to be %v, received %v", expectedToken, actualToken)
+		}
+	}
+	if iterator.HasNext() {
+		t.Fatalf("Expected no more tokens, received %v", iterator.Next())
+	}
+}
+
+func TestTokenizerWithComparisonOperatorsWithoutSpaces(t *testing.T) {
+	tokenizer := NewTokenizer("select name from . where size>=2 and name!=a.log or size<>3")
+	tokens := tokenizer.Tokenize()
+
+	iterator := tokens.Iterator()
+	expectedTokens := []string{"select", "name", "from", ".", "where", "size", ">=", "2", "and", "name", "!=", "a.log", "or", "size", "<>", "3"}
+
+	for count := 1; count <= len(expectedTokens); count++ {
+		actualToken := iterator.Next()
+		expectedToken := expectedTokens[count-1]
+		if expectedToken != actualToken.TokenValue {
+			t.Fatalf("Expected token to be %v, received %v", expectedToken, actualToken)
+		}
+		if actualToken.TokenValue == ">=" && !actualToken.IsAnOperator() {
+			t.Fatalf("Expected token %v to be an operator", actualToken.TokenValue)
+		}
+	}
+}
@@ -41,3 +41,21 @@ func (tokenIterator *TokenIterator) Peek() Token {
 	token := tokenIterator.tokens[tokenIterator.index]
 	return token
 }
+
+func (tokenIterator *TokenIterator) Last() (Token, bool) {
+	if tokenIterator.index == 0 {
+		return Token{}, false
+	}
+	return tokenIterator.tokens[tokenIterator.index-1], true
+}
+
+func (tokenIterator *TokenIterator) ErrorAtNext(err error) error {
+	if !tokenIterator.HasNext() {
+		return err
+	}
+	return &TokenError{Token: tokenIterator.Peek(), err: err}
+}
+
+func (tokenIterator *TokenIterator) Clone() *TokenIterator {
+	return &TokenIterator{index: tokenIterator.index, tokens: tokenIterator.tokens}
+}
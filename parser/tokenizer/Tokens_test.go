@@ -3,7 +3,10 @@
 
 package tokenizer
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestTokenIteratorWithNoNextToken(t *testing.T) {
 	tokens := NewEmptyTokens()
@@ -350,3 +353,35 @@ func TestTokenTypeIsBoolean(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorAtNextCarriesThePeekedToken(t *testing.T) {
+	tokens := NewEmptyTokens()
+	tokens.Add(NewToken(RawString, "select"))
+	tokens.Add(NewToken(RawString, "junk"))
+	iterator := tokens.Iterator()
+	iterator.Next()
+
+	err := iterator.ErrorAtNext(errors.New("unexpected token"))
+
+	var tokenError *TokenError
+	if !errors.As(err, &tokenError) {
+		t.Fatalf("Expected a token error, received %v", err)
+	}
+	if tokenError.Token.TokenValue != "junk" || err.Error() != "unexpected token" {
+		t.Fatalf("Expected the token error to carry junk and the original message, received %v and %v", tokenError.Token.TokenValue, err.Error())
+	}
+}
+
+func TestErrorAtNextWithoutANextToken(t *testing.T) {
+	tokens := NewEmptyTokens()
+	tokens.Add(NewToken(RawString, "select"))
+	iterator := tokens.Iterator()
+	iterator.Next()
+
+	err := iterator.ErrorAtNext(errors.New("unexpected token"))
+
+	var tokenError *TokenError
+	if errors.As(err, &tokenError) {
+		t.Fatalf("Expected no token error given no next token, received %v", tokenError)
+	}
+}
@@ -2,7 +2,6 @@ package where
 
 import (
 	"errors"
-	"fmt"
 	"goselect/parser/context"
 	"goselect/parser/error/messages"
 	"goselect/parser/expression"
@@ -27,6 +26,16 @@ func NewWhere(
 	}
 }
 
+func NewWhereWith(anExpression *expression.Expression, ctx *context.ParsingApplicationContext) (*Where, error) {
+	if anExpression.HasAnAggregate() {
+		return nil, errors.New(messages.ErrorMessageAggregateFunctionInsideWhere)
+	}
+	if functionName := anExpression.FunctionName(); !ctx.IsASupportedFunction(functionName) || !ctx.FunctionContainsATag(functionName, "where") {
+		return nil, errors.New(messages.ErrorMessageInvalidWhereFunctionUsed)
+	}
+	return &Where{expressions: expression.Expressions{Expressions: []*expression.Expression{anExpression}}}, nil
+}
+
 func (where Where) Display() string {
 	if attributes := where.expressions.DisplayableAttributes(); len(attributes) >= 1 {
 		return attributes[0]
@@ -34,6 +43,18 @@ func (where Where) Display() string {
 	return ""
 }
 
+func (where Where) Expression() *expression.Expression {
+	return where.expressions.ExpressionAt(0)
+}
+
+func (where Where) Subqueries() []*expression.Subquery {
+	var subqueries []*expression.Subquery
+	for _, anExpression := range where.expressions.Expressions {
+		subqueries = append(subqueries, anExpression.Subqueries()...)
+	}
+	return subqueries
+}
+
 func (where Where) EvaluateWith(
 	fileAttributes *context.FileAttributes,
 	functions *context.AllFunctions,
@@ -66,81 +87,30 @@ func all(
 	if tokenIterator.HasNext() && tokenIterator.Peek().Equals("where") {
 		tokenIterator.Next()
 	}
+	parser := expression.NewParser(tokenIterator, ctx, expression.ParsingRules{
+		AllowAggregates:       false,
+		AllowSubqueries:       true,
+		AggregateErrorMessage: messages.ErrorMessageAggregateFunctionInsideWhere,
+		InvalidErrorMessage:   messages.ErrorMessageInvalidWhere,
+	})
 	for tokenIterator.HasNext() &&
+		!tokenIterator.Peek().Equals("group") &&
+		!tokenIterator.Peek().Equals("having") &&
 		!tokenIterator.Peek().Equals("order") &&
 		!tokenIterator.Peek().Equals("limit") {
 
 		token := tokenIterator.Next()
-		switch {
-		case ctx.IsASupportedFunction(token.TokenValue) && ctx.FunctionContainsATag(token.TokenValue, "where"):
-			if function, err := function(token, tokenIterator, ctx); err != nil {
-				return expression.Expressions{}, true, err
-			} else {
-				expressions = append(expressions, expression.WithFunctionInstance(function))
-			}
-		default:
+		if len(expressions) > 0 {
 			return expression.Expressions{}, true, errors.New(messages.ErrorMessageInvalidWhereFunctionUsed)
 		}
-	}
-	return expression.Expressions{Expressions: expressions}, true, nil
-}
-
-func function(
-	functionNameToken tokenizer.Token,
-	tokenIterator *tokenizer.TokenIterator,
-	ctx *context.ParsingApplicationContext,
-) (*expression.FunctionInstance, error) {
-
-	var parseFunction func(functionNameToken tokenizer.Token) (*expression.FunctionInstance, error)
-	parseFunction = func(functionNameToken tokenizer.Token) (*expression.FunctionInstance, error) {
-		var functionArgs []*expression.Expression
-		expectOpeningParentheses := true
-
-		for tokenIterator.HasNext() && !tokenIterator.Peek().Equals("order") {
-			token := tokenIterator.Next()
-			switch {
-			case expectOpeningParentheses:
-				if !token.Equals("(") {
-					return nil, fmt.Errorf(messages.ErrorMessageOpeningParenthesesProjection, functionNameToken.TokenValue)
-				}
-				expectOpeningParentheses = false
-			case token.Equals(")"):
-				return expression.FunctionInstanceWith(
-					functionNameToken.TokenValue,
-					functionArgs,
-					nil,
-					false,
-				), nil
-			case ctx.IsASupportedFunction(token.TokenValue):
-				if ctx.IsAnAggregateFunction(token.TokenValue) {
-					return nil, errors.New(messages.ErrorMessageAggregateFunctionInsideWhere)
-				}
-				fn, err := parseFunction(token)
-				if err != nil {
-					return nil, err
-				}
-				functionArgs = append(functionArgs, expression.WithFunctionInstance(fn))
-			case ctx.IsASupportedAttribute(token.TokenValue):
-				functionArgs = append(functionArgs, expression.WithAttribute(token.TokenValue))
-				expectOpeningParentheses = false
-			default:
-				if !token.Equals(",") {
-					value, err := context.ToValue(token)
-					if err != nil {
-						value = context.StringValue(token.TokenValue)
-					}
-					functionArgs = append(functionArgs, expression.WithValue(value))
-				}
-			}
+		anExpression, err := parser.ParseFrom(token)
+		if err != nil {
+			return expression.Expressions{}, true, err
 		}
-		return nil, nil
-	}
-
-	if fn, err := parseFunction(functionNameToken); err != nil {
-		return nil, err
-	} else if fn == nil {
-		return nil, errors.New(messages.ErrorMessageInvalidWhere)
-	} else {
-		return fn, nil
+		if functionName := anExpression.FunctionName(); !ctx.IsASupportedFunction(functionName) || !ctx.FunctionContainsATag(functionName, "where") {
+			return expression.Expressions{}, true, errors.New(messages.ErrorMessageInvalidWhereFunctionUsed)
+		}
+		expressions = append(expressions, anExpression)
 	}
+	return expression.Expressions{Expressions: expressions}, true, nil
 }
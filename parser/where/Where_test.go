@@ -5,6 +5,7 @@ package where
 
 import (
 	"goselect/parser/context"
+	"goselect/parser/error/messages"
 	"goselect/parser/tokenizer"
 	"testing"
 )
@@ -107,6 +108,62 @@ func TestWhereWithLimitKeywordAfterWhere(t *testing.T) {
 	}
 }
 
+func TestWhereWithGroupKeywordAfterWhere(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "where"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "eq"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "group"))
+
+	where, _ := NewWhere(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := "eq(1,1)"
+
+	if expected != where.Display() {
+		t.Fatalf("Expected where clause to be %v, received %v", expected, where.Display())
+	}
+}
+
+func TestWhereWithHavingKeywordAfterWhere(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "where"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "eq"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "having"))
+
+	where, _ := NewWhere(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := "eq(1,1)"
+
+	if expected != where.Display() {
+		t.Fatalf("Expected where clause to be %v, received %v", expected, where.Display())
+	}
+}
+
+func TestWhereWithAnUnsupportedKeywordAfterWhere(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "where"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "eq"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Comma, ","))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "grouped"))
+
+	_, err := NewWhere(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+
+	if err == nil || err.Error() != messages.ErrorMessageInvalidWhereFunctionUsed {
+		t.Fatalf("Expected error %v, received %v", messages.ErrorMessageInvalidWhereFunctionUsed, err)
+	}
+}
+
 func TestWhere(t *testing.T) {
 	tokens := tokenizer.NewEmptyTokens()
 	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "where"))
@@ -401,3 +458,72 @@ func TestEvaluatesWhereWithoutAnyCondition(t *testing.T) {
 		t.Fatalf("Expected where clause to evaluate to true but it did not")
 	}
 }
+
+func TestWhereWithInfixOperators(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Where, "where"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Operator, ">"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "10"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "and"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "name"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "like"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "a.*"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Order, "order"))
+
+	where, _ := NewWhere(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := "and(gt(size,10),like(name,a.*))"
+
+	if expected != where.Display() {
+		t.Fatalf("Expected where clause to be %v, received %v", expected, where.Display())
+	}
+}
+
+func TestWhereWithAnInfixOperatorNotSupportedInWhere(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Where, "where"))
+	tokens.Add(tokenizer.NewToken(tokenizer.RawString, "size"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Operator, "+"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "10"))
+
+	_, err := NewWhere(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	if err == nil {
+		t.Fatalf("Expected an error given where clause with an arithmetic expression")
+	}
+}
+
+func TestEvaluatesWhereClauseWithInfixOperators(t *testing.T) {
+	tokens := tokenizer.NewEmptyTokens()
+	tokens.Add(tokenizer.NewToken(tokenizer.Where, "where"))
+	tokens.Add(tokenizer.NewToken(tokenizer.OpeningParentheses, "("))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "1"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Operator, "+"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "2"))
+	tokens.Add(tokenizer.NewToken(tokenizer.ClosingParentheses, ")"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Operator, "*"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "2"))
+	tokens.Add(tokenizer.NewToken(tokenizer.Operator, "="))
+	tokens.Add(tokenizer.NewToken(tokenizer.Numeric, "6"))
+
+	functions := context.NewFunctions()
+	where, _ := NewWhere(tokens.Iterator(), context.NewContext(functions, context.NewAttributes()))
+	result, _ := where.EvaluateWith(nil, functions)
+
+	if result != true {
+		t.Fatalf("Expected where clause to evaluate to true but was not")
+	}
+}
+
+func TestWhereWithASubquery(t *testing.T) {
+	tokens := tokenizer.NewTokenizer("where basename in (select basename from .)").Tokenize()
+
+	where, _ := NewWhere(tokens.Iterator(), context.NewContext(context.NewFunctions(), context.NewAttributes()))
+	expected := "in(basename,(select basename from .))"
+
+	if expected != where.Display() {
+		t.Fatalf("Expected where clause to be %v, received %v", expected, where.Display())
+	}
+	if len(where.Subqueries()) != 1 {
+		t.Fatalf("Expected 1 subquery in where, received %v", len(where.Subqueries()))
+	}
+}
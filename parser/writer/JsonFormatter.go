@@ -24,6 +24,9 @@ func (jsonFormatter JsonFormatter) Format(projections *projection.Projections, r
 		return value.String()
 	}
 	attributeValueAsString := func(attributeValue context.Value) string {
+		if attributeValue.IsNull() {
+			return "null"
+		}
 		var value strings.Builder
 		value.WriteString("\"")
 		value.WriteString(attributeValue.GetAsString())